@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/provider"
+)
+
+// target is an object of the tenant to import: the import block addresses it
+// by importID, or by identity for the resources that are only read back by
+// their identity.
+type target struct {
+	typeName string
+	importID string
+	identity map[string]string
+	// name is the platform name, from which the resource label is derived.
+	name string
+	// vm is the target of the virtual machine a child is attached to: its
+	// virtual_machine_id is rendered as a reference to that resource.
+	vm *target
+	// known are attribute values the walk knows but the read of an imported
+	// object leaves empty (the virtual machine of an OpenIaaS disk). They are
+	// rendered only when the state does not have them.
+	known map[string]string
+
+	label string
+}
+
+// walker discovers the objects of one product of the tenant.
+type walker struct {
+	name        string
+	description string
+	walk        func(ctx context.Context, c *client.Client) ([]*target, error)
+}
+
+// walkers is every product ct-import knows how to walk. The listings are
+// strict ones wherever the client has one: a partial listing would silently
+// leave objects out of the generated configuration.
+var walkers = []walker{
+	{
+		name:        "vmware",
+		description: "VMware virtual machines, with their disks, network adapters and controllers",
+		walk:        walkVMware,
+	},
+	{
+		name:        "openiaas",
+		description: "OpenIaaS virtual machines, with their disks and network adapters",
+		walk:        walkOpenIaaS,
+	},
+	{
+		name:        "openiaas-replication",
+		description: "OpenIaaS replication policies",
+		walk:        walkReplicationPolicies,
+	},
+	{
+		name:        "public-cloud",
+		description: "public cloud instances, with their data disks and secondary network adapters",
+		walk:        walkPublicCloud,
+	},
+	{
+		name:        "object-storage",
+		description: "object storage buckets, with their ACL entries",
+		walk:        walkObjectStorage,
+	},
+	{
+		name:        "vpc",
+		description: "VPC static IPs and floating IPs",
+		walk:        walkVPC,
+	},
+}
+
+// selectWalkers resolves the -types flag: a comma-separated list of walker
+// names, or "all".
+func selectWalkers(types string) ([]walker, error) {
+	if strings.TrimSpace(types) == "all" {
+		return walkers, nil
+	}
+
+	wanted := map[string]bool{}
+	for _, name := range strings.Split(types, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			wanted[name] = true
+		}
+	}
+
+	var selected []walker
+	for _, w := range walkers {
+		if wanted[w.name] {
+			selected = append(selected, w)
+			delete(wanted, w.name)
+		}
+	}
+	for name := range wanted {
+		return nil, fmt.Errorf("unknown type %q (see -list)", name)
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no type selected")
+	}
+	return selected, nil
+}
+
+func walkVMware(ctx context.Context, c *client.Client) ([]*target, error) {
+	vms, err := c.Compute().VirtualMachine().ListStrict(ctx, &client.VirtualMachineFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("listing virtual machines: %w", err)
+	}
+
+	var targets []*target
+	for _, vm := range vms {
+		parent := &target{typeName: "cloudtemple_compute_virtual_machine", importID: vm.ID, name: vm.Name}
+		targets = append(targets, parent)
+
+		disks, err := c.Compute().VirtualDisk().ListStrict(ctx, &client.VirtualDiskFilter{VirtualMachineID: vm.ID})
+		if err != nil {
+			return nil, fmt.Errorf("listing the disks of virtual machine %s: %w", vm.ID, err)
+		}
+		for _, disk := range disks {
+			targets = append(targets, &target{
+				typeName: "cloudtemple_compute_virtual_disk",
+				importID: disk.ID,
+				name:     vm.Name + "_" + disk.Name,
+				vm:       parent,
+			})
+		}
+
+		adapters, err := c.Compute().NetworkAdapter().ListStrict(ctx, &client.NetworkAdapterFilter{VirtualMachineID: vm.ID})
+		if err != nil {
+			return nil, fmt.Errorf("listing the network adapters of virtual machine %s: %w", vm.ID, err)
+		}
+		for _, adapter := range adapters {
+			targets = append(targets, &target{
+				typeName: "cloudtemple_compute_network_adapter",
+				importID: adapter.ID,
+				name:     vm.Name + "_" + adapter.Name,
+				vm:       parent,
+			})
+		}
+
+		controllers, err := c.Compute().VirtualController().ListStrict(ctx, &client.VirtualControllerFilter{VirtualMachineId: vm.ID})
+		if err != nil {
+			return nil, fmt.Errorf("listing the controllers of virtual machine %s: %w", vm.ID, err)
+		}
+		for _, controller := range controllers {
+			targets = append(targets, &target{
+				typeName: "cloudtemple_compute_virtual_controller",
+				importID: controller.ID,
+				name:     vm.Name + "_" + controller.Label,
+				vm:       parent,
+			})
+		}
+	}
+	return targets, nil
+}
+
+func walkOpenIaaS(ctx context.Context, c *client.Client) ([]*target, error) {
+	vms, err := c.Compute().OpenIaaS().VirtualMachine().ListStrict(ctx, &client.OpenIaaSVirtualMachineFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("listing virtual machines: %w", err)
+	}
+
+	var targets []*target
+	for _, vm := range vms {
+		parent := &target{typeName: "cloudtemple_compute_iaas_opensource_virtual_machine", importID: vm.ID, name: vm.Name}
+		targets = append(targets, parent)
+
+		disks, err := c.Compute().OpenIaaS().VirtualDisk().ListStrict(ctx, &client.OpenIaaSVirtualDiskFilter{VirtualMachineID: vm.ID})
+		if err != nil {
+			return nil, fmt.Errorf("listing the disks of virtual machine %s: %w", vm.ID, err)
+		}
+		for _, disk := range disks {
+			targets = append(targets, &target{
+				typeName: "cloudtemple_compute_iaas_opensource_virtual_disk",
+				importID: disk.ID,
+				name:     vm.Name + "_" + disk.Name,
+				vm:       parent,
+				// A disk can be attached to several virtual machines, so an
+				// import by ID does not tell which one the resource is for.
+				known: map[string]string{"virtual_machine_id": vm.ID},
+			})
+		}
+
+		adapters, err := c.Compute().OpenIaaS().NetworkAdapter().ListStrict(ctx, &client.OpenIaaSNetworkAdapterFilter{VirtualMachineID: vm.ID})
+		if err != nil {
+			return nil, fmt.Errorf("listing the network adapters of virtual machine %s: %w", vm.ID, err)
+		}
+		for _, adapter := range adapters {
+			targets = append(targets, &target{
+				typeName: "cloudtemple_compute_iaas_opensource_network_adapter",
+				importID: adapter.ID,
+				name:     vm.Name + "_" + adapter.Name,
+				vm:       parent,
+			})
+		}
+	}
+	return targets, nil
+}
+
+func walkReplicationPolicies(ctx context.Context, c *client.Client) ([]*target, error) {
+	policies, err := c.Compute().OpenIaaS().Replication().Policy().ListStrict(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing replication policies: %w", err)
+	}
+
+	var targets []*target
+	for _, policy := range policies {
+		targets = append(targets, &target{
+			typeName: "cloudtemple_compute_iaas_opensource_replication_policy",
+			importID: policy.ID,
+			name:     policy.Name,
+		})
+	}
+	return targets, nil
+}
+
+// walkPublicCloud leaves out the primary disk and the first network adapter
+// of an instance: they are managed by the os_disk and os_network_adapter
+// blocks of the instance, not by resources of their own.
+func walkPublicCloud(ctx context.Context, c *client.Client) ([]*target, error) {
+	instances, err := c.PublicCloudVM().Instance().ListStrict(ctx, &client.PublicCloudVMInstanceFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("listing instances: %w", err)
+	}
+
+	var targets []*target
+	for _, instance := range instances {
+		parent := &target{typeName: "cloudtemple_public_cloud_vm_instance", importID: instance.ID, name: instance.Name}
+		targets = append(targets, parent)
+
+		disks, err := c.PublicCloudVM().Disk().ListStrict(ctx, instance.ID)
+		if err != nil {
+			return nil, fmt.Errorf("listing the disks of instance %s: %w", instance.ID, err)
+		}
+		for _, disk := range disks {
+			if disk.IsPrimary {
+				continue
+			}
+			targets = append(targets, &target{
+				typeName: "cloudtemple_public_cloud_vm_disk",
+				importID: provider.VMScopedImportID(instance.ID, disk.ID),
+				name:     instance.Name + "_" + disk.Label,
+				vm:       parent,
+			})
+		}
+
+		adapters, err := c.PublicCloudVM().NetworkAdapter().ListStrict(ctx, instance.ID)
+		if err != nil {
+			return nil, fmt.Errorf("listing the network adapters of instance %s: %w", instance.ID, err)
+		}
+		for _, adapter := range adapters {
+			if adapter.DeviceIndex == 0 {
+				continue
+			}
+			targets = append(targets, &target{
+				typeName: "cloudtemple_public_cloud_vm_network_adapter",
+				importID: provider.VMScopedImportID(instance.ID, adapter.ID),
+				name:     fmt.Sprintf("%s_eth%d", instance.Name, adapter.DeviceIndex),
+				vm:       parent,
+			})
+		}
+	}
+	return targets, nil
+}
+
+// walkObjectStorage imports the buckets by their identity: a bucket is read
+// by its name, which an import by ID does not set. The ACL entries of a bucket
+// are part of its acl_entry blocks.
+func walkObjectStorage(ctx context.Context, c *client.Client) ([]*target, error) {
+	buckets, err := c.ObjectStorage().Bucket().ListStrict(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing buckets: %w", err)
+	}
+
+	var targets []*target
+	for _, bucket := range buckets {
+		targets = append(targets, &target{
+			typeName: "cloudtemple_object_storage_bucket",
+			identity: map[string]string{"name": bucket.Name},
+			name:     bucket.Name,
+		})
+	}
+	return targets, nil
+}
+
+func walkVPC(ctx context.Context, c *client.Client) ([]*target, error) {
+	networks, err := c.VPC().PrivateNetwork().ListStrict(ctx, &client.PrivateNetworkFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("listing private networks: %w", err)
+	}
+
+	var targets []*target
+	for _, network := range networks {
+		staticIPs, err := c.VPC().StaticIP().ListStrict(ctx, network.ID)
+		if err != nil {
+			return nil, fmt.Errorf("listing the static IPs of private network %s: %w", network.ID, err)
+		}
+		for _, ip := range staticIPs {
+			targets = append(targets, &target{
+				typeName: "cloudtemple_vpc_static_ip",
+				importID: ip.ID,
+				name:     ip.IPAddress,
+			})
+		}
+	}
+
+	floatingIPs, err := c.VPC().FloatingIP().ListStrict(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing floating IPs: %w", err)
+	}
+	for _, ip := range floatingIPs {
+		targets = append(targets, &target{
+			typeName: "cloudtemple_vpc_floating_ip",
+			importID: ip.ID,
+			name:     ip.IPAddress,
+		})
+	}
+	return targets, nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// TestWalkVPCRefusesAPartialListing: a 206 on the private networks would drop
+// the static IPs of the missing networks from the generated configuration.
+func TestWalkVPCRefusesAPartialListing(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/vpc/v1/private_networks" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = w.Write([]byte(`[{"id":"pn-1"}]`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := client.NewClient(&client.Config{Address: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SavedToken = &jwt.Token{Claims: jwt.MapClaims{"exp": float64(time.Now().Add(time.Hour).Unix())}}
+
+	if targets, err := walkVPC(context.Background(), c); err == nil {
+		t.Fatalf("a partial listing must fail the walk, got %d targets", len(targets))
+	}
+}
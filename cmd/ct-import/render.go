@@ -0,0 +1,235 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	hcty "github.com/hashicorp/go-cty/cty"
+	hctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// assignLabels gives every target a resource label derived from its name,
+// unique within its resource type.
+func assignLabels(targets []*target) {
+	used := map[string]map[string]bool{}
+	for _, t := range targets {
+		if used[t.typeName] == nil {
+			used[t.typeName] = map[string]bool{}
+		}
+		base := sanitizeLabel(t.name)
+		label := base
+		for i := 2; used[t.typeName][label]; i++ {
+			label = fmt.Sprintf("%s_%d", base, i)
+		}
+		used[t.typeName][label] = true
+		t.label = label
+	}
+}
+
+// sanitizeLabel turns a platform name into a Terraform identifier: lower-case
+// letters, digits and underscores, not starting with a digit.
+func sanitizeLabel(name string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			underscore = false
+		} else if !underscore && b.Len() > 0 {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+
+	label := strings.TrimSuffix(b.String(), "_")
+	if label == "" {
+		return "unnamed"
+	}
+	if label[0] >= '0' && label[0] <= '9' {
+		return "r_" + label
+	}
+	return label
+}
+
+// renderTarget appends the import block and the resource block of t to body.
+// The resource block sets the arguments of the state read at import, so that
+// the configuration plans clean:
+//   - computed-only and optional computed attributes are left out, the state
+//     being kept for them;
+//   - so are empty values and values equal to the default of the attribute;
+//   - write-only and sensitive attributes are never in the state: they are
+//     left to the user, as are the required attributes the API does not
+//     return, with a TODO comment.
+//
+// rendered tells whether a target has a resource block, so that
+// virtual_machine_id refers to the resource of its virtual machine.
+func renderTarget(body *hclwrite.Body, res *schema.Resource, t *target, value hcty.Value, rendered map[*target]bool) error {
+	importBlock := body.AppendNewBlock("import", nil).Body()
+	importBlock.SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: t.typeName},
+		hcl.TraverseAttr{Name: t.label},
+	})
+	if t.identity != nil {
+		identity := map[string]cty.Value{}
+		for k, v := range t.identity {
+			identity[k] = cty.StringVal(v)
+		}
+		importBlock.SetAttributeValue("identity", cty.ObjectVal(identity))
+	} else {
+		importBlock.SetAttributeValue("id", cty.StringVal(t.importID))
+	}
+	body.AppendNewline()
+
+	resource := body.AppendNewBlock("resource", []string{t.typeName, t.label}).Body()
+	if err := renderBody(resource, res.SchemaMap(), value, t, rendered); err != nil {
+		return fmt.Errorf("%s.%s: %w", t.typeName, t.label, err)
+	}
+	body.AppendNewline()
+	return nil
+}
+
+// renderBody sets the arguments of a resource, or of a nested block when t is
+// nil.
+func renderBody(body *hclwrite.Body, attributes map[string]*schema.Schema, value hcty.Value, t *target, rendered map[*target]bool) error {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	set := map[string]bool{}
+	for _, name := range names {
+		s := attributes[name]
+		if name == "id" || s.Computed || s.WriteOnly || conflictsWithSet(s, set) {
+			continue
+		}
+
+		v := value.GetAttr(name)
+		if t != nil && name == "virtual_machine_id" && t.vm != nil && rendered[t.vm] {
+			body.SetAttributeTraversal(name, hcl.Traversal{
+				hcl.TraverseRoot{Name: t.vm.typeName},
+				hcl.TraverseAttr{Name: t.vm.label},
+				hcl.TraverseAttr{Name: "id"},
+			})
+			set[name] = true
+			continue
+		}
+		if isEmpty(v) && t != nil && t.known[name] != "" {
+			v = hcty.StringVal(t.known[name])
+		}
+
+		if s.Sensitive {
+			if s.Required || !isEmpty(v) {
+				appendComment(body, fmt.Sprintf("TODO: set %s, a sensitive argument ct-import does not write.", name))
+			}
+			continue
+		}
+		if isEmpty(v) {
+			if s.Required {
+				appendComment(body, fmt.Sprintf("TODO: set %s, which the API does not return.", name))
+			}
+			continue
+		}
+		if !s.Required && isDefault(s, v) {
+			continue
+		}
+
+		if block, ok := s.Elem.(*schema.Resource); ok && (s.Type == schema.TypeList || s.Type == schema.TypeSet) {
+			for it := v.ElementIterator(); it.Next(); {
+				_, element := it.Element()
+				if err := renderBody(body.AppendNewBlock(name, nil).Body(), block.SchemaMap(), element, nil, rendered); err != nil {
+					return fmt.Errorf("%s: %w", name, err)
+				}
+			}
+			set[name] = true
+			continue
+		}
+
+		converted, err := convertValue(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		body.SetAttributeValue(name, converted)
+		set[name] = true
+	}
+	return nil
+}
+
+func conflictsWithSet(s *schema.Schema, set map[string]bool) bool {
+	for _, other := range s.ConflictsWith {
+		if set[other] {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmpty tells whether v is what SDKv2 takes for an unset argument: null, an
+// empty collection, or the zero value of a primitive.
+func isEmpty(v hcty.Value) bool {
+	if v.IsNull() || !v.IsKnown() {
+		return true
+	}
+	ty := v.Type()
+	switch {
+	case ty.IsListType(), ty.IsSetType(), ty.IsMapType():
+		return v.LengthInt() == 0
+	case ty == hcty.String:
+		return v.AsString() == ""
+	case ty == hcty.Number:
+		return v.AsBigFloat().Sign() == 0
+	case ty == hcty.Bool:
+		return v.False()
+	}
+	return false
+}
+
+// isDefault tells whether v is the default of the attribute, which an
+// omitted argument takes.
+func isDefault(s *schema.Schema, v hcty.Value) bool {
+	if s.Default == nil {
+		return false
+	}
+	switch v.Type() {
+	case hcty.String:
+		return fmt.Sprint(s.Default) == v.AsString()
+	case hcty.Bool:
+		b, ok := s.Default.(bool)
+		return ok && b == v.True()
+	case hcty.Number:
+		def, ok := new(big.Float).SetString(fmt.Sprint(s.Default))
+		return ok && def.Cmp(v.AsBigFloat()) == 0
+	}
+	return false
+}
+
+// convertValue converts a value of the SDK's cty fork to one hclwrite takes.
+func convertValue(v hcty.Value) (cty.Value, error) {
+	tyJSON, err := hctyjson.MarshalType(v.Type())
+	if err != nil {
+		return cty.NilVal, err
+	}
+	valueJSON, err := hctyjson.Marshal(v, v.Type())
+	if err != nil {
+		return cty.NilVal, err
+	}
+	ty, err := ctyjson.UnmarshalType(tyJSON)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	return ctyjson.Unmarshal(valueJSON, ty)
+}
+
+func appendComment(body *hclwrite.Body, text string) {
+	body.AppendUnstructuredTokens(hclwrite.Tokens{
+		{Type: hclsyntax.TokenComment, Bytes: []byte("# " + text + "\n")},
+	})
+}
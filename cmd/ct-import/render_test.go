@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/provider"
+	hcty "github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestSanitizeLabel(t *testing.T) {
+	cases := map[string]string{
+		"web-01":         "web_01",
+		"Web 01 (prod)":  "web_01_prod",
+		"10.0.0.5":       "r_10_0_0_5",
+		"--":             "unnamed",
+		"vm_Hard disk 1": "vm_hard_disk_1",
+	}
+	for in, want := range cases {
+		if got := sanitizeLabel(in); got != want {
+			t.Errorf("sanitizeLabel(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestAssignLabelsDeduplicatesPerType(t *testing.T) {
+	targets := []*target{
+		{typeName: "a", name: "web"},
+		{typeName: "a", name: "WEB"},
+		{typeName: "b", name: "web"},
+	}
+	assignLabels(targets)
+	if targets[0].label != "web" || targets[1].label != "web_2" || targets[2].label != "web" {
+		t.Fatalf("labels = %q, %q, %q", targets[0].label, targets[1].label, targets[2].label)
+	}
+}
+
+func TestSelectWalkers(t *testing.T) {
+	all, err := selectWalkers("all")
+	if err != nil || len(all) != len(walkers) {
+		t.Fatalf("all: %d walkers, %v", len(all), err)
+	}
+	some, err := selectWalkers("vpc, vmware")
+	if err != nil || len(some) != 2 || some[0].name != "vmware" || some[1].name != "vpc" {
+		t.Fatalf("vpc,vmware: %v, %v", some, err)
+	}
+	if _, err := selectWalkers("vmware,nope"); err == nil {
+		t.Fatal("an unknown type must be rejected")
+	}
+}
+
+func TestRunListNeedsNoNetwork(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-list"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d, stderr: %s", code, stderr.String())
+	}
+	for _, w := range walkers {
+		if !strings.Contains(stdout.String(), w.name) {
+			t.Errorf("-list does not show %s", w.name)
+		}
+	}
+}
+
+// fakeImporter returns the state of an object set attribute by attribute, as
+// its Read would have.
+func fakeImporter(t *testing.T, states map[*target]map[string]any) importer {
+	return func(ctx context.Context, p *schema.Provider, target *target) (hcty.Value, error) {
+		attributes, ok := states[target]
+		if !ok {
+			return hcty.NilVal, fmt.Errorf("read failed")
+		}
+		res := p.ResourcesMap[target.typeName]
+		d := res.TestResourceData()
+		d.SetId("id")
+		for k, v := range attributes {
+			if err := d.Set(k, v); err != nil {
+				t.Fatalf("%s.%s: %s", target.typeName, k, err)
+			}
+		}
+		return schema.StateValueFromInstanceState(d.State(), res.CoreConfigSchema().ImpliedType())
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	const vmID = "8a0e0bd6-9c0e-4a0a-8f2e-1c7d2d0ba0c1"
+	const diskID = "1b8e3c52-f7a6-4f0e-9d2b-5f1a2e3d4c5b"
+
+	vm := &target{typeName: "cloudtemple_public_cloud_vm_instance", importID: vmID, name: "Web 01"}
+	disk := &target{
+		typeName: "cloudtemple_public_cloud_vm_disk",
+		importID: provider.VMScopedImportID(vmID, diskID),
+		name:     "Web 01_data",
+		vm:       vm,
+	}
+	orphan := &target{
+		typeName: "cloudtemple_public_cloud_vm_disk",
+		importID: provider.VMScopedImportID(vmID, diskID),
+		name:     "lost",
+		vm:       &target{typeName: "cloudtemple_public_cloud_vm_instance", name: "unread"},
+	}
+	bucket := &target{
+		typeName: "cloudtemple_object_storage_bucket",
+		identity: map[string]string{"name": "backups"},
+		name:     "backups",
+	}
+	failing := &target{typeName: "cloudtemple_vpc_floating_ip", importID: "x", name: "x"}
+
+	read := fakeImporter(t, map[*target]map[string]any{
+		vm: {
+			"name":                 "Web 01",
+			"availability_zone_id": "az",
+			"backup_policy_id":     "bp",
+			"instance_family_id":   "fam",
+			"cpu":                  2,
+			"memory":               4,
+			"power_state":          "off",
+			"status":               "stopped",
+		},
+		disk:   {"virtual_machine_id": vmID, "size": 20, "name": "data"},
+		orphan: {"virtual_machine_id": vmID, "size": 10},
+		bucket: {"name": "backups", "access_type": "private"},
+	})
+
+	targets := []*target{vm, disk, orphan.vm, orphan, bucket, failing}
+	files, errs := generateWith(context.Background(), provider.New("test")(), targets, read)
+
+	if len(errs) != 2 {
+		t.Fatalf("want the errors of the unread VM and the floating IP, got %v", errs)
+	}
+
+	instance := string(files["public_cloud_vm_instance.tf"])
+	for _, want := range []string{
+		"to = cloudtemple_public_cloud_vm_instance.web_01",
+		`id = "` + vmID + `"`,
+		`resource "cloudtemple_public_cloud_vm_instance" "web_01"`,
+		`name                 = "Web 01"`,
+		"# TODO: set template_id",
+	} {
+		if !strings.Contains(instance, want) {
+			t.Errorf("instance file lacks %q:\n%s", want, instance)
+		}
+	}
+	// Computed attributes and defaults are left to the state.
+	for _, unwanted := range []string{"status", "power_state"} {
+		if strings.Contains(instance, unwanted) {
+			t.Errorf("instance file sets %s:\n%s", unwanted, instance)
+		}
+	}
+
+	disks := string(files["public_cloud_vm_disk.tf"])
+	for _, want := range []string{
+		`id = "` + vmID + "/" + diskID + `"`,
+		"virtual_machine_id = cloudtemple_public_cloud_vm_instance.web_01.id",
+		`virtual_machine_id = "` + vmID + `"`,
+		"size               = 20",
+	} {
+		if !strings.Contains(disks, want) {
+			t.Errorf("disk file lacks %q:\n%s", want, disks)
+		}
+	}
+
+	buckets := string(files["object_storage_bucket.tf"])
+	if !strings.Contains(buckets, `name = "backups"`) || !strings.Contains(buckets, "identity = {") {
+		t.Errorf("the bucket must be imported by identity:\n%s", buckets)
+	}
+
+	if _, ok := files["vpc_floating_ip.tf"]; ok {
+		t.Error("an object that failed to read must not be written")
+	}
+}
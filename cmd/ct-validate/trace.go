@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+)
+
+// traceRecord is one line of the -http-trace transcript.
+type traceRecord struct {
+	Time         string  `json:"time"`
+	Method       string  `json:"method"`
+	Path         string  `json:"path"`
+	Query        string  `json:"query,omitempty"`
+	Status       int     `json:"status"`
+	LatencyMS    float64 `json:"latency_ms"`
+	Location     string  `json:"location,omitempty"`
+	RequestBody  string  `json:"request_body,omitempty"`
+	ResponseBody string  `json:"response_body,omitempty"`
+	Error        string  `json:"error,omitempty"`
+}
+
+// traceWriter writes the trace of every request as JSON lines. The client
+// redacts the secrets beforehand, so the transcript can be attached to a
+// support ticket as is.
+type traceWriter struct {
+	lock sync.Mutex
+	enc  *json.Encoder
+	now  func() time.Time
+}
+
+func newTraceWriter(w io.Writer) *traceWriter {
+	return &traceWriter{enc: json.NewEncoder(w), now: time.Now}
+}
+
+// trace is a client.HTTPTracer; the workers call it concurrently.
+func (t *traceWriter) trace(_ context.Context, trace *client.HTTPTrace) {
+	record := traceRecord{
+		Time:         t.now().UTC().Format(time.RFC3339Nano),
+		Method:       trace.Method,
+		Path:         trace.Path,
+		Query:        trace.Query,
+		Status:       trace.Status,
+		LatencyMS:    float64(trace.Latency.Microseconds()) / 1000,
+		Location:     trace.Location,
+		RequestBody:  trace.RequestBody,
+		ResponseBody: trace.ResponseBody,
+	}
+	if trace.Err != nil {
+		record.Error = trace.Err.Error()
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	_ = t.enc.Encode(record)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+)
+
+func TestTraceWriterWritesJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	w := newTraceWriter(&buf)
+	w.now = func() time.Time { return time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC) }
+
+	w.trace(context.Background(), &client.HTTPTrace{
+		Method:   "POST",
+		Path:     "/api/compute/v1/virtual_machines",
+		Status:   200,
+		Latency:  1500 * time.Microsecond,
+		Location: "/api/activity/v1/activities/42",
+	})
+	w.trace(context.Background(), &client.HTTPTrace{Method: "GET", Path: "/api/iam/v2/tenants", Err: errors.New("connection reset")})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("want 2 lines, got %q", buf.String())
+	}
+	var first, second traceRecord
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatal(err)
+	}
+	if first.Time != "2026-10-18T12:00:00Z" || first.LatencyMS != 1.5 || first.Location != "/api/activity/v1/activities/42" {
+		t.Errorf("first record = %+v", first)
+	}
+	if second.Status != 0 || second.Error != "connection reset" {
+		t.Errorf("second record = %+v", second)
+	}
+	if strings.Contains(lines[0], "request_body") {
+		t.Errorf("an empty body must be omitted: %s", lines[0])
+	}
+}
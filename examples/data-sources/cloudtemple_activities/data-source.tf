@@ -1 +1,12 @@
 data "cloudtemple_activities" "foo" {}
+
+# Failed operations on a given virtual machine since the beginning of the year
+data "cloudtemple_activities" "failed" {
+  concerned_item_id = "de2b8b80-8b90-414a-bc33-e12f61a4c05c"
+  state             = "failed"
+  created_after     = "2025-01-01T00:00:00Z"
+}
+
+output "failure_reasons" {
+  value = flatten([for a in data.cloudtemple_activities.failed.activities : [for s in a.state : s.reason]])
+}
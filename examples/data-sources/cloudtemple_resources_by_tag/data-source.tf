@@ -0,0 +1,10 @@
+# Find the virtual machines and buckets of the billing application
+data "cloudtemple_resources_by_tag" "billing" {
+  key   = "app"
+  value = "billing"
+  types = ["cloudtemple_compute_virtual_machine", "cloudtemple_object_storage_bucket"]
+}
+
+output "billing_virtual_machine_ids" {
+  value = [for r in data.cloudtemple_resources_by_tag.billing.resources : r.id if r.type == "cloudtemple_compute_virtual_machine"]
+}
@@ -0,0 +1,7 @@
+data "cloudtemple_tags" "foo" {
+  resource_id = "00791ba3-8cc0-4051-a654-9cd4d71eb48c"
+}
+
+output "cost_center" {
+  value = lookup(data.cloudtemple_tags.foo.tags, "cost_center", null)
+}
@@ -0,0 +1,8 @@
+terraform {
+  required_providers {
+    cloudtemple = {
+      source  = "Cloud-Temple/cloudtemple"
+      version = "0.1.0"
+    }
+  }
+}
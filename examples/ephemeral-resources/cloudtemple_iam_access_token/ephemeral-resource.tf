@@ -0,0 +1,10 @@
+ephemeral "cloudtemple_iam_access_token" "api" {}
+
+# The token is only valid during the run: use it where ephemeral values are
+# accepted, such as the configuration of another provider.
+provider "restapi" {
+  uri = "https://shiva.cloud-temple.com/api"
+  headers = {
+    Authorization = "Bearer ${ephemeral.cloudtemple_iam_access_token.api.token}"
+  }
+}
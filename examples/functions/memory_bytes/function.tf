@@ -0,0 +1,6 @@
+resource "cloudtemple_compute_iaas_opensource_virtual_machine" "web" {
+  name   = "web"
+  memory = provider::cloudtemple::memory_bytes(4, "GiB")
+  cpu    = 2
+  # ...
+}
@@ -0,0 +1,3 @@
+locals {
+  on_expected_host = provider::cloudtemple::normalize_uuid(var.host_id) == cloudtemple_compute_virtual_machine.web.host_id
+}
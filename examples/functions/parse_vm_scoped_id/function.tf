@@ -0,0 +1,11 @@
+locals {
+  snapshot = provider::cloudtemple::parse_vm_scoped_id(var.snapshot_import_id)
+}
+
+output "virtual_machine_id" {
+  value = local.snapshot.virtual_machine_id
+}
+
+output "snapshot_id" {
+  value = local.snapshot.id
+}
@@ -0,0 +1,4 @@
+import {
+  to = cloudtemple_compute_snapshot.before_upgrade
+  id = provider::cloudtemple::vm_scoped_id(var.virtual_machine_id, var.snapshot_id)
+}
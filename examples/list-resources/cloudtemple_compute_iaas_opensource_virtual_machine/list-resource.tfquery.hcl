@@ -0,0 +1,8 @@
+list "cloudtemple_compute_iaas_opensource_virtual_machine" "pool" {
+  provider = cloudtemple
+
+  config {
+    pool_id    = "12345678-1234-1234-1234-123456789abc"
+    name_regex = "^app-"
+  }
+}
@@ -0,0 +1,10 @@
+list "cloudtemple_compute_virtual_machine" "web" {
+  provider = cloudtemple
+
+  config {
+    datacenter_id = "7b56f202-83e3-4112-9771-8fb001fbac3e"
+    name_regex    = "^web-"
+    tag_key       = "environment"
+    tag_value     = "production"
+  }
+}
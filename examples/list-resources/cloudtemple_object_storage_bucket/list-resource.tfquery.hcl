@@ -0,0 +1,7 @@
+list "cloudtemple_object_storage_bucket" "backups" {
+  provider = cloudtemple
+
+  config {
+    name_regex = "-backup$"
+  }
+}
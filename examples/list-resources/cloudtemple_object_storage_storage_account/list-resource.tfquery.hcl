@@ -0,0 +1,3 @@
+list "cloudtemple_object_storage_storage_account" "all" {
+  provider = cloudtemple
+}
@@ -0,0 +1,7 @@
+list "cloudtemple_public_cloud_vm_instance" "all" {
+  provider = cloudtemple
+
+  config {
+    tag_key = "team"
+  }
+}
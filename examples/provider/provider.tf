@@ -1,7 +1,23 @@
 provider "cloudtemple" {
-  # Can also be set as the CLOUDTEMPLE_CLIENT_ID environment variable
+  # Can also be set as the CLOUDTEMPLE_CLIENT_ID environment variable, or read
+  # from a profile of ~/.cloudtemple/credentials (see the profile argument)
   client_id = "12345678-1234-1234-1234-123456789abc"
 
   # Can also be set as the CLOUDTEMPLE_SECRET_ID environment variable
   secret_id = "12345678-1234-1234-1234-123456789abc"
-}
\ No newline at end of file
+
+  # Tags merged under the tags of every virtual machine
+  default_tags {
+    tags = {
+      cost_center = "1234"
+      owner       = "platform"
+      env         = "prod"
+    }
+  }
+
+  # Tags written by other tools, left alone by the provider
+  ignore_tags {
+    keys         = ["cmdb_id"]
+    key_prefixes = ["backup:"]
+  }
+}
@@ -0,0 +1,3 @@
+# An Open IaaS snapshot is imported by its id. `save_memory` is not reported by
+# the API and is left unset in the state after an import.
+terraform import cloudtemple_compute_iaas_opensource_snapshot.pre_upgrade 11111111-1111-1111-1111-111111111111
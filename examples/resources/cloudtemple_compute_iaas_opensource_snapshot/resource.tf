@@ -0,0 +1,35 @@
+variable "virtual_machine_id" {
+  type        = string
+  description = "The ID of the Open IaaS virtual machine to snapshot."
+}
+
+variable "revert_trigger" {
+  type        = string
+  default     = ""
+  description = "Change this value to revert the virtual machine to the snapshot."
+}
+
+# A disk-only snapshot (the default): reverting to it leaves the virtual
+# machine halted.
+resource "cloudtemple_compute_iaas_opensource_snapshot" "nightly" {
+  virtual_machine_id = var.virtual_machine_id
+  name               = "nightly"
+}
+
+# A snapshot including the memory of the running virtual machine: reverting to
+# it resumes the virtual machine where it was. Every argument except
+# `revert_trigger` is immutable and recreates the snapshot.
+resource "cloudtemple_compute_iaas_opensource_snapshot" "pre_upgrade" {
+  virtual_machine_id = var.virtual_machine_id
+  name               = "pre-upgrade"
+  description        = "Taken before the application upgrade"
+  save_memory        = true
+
+  # Rolling back: set a new value (e.g. `terraform apply -var revert_trigger=1`)
+  # and apply; the virtual machine is reverted to this snapshot.
+  revert_trigger = var.revert_trigger
+}
+
+output "pre_upgrade_snapshot_create_time" {
+  value = cloudtemple_compute_iaas_opensource_snapshot.pre_upgrade.create_time
+}
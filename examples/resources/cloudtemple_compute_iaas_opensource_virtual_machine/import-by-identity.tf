@@ -0,0 +1,6 @@
+import {
+  to = cloudtemple_compute_iaas_opensource_virtual_machine.example
+  identity = {
+    id = "12345678-1234-1234-1234-123456789abc"
+  }
+}
@@ -0,0 +1,2 @@
+# A snapshot is imported by the composite id "<virtual_machine_id>/<snapshot_id>".
+terraform import cloudtemple_compute_snapshot.pre_upgrade 00000000-0000-0000-0000-000000000000/11111111-1111-1111-1111-111111111111
@@ -0,0 +1,25 @@
+variable "virtual_machine_id" {
+  type        = string
+  description = "The ID of the virtual machine to snapshot."
+}
+
+variable "revert_trigger" {
+  type        = string
+  default     = ""
+  description = "Change this value to revert the virtual machine to the snapshot."
+}
+
+# A point-in-time snapshot taken before a risky change. `name` is immutable —
+# renaming recreates the snapshot.
+resource "cloudtemple_compute_snapshot" "pre_upgrade" {
+  virtual_machine_id = var.virtual_machine_id
+  name               = "pre-upgrade"
+
+  # Rolling back: set a new value (e.g. `terraform apply -var revert_trigger=1`)
+  # and apply; the virtual machine is reverted to this snapshot.
+  revert_trigger = var.revert_trigger
+}
+
+output "snapshot_create_time" {
+  value = cloudtemple_compute_snapshot.pre_upgrade.create_time
+}
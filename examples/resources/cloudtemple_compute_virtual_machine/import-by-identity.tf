@@ -0,0 +1,6 @@
+import {
+  to = cloudtemple_compute_virtual_machine.foo
+  identity = {
+    id = "de2b8b80-8b90-414a-bc33-e12f61a4c05c"
+  }
+}
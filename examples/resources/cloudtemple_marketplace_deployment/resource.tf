@@ -0,0 +1,70 @@
+data "cloudtemple_marketplace_item" "appliance" {
+  name = "Ubuntu 24.04 LTS"
+}
+
+# Open IaaS: the network ids are given in the order of the item's network
+# adapters.
+data "cloudtemple_compute_iaas_opensource_availability_zone" "az05" {
+  name = "az05"
+}
+
+data "cloudtemple_compute_iaas_opensource_storage_repository" "sr" {
+  name               = "sr001-clu001-t0001-az05-r-flh1-data13"
+  machine_manager_id = data.cloudtemple_compute_iaas_opensource_availability_zone.az05.id
+}
+
+data "cloudtemple_compute_iaas_opensource_network" "lan" {
+  name               = "VLAN_201"
+  machine_manager_id = data.cloudtemple_compute_iaas_opensource_availability_zone.az05.id
+}
+
+resource "cloudtemple_marketplace_deployment" "openiaas" {
+  marketplace_item_id = data.cloudtemple_marketplace_item.appliance.id
+  target              = "openiaas"
+  name                = "appliance-openiaas"
+  network_ids         = [data.cloudtemple_compute_iaas_opensource_network.lan.id]
+
+  openiaas {
+    storage_repository_id = data.cloudtemple_compute_iaas_opensource_storage_repository.sr.id
+
+    cloud_init = {
+      cloud_config = file("./cloud-init/cloud-config.yml")
+    }
+  }
+}
+
+# VMware: the placement is a datacenter and a datastore, optionally narrowed to
+# a host cluster or a host.
+data "cloudtemple_compute_machine_manager" "vstack-001" {
+  name = "vc-vstack-001-t0001"
+}
+
+data "cloudtemple_compute_virtual_datacenter" "dc" {
+  name               = "DC-EQX6"
+  machine_manager_id = data.cloudtemple_compute_machine_manager.vstack-001.id
+}
+
+data "cloudtemple_compute_datastore" "ds" {
+  name               = "ds001-bob-svc1-data4-eqx6"
+  machine_manager_id = data.cloudtemple_compute_machine_manager.vstack-001.id
+}
+
+resource "cloudtemple_marketplace_deployment" "vmware" {
+  marketplace_item_id = data.cloudtemple_marketplace_item.appliance.id
+  target              = "vmware"
+  name                = "appliance-vmware"
+
+  vmware {
+    datacenter_id = data.cloudtemple_compute_virtual_datacenter.dc.id
+    datastore_id  = data.cloudtemple_compute_datastore.ds.id
+
+    deploy_options = {
+      hostname = "appliance-vmware"
+    }
+  }
+}
+
+# Every virtual machine created by the deployment, including multi-VM items.
+output "openiaas_virtual_machine_ids" {
+  value = cloudtemple_marketplace_deployment.openiaas.virtual_machine_ids
+}
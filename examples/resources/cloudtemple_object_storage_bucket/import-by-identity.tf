@@ -0,0 +1,6 @@
+import {
+  to = cloudtemple_object_storage_bucket.example
+  identity = {
+    name = "my-bucket"
+  }
+}
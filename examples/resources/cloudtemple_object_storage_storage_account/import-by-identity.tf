@@ -0,0 +1,6 @@
+import {
+  to = cloudtemple_object_storage_storage_account.example
+  identity = {
+    name = "my-storage-account"
+  }
+}
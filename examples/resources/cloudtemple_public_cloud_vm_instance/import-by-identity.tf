@@ -0,0 +1,6 @@
+import {
+  to = cloudtemple_public_cloud_vm_instance.web
+  identity = {
+    id = "00000000-0000-0000-0000-000000000000"
+  }
+}
@@ -0,0 +1,2 @@
+# A tag is imported by the composite id "<resource_id>/<resource_type>/<source>/<key>".
+terraform import cloudtemple_tag.cost_center 00000000-0000-0000-0000-000000000000/vcenter_virtual_machine/vmware/cost_center
@@ -0,0 +1,12 @@
+data "cloudtemple_compute_virtual_machine" "legacy" {
+  name = "legacy-vm"
+}
+
+# Tag a virtual machine that is not managed by Terraform
+resource "cloudtemple_tag" "cost_center" {
+  resource_id   = data.cloudtemple_compute_virtual_machine.legacy.id
+  resource_type = "vcenter_virtual_machine"
+  source        = "vmware"
+  key           = "cost_center"
+  value         = "1234"
+}
@@ -61,6 +61,19 @@ func IsTransientActivityFailure(err error) bool {
 	return false
 }
 
+// IsFailedActivity reports whether err is an activity that reached the terminal
+// "failed" state, as opposed to a wait that was cut short (cancellation, timeout)
+// or an activity that could not be read: only the former proves the operation
+// will never complete.
+func IsFailedActivity(err error) bool {
+	var ace *ActivityCompletionError
+	if !errors.As(err, &ace) || ace.activity == nil {
+		return false
+	}
+	_, failed := ace.activity.State["failed"]
+	return failed
+}
+
 // containsAny reports whether s contains at least one of the given substrings.
 func containsAny(s string, subs []string) bool {
 	for _, sub := range subs {
@@ -104,22 +117,27 @@ type ActivityConcernedItem struct {
 }
 
 func (c *ActivityClient) List(ctx context.Context, filter *struct{}) ([]*Activity, error) {
-	r := c.c.newRequest("GET", "/activity/v1/activities")
-	resp, err := c.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	if err := requireOK(resp); err != nil {
-		return nil, err
-	}
+	return paginate(ctx, c.c, "activity", 0,
+		func(item *Activity) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*Activity, error) {
+			r := c.c.newRequest("GET", "/activity/v1/activities")
+			pg.addTo(r)
+			resp, err := c.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			if err := requireOK(resp); err != nil {
+				return nil, err
+			}
 
-	var out []*Activity
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
+			var out []*Activity
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
 
-	return out, nil
+			return out, nil
+		})
 }
 
 func (c *ActivityClient) Read(ctx context.Context, id string) (*Activity, error) {
@@ -219,11 +237,22 @@ func (c *ActivityClient) WaitForCompletion(ctx context.Context, id string, optio
 	b := retry.NewFibonacci(1 * time.Second)
 	b = retry.WithCappedDuration(30*time.Second, b)
 
+	// The reference data written by the activity is only visible once it
+	// completed, whatever its outcome.
+	defer c.c.cache.settle(id)
 	return waitForActivityCompletion(ctx, id, func(ctx context.Context) (*Activity, error) {
 		return c.Read(ctx, id)
 	}, b, options)
 }
 
+// WaitForCreatedID waits for a create activity and returns the id of the created
+// object, read from the completed activity's single state Result. It shares the
+// fail-closed extraction of the VPC async creates (waitCreatedIDFromActivity,
+// vpc.go); label names the operation in diagnostics.
+func (c *ActivityClient) WaitForCreatedID(ctx context.Context, id, label string, options *WaiterOptions) (string, error) {
+	return c.c.waitCreatedIDFromActivity(ctx, id, label, options)
+}
+
 // activityReadFunc abstracts the activity read so the polling loop can be
 // unit tested without HTTP calls or sleeps.
 type activityReadFunc func(ctx context.Context) (*Activity, error)
@@ -240,8 +269,13 @@ type activityReadFunc func(ctx context.Context) (*Activity, error)
 //     (WaiterOptions.NotFoundRetries, default 1) for eventual consistency,
 //     a not-found beyond the budget is permanent;
 //   - an activity that was seen and then vanishes is always permanent,
-//     regardless of the not-found budget.
+//     regardless of the not-found budget;
+//   - reaching the deadline (ctx or WaiterOptions.Deadline) is a
+//     *WaitTimeoutError naming the still-pending activity.
 func waitForActivityCompletion(ctx context.Context, id string, read activityReadFunc, b retry.Backoff, options *WaiterOptions) (*Activity, error) {
+	ctx, cancel := options.withDeadline(ctx)
+	defer cancel()
+
 	var res *Activity
 	var consecutiveReadFailures int
 	// The initial not-found tolerance (eventual consistency right after the
@@ -314,5 +348,5 @@ func waitForActivityCompletion(ctx context.Context, id string, read activityRead
 		return nil
 	})
 
-	return res, err
+	return res, options.timeoutError(ctx, fmt.Sprintf("activity %q to complete", id), err)
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -395,3 +396,59 @@ func TestActivityWaitNotFoundBudgetIsConfigurableAndBounded(t *testing.T) {
 		}
 	})
 }
+
+func TestIsFailedActivity(t *testing.T) {
+	calls := 0
+	_, failed := waitForActivityCompletion(context.Background(), "act-1", scriptedReads(&calls, readOutcome{activity: failedActivity()}), immediateBackoff(5), nil)
+	if !IsFailedActivity(failed) || !IsFailedActivity(fmt.Errorf("wrapped: %w", failed)) {
+		t.Fatalf("a terminal failed activity must be reported as failed, got %v", failed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := func(c context.Context) (*Activity, error) {
+		cancel()
+		return pendingActivity(), nil
+	}
+	_, interrupted := waitForActivityCompletion(ctx, "act-1", cancelled, immediateBackoff(5), nil)
+	if interrupted == nil || IsFailedActivity(interrupted) {
+		t.Fatalf("an interrupted wait must not be reported as a failed activity, got %v", interrupted)
+	}
+
+	if IsFailedActivity(&ActivityCompletionError{message: "the activity \"act-1\" could not be found"}) {
+		t.Fatal("a not-found activity is not proof of a failure")
+	}
+	if IsFailedActivity(errors.New("plain error")) {
+		t.Fatal("a non-activity error is not a failed activity")
+	}
+}
+
+func TestActivityWaitDeadlineNamesThePendingActivity(t *testing.T) {
+	calls := 0
+	options := &WaiterOptions{Deadline: time.Now().Add(20 * time.Millisecond)}
+	b := retry.WithMaxRetries(1000, retry.BackoffFunc(func() (time.Duration, bool) {
+		return 5 * time.Millisecond, false
+	}))
+	_, err := waitForActivityCompletion(context.Background(), "act-42", scriptedReads(&calls, readOutcome{activity: pendingActivity()}), b, options)
+
+	var timeoutErr *WaitTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("reaching the options deadline must be a *WaitTimeoutError, got %T: %v", err, err)
+	}
+	if !strings.Contains(err.Error(), `"act-42"`) {
+		t.Fatalf("the timeout must name the pending activity, got %q", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("a wait timeout must unwrap to context.DeadlineExceeded")
+	}
+	if IsFailedActivity(err) {
+		t.Fatal("a wait timeout is not a failed activity")
+	}
+
+	// A cancellation is not a timeout and is returned as is.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = waitForActivityCompletion(ctx, "act-42", scriptedReads(&calls, readOutcome{activity: pendingActivity()}), immediateBackoff(5), nil)
+	if errors.As(err, &timeoutErr) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("a cancellation must stay a context.Canceled, got %v", err)
+	}
+}
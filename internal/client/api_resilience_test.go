@@ -140,7 +140,10 @@ func TestRetryableTransportError(t *testing.T) {
 
 func TestParseRetryAfter(t *testing.T) {
 	require.Equal(t, time.Duration(0), parseRetryAfter(""))
-	require.Equal(t, time.Duration(0), parseRetryAfter("Wed, 21 Oct 2026 07:28:00 GMT")) // HTTP-date ignored (best-effort)
+	require.Equal(t, time.Duration(0), parseRetryAfter("Wed, 21 Oct 2015 07:28:00 GMT")) // a past HTTP-date
+	require.Equal(t, time.Duration(0), parseRetryAfter("soon"))
+	inAMinute := parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	require.True(t, inAMinute > 55*time.Second && inAMinute <= time.Minute, inAMinute)
 	require.Equal(t, time.Duration(0), parseRetryAfter("0"))
 	require.Equal(t, 3*time.Second, parseRetryAfter("3"))
 }
@@ -208,24 +211,83 @@ func TestNewClientAppliesHTTPTimeout(t *testing.T) {
 	})
 }
 
+// defaultConfig is DefaultConfig without a shared credentials file.
+func defaultConfig(t *testing.T) *Config {
+	t.Helper()
+	isolateCredentials(t)
+	config, err := DefaultConfig()
+	require.NoError(t, err)
+	return config
+}
+
 func TestDefaultConfigReadsHTTPTimeoutEnv(t *testing.T) {
 	t.Run("unset keeps default", func(t *testing.T) {
 		t.Setenv(HTTPTimeoutEnvName, "")
-		require.Equal(t, defaultHTTPTimeout, DefaultConfig().HTTPTimeout)
+		require.Equal(t, defaultHTTPTimeout, defaultConfig(t).HTTPTimeout)
 	})
 	t.Run("valid seconds honored", func(t *testing.T) {
 		t.Setenv(HTTPTimeoutEnvName, "30")
-		require.Equal(t, 30*time.Second, DefaultConfig().HTTPTimeout)
+		require.Equal(t, 30*time.Second, defaultConfig(t).HTTPTimeout)
 	})
 	t.Run("non-numeric keeps default (fail-safe)", func(t *testing.T) {
 		t.Setenv(HTTPTimeoutEnvName, "not-a-number")
-		require.Equal(t, defaultHTTPTimeout, DefaultConfig().HTTPTimeout)
+		require.Equal(t, defaultHTTPTimeout, defaultConfig(t).HTTPTimeout)
 	})
 	t.Run("non-positive keeps default (fail-safe)", func(t *testing.T) {
 		t.Setenv(HTTPTimeoutEnvName, "0")
-		require.Equal(t, defaultHTTPTimeout, DefaultConfig().HTTPTimeout)
+		require.Equal(t, defaultHTTPTimeout, defaultConfig(t).HTTPTimeout)
 		t.Setenv(HTTPTimeoutEnvName, "-5")
-		require.Equal(t, defaultHTTPTimeout, DefaultConfig().HTTPTimeout)
+		require.Equal(t, defaultHTTPTimeout, defaultConfig(t).HTTPTimeout)
+	})
+}
+
+func TestDefaultConfigReadsRetryEnv(t *testing.T) {
+	t.Run("unset keeps the defaults", func(t *testing.T) {
+		t.Setenv(ReadRetryMaxEnvName, "")
+		t.Setenv(ReadRetryBackoffBaseEnvName, "")
+		t.Setenv(ReadRetryBackoffMaxEnvName, "")
+		config := defaultConfig(t)
+		require.Equal(t, defaultReadRetryMax, config.ReadRetryMax)
+		require.Equal(t, defaultReadRetryBackoffBase, config.ReadRetryBackoffBase)
+		require.Equal(t, defaultReadRetryBackoffMax, config.ReadRetryBackoffMax)
+	})
+	t.Run("valid values honored", func(t *testing.T) {
+		t.Setenv(ReadRetryMaxEnvName, "5")
+		t.Setenv(ReadRetryBackoffBaseEnvName, "250ms")
+		t.Setenv(ReadRetryBackoffMaxEnvName, "1m")
+		config := defaultConfig(t)
+		require.Equal(t, 5, config.ReadRetryMax)
+		require.Equal(t, 250*time.Millisecond, config.ReadRetryBackoffBase)
+		require.Equal(t, time.Minute, config.ReadRetryBackoffMax)
+	})
+	t.Run("invalid values keep the defaults (fail-safe)", func(t *testing.T) {
+		t.Setenv(ReadRetryMaxEnvName, "0")
+		t.Setenv(ReadRetryBackoffBaseEnvName, "500")
+		t.Setenv(ReadRetryBackoffMaxEnvName, "-1s")
+		config := defaultConfig(t)
+		require.Equal(t, defaultReadRetryMax, config.ReadRetryMax)
+		require.Equal(t, defaultReadRetryBackoffBase, config.ReadRetryBackoffBase)
+		require.Equal(t, defaultReadRetryBackoffMax, config.ReadRetryBackoffMax)
+	})
+}
+
+func TestNewClientAppliesRetryBackoff(t *testing.T) {
+	t.Run("defaults applied for a bare Config", func(t *testing.T) {
+		c, err := NewClient(&Config{Address: "example.test"})
+		require.NoError(t, err)
+		require.Equal(t, defaultReadRetryBackoffBase, c.readRetryBackoffBase)
+		require.Equal(t, defaultReadRetryBackoffMax, c.readRetryBackoffMax)
+	})
+	t.Run("explicit values honored and capped", func(t *testing.T) {
+		c, err := NewClient(&Config{Address: "example.test", ReadRetryBackoffBase: time.Second, ReadRetryBackoffMax: 3 * time.Second})
+		require.NoError(t, err)
+		require.Equal(t, time.Second, c.readRetryBackoff(0))
+		require.Equal(t, 2*time.Second, c.readRetryBackoff(1))
+		require.Equal(t, 3*time.Second, c.readRetryBackoff(2))
+	})
+	t.Run("a max shorter than the base is rejected", func(t *testing.T) {
+		_, err := NewClient(&Config{Address: "example.test", ReadRetryBackoffBase: time.Second, ReadRetryBackoffMax: time.Millisecond})
+		require.Error(t, err)
 	})
 }
 
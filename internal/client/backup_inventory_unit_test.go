@@ -2,8 +2,13 @@ package client
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/sethvargo/go-retry"
 )
 
 // These tests pin the two inventory waiters (backup virtual disk + virtual
@@ -182,3 +187,18 @@ func TestWaitForBackupVirtualMachineInventory(t *testing.T) {
 		}
 	})
 }
+
+func TestBackupInventoryWaitDeadlineIsATimeout(t *testing.T) {
+	calls := 0
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	b := retry.WithMaxRetries(1000, retry.BackoffFunc(func() (time.Duration, bool) {
+		return 5 * time.Millisecond, false
+	}))
+	_, err := waitForBackupVirtualMachineInventory(ctx, "vm-1", scriptedBackupVirtualMachineReads(&calls, bvmOutcome{}), b, nil)
+
+	var timeoutErr *WaitTimeoutError
+	if !errors.As(err, &timeoutErr) || !strings.Contains(err.Error(), `"vm-1"`) {
+		t.Fatalf("the context deadline must be a *WaitTimeoutError naming the VM, got %v", err)
+	}
+}
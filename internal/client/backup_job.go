@@ -30,25 +30,30 @@ type BackupJobFilter struct {
 }
 
 func (c *BackupJobClient) List(ctx context.Context, filter *BackupJobFilter) ([]*BackupJob, error) {
-	r := c.c.newRequest("GET", "/backup/v1/spp/jobs")
-	if filter != nil && filter.Type != "" {
-		r.params.Add("type", filter.Type)
-	}
-	resp, err := c.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	if err := requireOK(resp); err != nil {
-		return nil, err
-	}
+	return paginate(ctx, c.c, "backup job", 0,
+		func(item *BackupJob) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*BackupJob, error) {
+			r := c.c.newRequest("GET", "/backup/v1/spp/jobs")
+			if filter != nil && filter.Type != "" {
+				r.params.Add("type", filter.Type)
+			}
+			pg.addTo(r)
+			resp, err := c.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			if err := requireOK(resp); err != nil {
+				return nil, err
+			}
 
-	var out []*BackupJob
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
+			var out []*BackupJob
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
 
-	return out, nil
+			return out, nil
+		})
 }
 
 func (c *BackupJobClient) Read(ctx context.Context, id string) (*BackupJob, error) {
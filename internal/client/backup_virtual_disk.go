@@ -87,8 +87,12 @@ type backupVirtualDiskReadFunc func(ctx context.Context) (*BackupVirtualDisk, er
 // fatal at once (no transient-vs-permanent distinction — see #293 Finding F1; NOT
 // changed here), a nil result keeps polling (waiting for the disk to appear in the
 // inventory), and a found disk returns. The poll is bounded only by the injected
-// backoff / context.
+// backoff / context / options deadline; reaching the deadline is a
+// *WaitTimeoutError.
 func waitForBackupVirtualDiskInventory(ctx context.Context, id string, read backupVirtualDiskReadFunc, b retry.Backoff, options *WaiterOptions) (*BackupVirtualDisk, error) {
+	ctx, cancel := options.withDeadline(ctx)
+	defer cancel()
+
 	var res *BackupVirtualDisk
 	var count int
 
@@ -109,6 +113,6 @@ func waitForBackupVirtualDiskInventory(ctx context.Context, id string, read back
 		options.log(fmt.Sprintf("the virtual disk %q has been found.", id))
 		return nil
 	})
-	return res, err
+	return res, options.timeoutError(ctx, fmt.Sprintf("the virtual disk %q to appear in the backup inventory", id), err)
 
 }
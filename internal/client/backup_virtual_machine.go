@@ -98,8 +98,12 @@ type backupVirtualMachineReadFunc func(ctx context.Context) (*BackupVirtualMachi
 // with read and backoff injected. Behavior preserved exactly (same shape as the
 // disk inventory waiter): ANY read error is fatal at once (no transient distinction
 // — see #293 Finding F1; NOT changed here), a nil result keeps polling, a found VM
-// returns. The poll is bounded only by the injected backoff / context.
+// returns. The poll is bounded only by the injected backoff / context / options
+// deadline; reaching the deadline is a *WaitTimeoutError.
 func waitForBackupVirtualMachineInventory(ctx context.Context, id string, read backupVirtualMachineReadFunc, b retry.Backoff, options *WaiterOptions) (*BackupVirtualMachine, error) {
+	ctx, cancel := options.withDeadline(ctx)
+	defer cancel()
+
 	var res *BackupVirtualMachine
 	var count int
 
@@ -120,6 +124,6 @@ func waitForBackupVirtualMachineInventory(ctx context.Context, id string, read b
 		options.log(fmt.Sprintf("the virtual machine %q has been found.", id))
 		return nil
 	})
-	return res, err
+	return res, options.timeoutError(ctx, fmt.Sprintf("the virtual machine %q to appear in the backup inventory", id), err)
 
 }
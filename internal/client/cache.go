@@ -0,0 +1,207 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/singleflight"
+)
+
+// referenceFamilies are the products whose reference data (guest operating
+// systems, datastores, templates, flavors...) the client may cache. A write to
+// any endpoint of a family invalidates everything cached for it: creating a
+// virtual machine changes the free capacity of the datastores, deploying an
+// image adds a template.
+var referenceFamilies = []string{
+	"/compute/v1/vcenters",
+	"/compute/v1/open_iaas",
+	"/vm_instances/v1",
+}
+
+// referenceFamily returns the family of path, "" when it belongs to none.
+func referenceFamily(path string) string {
+	path = strings.TrimPrefix(path, "/api")
+	for _, family := range referenceFamilies {
+		if path == family || strings.HasPrefix(path, family+"/") {
+			return family
+		}
+	}
+	return ""
+}
+
+// referenceCache keeps the 200 responses of the reference reads for ttl, and
+// coalesces the identical reads in flight into a single request. It is opt-in,
+// see Config.ReferenceCacheTTL; a nil referenceCache caches nothing.
+type referenceCache struct {
+	ttl   time.Duration
+	group singleflight.Group
+
+	lock    sync.Mutex
+	entries map[string]*cachedResponse
+	// generations counts the invalidations of each family, so a read sent
+	// before a write is not cached after it.
+	generations map[string]uint64
+	// activities are the families written by the activities still running:
+	// the write is only visible once the activity completes, see settle.
+	activities map[string]string
+}
+
+type cachedResponse struct {
+	family  string
+	expires time.Time
+
+	status     int
+	statusText string
+	header     http.Header
+	body       []byte
+}
+
+func newReferenceCache(config *Config) *referenceCache {
+	if config.ReferenceCacheTTL <= 0 {
+		return nil
+	}
+	return &referenceCache{
+		ttl:         config.ReferenceCacheTTL,
+		entries:     map[string]*cachedResponse{},
+		generations: map[string]uint64{},
+		activities:  map[string]string{},
+	}
+}
+
+// do answers the reference read r from the cache, or sends it with send. The
+// concurrent callers of the same read share the response of the first one. It
+// is sent detached from the caller's cancellation, which only stops the wait,
+// so a cancelled caller does not fail the others.
+func (rc *referenceCache) do(ctx context.Context, r *request, send func(context.Context) (*http.Response, error)) (*http.Response, error) {
+	key := r.url.Path + "?" + r.params.Encode()
+	fields := map[string]interface{}{"path": r.url.Path}
+	if query := RedactHTTPQuery(r.params); query != "" {
+		fields["query"] = query
+	}
+
+	if entry := rc.get(key); entry != nil {
+		tflog.Debug(ctx, "Reference cache hit", fields)
+		return entry.response(), nil
+	}
+
+	family := referenceFamily(r.url.Path)
+	flight := rc.group.DoChan(key, func() (interface{}, error) {
+		generation := rc.generation(family)
+		resp, err := send(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		entry := &cachedResponse{
+			family:     family,
+			expires:    time.Now().Add(rc.ttl),
+			status:     resp.StatusCode,
+			statusText: resp.Status,
+			header:     resp.Header,
+			body:       body,
+		}
+		if resp.StatusCode == http.StatusOK {
+			rc.put(key, entry, generation)
+		}
+		return entry, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-flight:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		fields["shared"] = res.Shared
+		tflog.Debug(ctx, "Reference cache miss", fields)
+		return res.Val.(*cachedResponse).response(), nil
+	}
+}
+
+func (rc *referenceCache) get(key string) *cachedResponse {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+	entry := rc.entries[key]
+	if entry == nil {
+		return nil
+	}
+	if time.Now().After(entry.expires) {
+		delete(rc.entries, key)
+		return nil
+	}
+	return entry
+}
+
+func (rc *referenceCache) put(key string, entry *cachedResponse, generation uint64) {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+	if rc.generations[entry.family] == generation {
+		rc.entries[key] = entry
+	}
+}
+
+func (rc *referenceCache) generation(family string) uint64 {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+	return rc.generations[family]
+}
+
+// written invalidates the family of a write to path. When the write started
+// activity, the family is invalidated again once the activity completes.
+func (rc *referenceCache) written(path, activity string) {
+	family := referenceFamily(path)
+	if rc == nil || family == "" {
+		return
+	}
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+	rc.invalidate(family)
+	if activity != "" {
+		rc.activities[activity] = family
+	}
+}
+
+// settle invalidates the family written by activity, which has completed.
+func (rc *referenceCache) settle(activity string) {
+	if rc == nil {
+		return
+	}
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+	if family, ok := rc.activities[activity]; ok {
+		delete(rc.activities, activity)
+		rc.invalidate(family)
+	}
+}
+
+// invalidate must be called with the lock held.
+func (rc *referenceCache) invalidate(family string) {
+	rc.generations[family]++
+	for key, entry := range rc.entries {
+		if entry.family == family {
+			delete(rc.entries, key)
+		}
+	}
+}
+
+// response returns a copy of the cached response, with its own body.
+func (entry *cachedResponse) response() *http.Response {
+	return &http.Response{
+		Status:        entry.statusText,
+		StatusCode:    entry.status,
+		Header:        entry.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(entry.body)),
+		ContentLength: int64(len(entry.body)),
+	}
+}
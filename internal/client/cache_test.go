@@ -0,0 +1,245 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// referenceServer answers every GET with an empty list and every write with the
+// activity in the Location header, counting the GETs by path and query.
+type referenceServer struct {
+	lock     sync.Mutex
+	reads    map[string]int
+	location string
+}
+
+func (s *referenceServer) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		if s.location != "" {
+			w.Header().Set("Location", s.location)
+		}
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+	s.lock.Lock()
+	s.reads[r.URL.Path+"?"+r.URL.RawQuery]++
+	s.lock.Unlock()
+	_, _ = w.Write([]byte("[]"))
+}
+
+func (s *referenceServer) count(key string) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.reads[key]
+}
+
+func newReferenceTestClient(t *testing.T, ttl time.Duration) (*Client, *referenceServer) {
+	s := &referenceServer{reads: map[string]int{}}
+	c := newPATTestClient(t, s.handle)
+	c.cache = newReferenceCache(&Config{ReferenceCacheTTL: ttl})
+	return c, s
+}
+
+func TestReferenceCacheIsOptIn(t *testing.T) {
+	t.Setenv(ReferenceCacheTTLEnvName, "")
+	c, err := NewClient(&Config{Address: "example.test"})
+	require.NoError(t, err)
+	require.Nil(t, c.cache)
+}
+
+func TestReferenceCacheServesRepeatedReads(t *testing.T) {
+	c, s := newReferenceTestClient(t, time.Minute)
+	ctx := context.Background()
+	guestOS := c.Compute().GuestOperatingSystem()
+
+	for range 3 {
+		_, err := guestOS.List(ctx, &GuestOperatingSystemFilter{HostClusterID: "cluster"})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, s.count("/compute/v1/vcenters/guest_operating_systems?hostClusterId=cluster"))
+
+	_, err := guestOS.List(ctx, &GuestOperatingSystemFilter{HostClusterID: "other"})
+	require.NoError(t, err)
+	require.Equal(t, 1, s.count("/compute/v1/vcenters/guest_operating_systems?hostClusterId=other"), "another filter is another read")
+
+	for range 2 {
+		_, err := c.ObjectStorage().Bucket().List(ctx)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, s.count("/storage/object/v1/buckets?limit=200&offset=0"), "only the reference data is cached")
+}
+
+func TestReferenceCacheExpires(t *testing.T) {
+	c, s := newReferenceTestClient(t, time.Millisecond)
+	ctx := context.Background()
+
+	_, err := c.Compute().Datastore().List(ctx, &DatastoreFilter{})
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+	_, err = c.Compute().Datastore().List(ctx, &DatastoreFilter{})
+	require.NoError(t, err)
+	require.Equal(t, 2, s.count("/compute/v1/vcenters/datastores?"))
+}
+
+func TestReferenceCacheCoalescesConcurrentReads(t *testing.T) {
+	var reads atomic.Int32
+	arrived, release := make(chan struct{}), make(chan struct{})
+	c := newPATTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if reads.Add(1) == 1 {
+			close(arrived)
+		}
+		<-release
+		_, _ = w.Write([]byte(`{"flavors": [{"id": "flavor"}]}`))
+	})
+	c.cache = newReferenceCache(&Config{ReferenceCacheTTL: time.Minute})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			flavors, err := c.PublicCloudVM().Flavor().List(context.Background(), &PublicCloudVMFlavorFilter{})
+			if err == nil && len(flavors) != 1 {
+				err = fmt.Errorf("%d flavors, want 1", len(flavors))
+			}
+			errs <- err
+		}()
+	}
+	<-arrived
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.EqualValues(t, 1, reads.Load(), "the concurrent reads share one request")
+}
+
+func TestReferenceCacheCancelledCallerDoesNotFailTheOthers(t *testing.T) {
+	arrived, release := make(chan struct{}), make(chan struct{})
+	var reads atomic.Int32
+	c := newPATTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if reads.Add(1) == 1 {
+			close(arrived)
+		}
+		<-release
+		_, _ = w.Write([]byte("[]"))
+	})
+	c.cache = newReferenceCache(&Config{ReferenceCacheTTL: time.Minute})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := c.Compute().OpenIaaS().Template().List(ctx, &OpenIaaSTemplateFilter{})
+		first <- err
+	}()
+	<-arrived
+	second := make(chan error)
+	go func() {
+		_, err := c.Compute().OpenIaaS().Template().List(context.Background(), &OpenIaaSTemplateFilter{})
+		second <- err
+	}()
+	cancel()
+	require.ErrorIs(t, <-first, context.Canceled)
+	close(release)
+	require.NoError(t, <-second)
+	require.EqualValues(t, 1, reads.Load())
+}
+
+func TestReferenceCacheKeepsOnlyOK(t *testing.T) {
+	var reads atomic.Int32
+	c := newPATTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		reads.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	})
+	c.cache = newReferenceCache(&Config{ReferenceCacheTTL: time.Minute})
+
+	for range 2 {
+		oses, err := c.Compute().GuestOperatingSystem().List(context.Background(), &GuestOperatingSystemFilter{})
+		require.NoError(t, err)
+		require.Empty(t, oses)
+	}
+	require.EqualValues(t, 2, reads.Load())
+}
+
+func TestReferenceCacheInvalidatedByAWriteOfTheFamily(t *testing.T) {
+	c, s := newReferenceTestClient(t, time.Minute)
+	ctx := context.Background()
+	const templates = "/compute/v1/open_iaas/templates?"
+	readTemplates := func() {
+		_, err := c.Compute().OpenIaaS().Template().List(ctx, &OpenIaaSTemplateFilter{})
+		require.NoError(t, err)
+	}
+	write := func(path string) {
+		resp, err := c.doRequest(ctx, c.newRequest("POST", path))
+		require.NoError(t, err)
+		closeResponseBody(resp)
+	}
+
+	readTemplates()
+	write("/compute/v1/vcenters/virtual_machines")
+	readTemplates()
+	require.Equal(t, 1, s.count(templates), "a write to another family keeps the cache")
+
+	write("/compute/v1/open_iaas/virtual_machines")
+	readTemplates()
+	require.Equal(t, 2, s.count(templates), "a write to the family invalidates it")
+
+	// The write of an activity is invalidated again once the activity
+	// completes, as the reads in between may not see it yet.
+	s.location = "activity-1"
+	_, err := c.doRequestAndReturnActivity(ctx, c.newRequest("POST", "/compute/v1/open_iaas/templates"))
+	require.NoError(t, err)
+	readTemplates()
+	readTemplates()
+	require.Equal(t, 3, s.count(templates))
+	c.cache.settle("activity-1")
+	readTemplates()
+	require.Equal(t, 4, s.count(templates))
+}
+
+func TestReferenceCacheIgnoresAReadSentBeforeAWrite(t *testing.T) {
+	arrived, release := make(chan struct{}), make(chan struct{})
+	var reads atomic.Int32
+	c := newPATTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && reads.Add(1) == 1 {
+			close(arrived)
+			<-release
+		}
+		_, _ = w.Write([]byte("[]"))
+	})
+	c.cache = newReferenceCache(&Config{ReferenceCacheTTL: time.Minute})
+	ctx := context.Background()
+
+	done := make(chan error)
+	go func() {
+		_, err := c.Compute().Host().List(ctx, &HostFilter{})
+		done <- err
+	}()
+	<-arrived
+	resp, err := c.doRequest(ctx, c.newRequest("DELETE", "/compute/v1/vcenters/virtual_machines/vm"))
+	require.NoError(t, err)
+	closeResponseBody(resp)
+	close(release)
+	require.NoError(t, <-done)
+
+	_, err = c.Compute().Host().List(ctx, &HostFilter{})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, reads.Load(), "a response read before the write must not be cached")
+}
+
+func TestDefaultConfigReadsReferenceCacheEnv(t *testing.T) {
+	t.Setenv(ReferenceCacheTTLEnvName, "5m")
+	require.Equal(t, 5*time.Minute, defaultConfig(t).ReferenceCacheTTL)
+
+	t.Setenv(ReferenceCacheTTLEnvName, "soon")
+	require.Zero(t, defaultConfig(t).ReferenceCacheTTL, "an invalid value keeps the cache disabled")
+}
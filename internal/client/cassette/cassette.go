@@ -0,0 +1,369 @@
+// Package cassette records the requests sent to the API and their responses in
+// a file, a cassette, and replays them later without network. The tests use it
+// to run against a recorded tenant.
+package cassette
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+)
+
+// ModeEnvName is the environment variable selecting the Mode of the tests.
+const ModeEnvName = "CLOUDTEMPLE_CASSETTE_MODE"
+
+// Mode tells what a Recorder does with the requests.
+type Mode string
+
+const (
+	// ModeLive sends the requests to the API and records nothing.
+	ModeLive Mode = "live"
+	// ModeRecord sends the requests to the API and records them.
+	ModeRecord Mode = "record"
+	// ModeReplay serves the requests from the cassette, without network.
+	ModeReplay Mode = "replay"
+)
+
+// ParseMode parses the value of ModeEnvName; an empty value is ModeLive.
+func ParseMode(s string) (Mode, error) {
+	switch mode := Mode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case "":
+		return ModeLive, nil
+	case ModeLive, ModeRecord, ModeReplay:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid %s %q: must be live, record or replay", ModeEnvName, s)
+	}
+}
+
+const redacted = "<redacted>"
+
+const (
+	// scrubbedTokenExpiry replaces the expiry of a recorded token, so the
+	// client never tries to renew it during a replay (2100-01-01).
+	scrubbedTokenExpiry = 4102444800
+	scrubbedSignature   = "c2NydWJiZWQ"
+)
+
+// keptHeaders are the response headers saved in a cassette; the others may
+// carry cookies or session identifiers.
+var keptHeaders = []string{"Content-Type", "Location", "Retry-After"}
+
+var jwtPattern = regexp.MustCompile(`^eyJ[\w-]*\.[\w-]+\.[\w-]*$`)
+
+// Cassette is the content of a cassette file.
+type Cassette struct {
+	// Env holds the environment the interactions were recorded with, such as
+	// the identifiers of the resources the tests read.
+	Env          map[string]string `json:"env,omitempty"`
+	Interactions []*Interaction    `json:"interactions"`
+}
+
+// Interaction is a request and the response the API sent back.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+type Response struct {
+	Status int               `json:"status"`
+	Header map[string]string `json:"header,omitempty"`
+	Body   string            `json:"body,omitempty"`
+}
+
+// key identifies the requests an interaction answers.
+func (r *Request) key() string {
+	return r.Method + " " + r.Path + "?" + r.Query + "\n" + r.Body
+}
+
+// Recorder is an http.RoundTripper recording or replaying a cassette. The
+// secrets are scrubbed before they are recorded: the credentials sent to log
+// in, the signature of the tokens, and the values client.RedactHTTPBody
+// redacts. The requests are matched by method, path, query and body, the
+// body being compared once scrubbed and with its JSON keys sorted.
+//
+// The interactions answering the same request are replayed in the order they
+// were recorded, so polling an activity sees it progress as it did. Once they
+// are exhausted, the last response to a GET is served again; replaying any
+// other request again is an error.
+type Recorder struct {
+	mode Mode
+	path string
+	base http.RoundTripper
+
+	lock     sync.Mutex
+	cassette *Cassette
+	served   map[string]int
+}
+
+// New returns a Recorder for the cassette at path. In ModeReplay the cassette
+// must exist; the error then wraps os.ErrNotExist. base sends the requests in
+// the other modes.
+func New(path string, mode Mode, base http.RoundTripper) (*Recorder, error) {
+	r := &Recorder{
+		mode:     mode,
+		path:     path,
+		base:     base,
+		cassette: &Cassette{Env: map[string]string{}},
+		served:   map[string]int{},
+	}
+	if mode != ModeReplay {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	if err := json.Unmarshal(data, r.cassette); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// Mode returns the mode of the recorder.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Env returns the environment saved in the cassette.
+func (r *Recorder) Env() map[string]string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	env := make(map[string]string, len(r.cassette.Env))
+	for name, value := range r.cassette.Env {
+		env[name] = value
+	}
+	return env
+}
+
+// SetEnv saves an environment variable in the cassette being recorded.
+func (r *Recorder) SetEnv(name, value string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.cassette.Env[name] = value
+}
+
+// Stop writes the cassette in ModeRecord; it does nothing in the other modes.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.lock.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.lock.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch r.mode {
+	case ModeRecord:
+		return r.record(req)
+	case ModeReplay:
+		return r.replay(req)
+	default:
+		return r.base.RoundTrip(req)
+	}
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := &Interaction{
+		Request: scrubRequest(req, body),
+		Response: Response{
+			Status: resp.StatusCode,
+			Header: map[string]string{},
+			Body:   scrubResponseBody(respBody),
+		},
+	}
+	for _, name := range keptHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			interaction.Response.Header[name] = value
+		}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	request := scrubRequest(req, body)
+	key := request.key()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var matches []*Interaction
+	for _, interaction := range r.cassette.Interactions {
+		if interaction.Request.key() == key {
+			matches = append(matches, interaction)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("cassette %s: no interaction recorded for %s %s", r.path, request.Method, requestURI(request))
+	}
+
+	i := r.served[key]
+	if i >= len(matches) {
+		if request.Method != http.MethodGet {
+			return nil, fmt.Errorf("cassette %s: %s %s was replayed %d times already", r.path, request.Method, requestURI(request), len(matches))
+		}
+		i = len(matches) - 1
+	}
+	r.served[key]++
+
+	recorded := matches[i].Response
+	resp := &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{},
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+	for name, value := range recorded.Header {
+		resp.Header.Set(name, value)
+	}
+	return resp, nil
+}
+
+func requestURI(r Request) string {
+	if r.Query == "" {
+		return r.Path
+	}
+	return r.Path + "?" + r.Query
+}
+
+// scrubRequest returns the request as it is recorded, and matched.
+func scrubRequest(req *http.Request, body []byte) Request {
+	request := Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  client.RedactHTTPQuery(req.URL.Query()),
+	}
+	switch {
+	case len(bytes.TrimSpace(body)) == 0:
+	case strings.HasSuffix(req.URL.Path, "/auth/personal_access_token"):
+		// The whole login is dropped, so a replay logs in with any credentials.
+		request.Body = redacted
+	default:
+		request.Body = client.RedactHTTPBody(normalizeBody(body))
+	}
+	return request
+}
+
+// normalizeBody compacts a JSON body and sorts its keys, so the encoding of a
+// request does not change whether it matches.
+func normalizeBody(body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return strings.TrimSpace(string(body))
+	}
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return strings.TrimSpace(string(body))
+	}
+	return string(normalized)
+}
+
+// scrubResponseBody redacts the secrets of a response. The token returned by
+// the login is kept parseable, as the client reads its claims, but loses its
+// signature and expires far in the future.
+func scrubResponseBody(body []byte) string {
+	trimmed := strings.TrimSpace(string(body))
+	if jwtPattern.MatchString(trimmed) {
+		return scrubToken(trimmed)
+	}
+	return client.RedactHTTPBody(string(body))
+}
+
+func scrubToken(token string) string {
+	parts := strings.Split(token, ".")
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return redacted
+	}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	claims := map[string]interface{}{}
+	if err := decoder.Decode(&claims); err != nil {
+		return redacted
+	}
+	claims["exp"] = scrubbedTokenExpiry
+	payload, err = json.Marshal(claims)
+	if err != nil {
+		return redacted
+	}
+	return parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload) + "." + scrubbedSignature
+}
+
+// IsNotRecorded reports whether err is the error New returns for a missing
+// cassette in ModeReplay.
+func IsNotRecorded(err error) bool {
+	return errors.Is(err, os.ErrNotExist)
+}
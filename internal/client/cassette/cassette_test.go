@@ -0,0 +1,168 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMode(t *testing.T) {
+	for value, expected := range map[string]Mode{
+		"":        ModeLive,
+		"live":    ModeLive,
+		"Record":  ModeRecord,
+		" replay": ModeReplay,
+	} {
+		mode, err := ParseMode(value)
+		require.NoError(t, err)
+		require.Equal(t, expected, mode)
+	}
+	_, err := ParseMode("rewind")
+	require.Error(t, err)
+}
+
+// fakeAPI logs in with a signed token and reports an activity running, then
+// completed.
+func fakeAPI(t *testing.T) *httptest.Server {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"userId":    "user-1",
+		"companyId": "company-1",
+		"scope":     map[string]interface{}{"id": "tenant-1"},
+		"exp":       time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("signing-key"))
+	require.NoError(t, err)
+
+	var reads int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/auth/personal_access_token"):
+			_, _ = io.WriteString(w, token)
+		case r.Method == http.MethodPost:
+			w.Header().Set("Location", "/activity/v1/activities/42")
+			w.Header().Set("Set-Cookie", "session=do-not-record")
+			w.WriteHeader(http.StatusCreated)
+		default:
+			state := "running"
+			if atomic.AddInt32(&reads, 1) > 1 {
+				state = "completed"
+			}
+			_, _ = io.WriteString(w, `{"id":"42","state":{"`+state+`":{}},"secretAccessKey":"do-not-record"}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// scenario logs in, starts an activity and reads it until it completes.
+func scenario(t *testing.T, address string, transport http.RoundTripper) []string {
+	c, err := client.NewClient(&client.Config{
+		Address:   address,
+		ClientID:  "client-id",
+		SecretID:  "do-not-record",
+		Transport: transport,
+	})
+	require.NoError(t, err)
+	token, err := c.Token(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "tenant-1", token.TenantID())
+
+	post, err := http.NewRequest(http.MethodPost, address+"/compute/v1/vcenters/virtual_machines",
+		strings.NewReader(`{"name":"vm-1","password":"do-not-record"}`))
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(post)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	require.Equal(t, "/activity/v1/activities/42", resp.Header.Get("Location"))
+
+	var states []string
+	for i := 0; i < 3; i++ {
+		activity, err := c.Activity().Read(context.Background(), "42")
+		require.NoError(t, err)
+		for state := range activity.State {
+			states = append(states, state)
+		}
+	}
+	return states
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	srv := fakeAPI(t)
+	path := filepath.Join(t.TempDir(), "cassettes", "lifecycle.json")
+
+	recorder, err := New(path, ModeRecord, http.DefaultTransport)
+	require.NoError(t, err)
+	recorder.SetEnv("COMPUTE_VIRTUAL_MACHINE_ID", "vm-1")
+	require.Equal(t, []string{"running", "completed", "completed"}, scenario(t, srv.URL, recorder))
+	require.NoError(t, recorder.Stop())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	for _, secret := range []string{"do-not-record", "client-id", "signing-key", "session="} {
+		require.NotContains(t, string(data), secret)
+	}
+
+	// The replay has no network, other credentials and another address.
+	offline := roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("no network")
+	})
+	replayer, err := New(path, ModeReplay, offline)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"COMPUTE_VIRTUAL_MACHINE_ID": "vm-1"}, replayer.Env())
+	require.Equal(t, []string{"running", "completed", "completed"}, scenario(t, "http://replay.invalid", replayer))
+}
+
+func TestReplayMatchesTheBody(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := New(path, ModeRecord, roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusCreated, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}))
+	require.NoError(t, err)
+	resp, err := recorder.RoundTrip(newRequest(t, http.MethodPost, "/tags?b=2&a=1", `{"key":"env","value":"prod"}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.NoError(t, recorder.Stop())
+
+	replayer, err := New(path, ModeReplay, nil)
+	require.NoError(t, err)
+
+	_, err = replayer.RoundTrip(newRequest(t, http.MethodPost, "/tags?a=1&b=2", `{"value":"staging","key":"env"}`))
+	require.ErrorContains(t, err, "no interaction recorded")
+
+	// The same body, keys and parameters in another order.
+	resp, err = replayer.RoundTrip(newRequest(t, http.MethodPost, "/tags?a=1&b=2", `{ "value": "prod", "key": "env" }`))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	_, err = replayer.RoundTrip(newRequest(t, http.MethodPost, "/tags?a=1&b=2", `{"key":"env","value":"prod"}`))
+	require.ErrorContains(t, err, "replayed 1 times already")
+}
+
+func TestReplayNeedsTheCassette(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay, nil)
+	require.True(t, IsNotRecorded(err))
+}
+
+func newRequest(t *testing.T, method, uri, body string) *http.Request {
+	req, err := http.NewRequest(method, "http://api.invalid"+uri, strings.NewReader(body))
+	require.NoError(t, err)
+	return req
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
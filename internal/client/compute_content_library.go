@@ -27,6 +27,7 @@ type ContentLibraryFilter struct {
 
 func (c *ContentLibraryClient) List(ctx context.Context, filter *ContentLibraryFilter) ([]*ContentLibrary, error) {
 	r := c.c.newRequest("GET", "/compute/v1/vcenters/content_libraries")
+	r.reference = true
 	r.addFilter(filter)
 	resp, err := c.c.doRequest(ctx, r)
 	if err != nil {
@@ -47,6 +48,7 @@ func (c *ContentLibraryClient) List(ctx context.Context, filter *ContentLibraryF
 
 func (c *ContentLibraryClient) Read(ctx context.Context, id string) (*ContentLibrary, error) {
 	r := c.c.newRequest("GET", "/compute/v1/vcenters/content_libraries/%s", id)
+	r.reference = true
 	resp, err := c.c.doRequest(ctx, r)
 	if err != nil {
 		return nil, err
@@ -83,6 +85,7 @@ type ContentLibraryItemFilter struct {
 
 func (c *ContentLibraryClient) ListItems(ctx context.Context, filter *ContentLibraryItemFilter) ([]*ContentLibraryItem, error) {
 	r := c.c.newRequest("GET", "/compute/v1/vcenters/content_libraries/%s/items", filter.ContentLibraryId)
+	r.reference = true
 	r.addFilter(filter)
 	resp, err := c.c.doRequest(ctx, r)
 	if err != nil {
@@ -104,6 +107,7 @@ func (c *ContentLibraryClient) ListItems(ctx context.Context, filter *ContentLib
 
 func (c *ContentLibraryClient) ReadItem(ctx context.Context, contentLibraryId, contentLibraryItemId string) (*ContentLibraryItem, error) {
 	r := c.c.newRequest("GET", "/compute/v1/vcenters/content_libraries/%s/items/%s", contentLibraryId, contentLibraryItemId)
+	r.reference = true
 	resp, err := c.c.doRequest(ctx, r)
 	if err != nil {
 		return nil, err
@@ -41,6 +41,7 @@ func (d *DatastoreClient) List(
 	filter *DatastoreFilter) ([]*Datastore, error) {
 
 	r := d.c.newRequest("GET", "/compute/v1/vcenters/datastores")
+	r.reference = true
 	r.addFilter(filter)
 	resp, err := d.c.doRequest(ctx, r)
 	if err != nil {
@@ -61,6 +62,7 @@ func (d *DatastoreClient) List(
 
 func (d *DatastoreClient) Read(ctx context.Context, id string) (*Datastore, error) {
 	r := d.c.newRequest("GET", "/compute/v1/vcenters/datastores/%s", id)
+	r.reference = true
 	resp, err := d.c.doRequest(ctx, r)
 	if err != nil {
 		return nil, err
@@ -46,6 +46,7 @@ type DatastoreClusterMetrics struct {
 
 func (d *DatastoreClusterClient) List(ctx context.Context, filter *DatastoreClusterFilter) ([]*DatastoreCluster, error) {
 	r := d.c.newRequest("GET", "/compute/v1/vcenters/datastore_clusters")
+	r.reference = true
 	r.addFilter(filter)
 	resp, err := d.c.doRequest(ctx, r)
 	if err != nil {
@@ -66,6 +67,7 @@ func (d *DatastoreClusterClient) List(ctx context.Context, filter *DatastoreClus
 
 func (d *DatastoreClusterClient) Read(ctx context.Context, id string) (*DatastoreCluster, error) {
 	r := d.c.newRequest("GET", "/compute/v1/vcenters/datastore_clusters/%s", id)
+	r.reference = true
 	resp, err := d.c.doRequest(ctx, r)
 	if err != nil {
 		return nil, err
@@ -24,6 +24,7 @@ type FolderFilter struct {
 
 func (f *FolderClient) List(ctx context.Context, filter *FolderFilter) ([]*Folder, error) {
 	r := f.c.newRequest("GET", "/compute/v1/vcenters/folders")
+	r.reference = true
 	r.addFilter(filter)
 	resp, err := f.c.doRequest(ctx, r)
 	if err != nil {
@@ -44,6 +45,7 @@ func (f *FolderClient) List(ctx context.Context, filter *FolderFilter) ([]*Folde
 
 func (f *FolderClient) Read(ctx context.Context, id string) (*Folder, error) {
 	r := f.c.newRequest("GET", "/compute/v1/vcenters/folders/%s", id)
+	r.reference = true
 	resp, err := f.c.doRequest(ctx, r)
 	if err != nil {
 		return nil, err
@@ -28,6 +28,7 @@ func (g *GuestOperatingSystemClient) List(
 	filter *GuestOperatingSystemFilter) ([]*GuestOperatingSystem, error) {
 
 	r := g.c.newRequest("GET", "/compute/v1/vcenters/guest_operating_systems")
+	r.reference = true
 	r.addFilter(filter)
 	resp, err := g.c.doRequest(ctx, r)
 	if err != nil {
@@ -49,6 +50,7 @@ func (g *GuestOperatingSystemClient) List(
 
 func (g *GuestOperatingSystemClient) Read(ctx context.Context, moref string, filter *GuestOperatingSystemFilter) (*GuestOperatingSystem, error) {
 	r := g.c.newRequest("GET", "/compute/v1/vcenters/guest_operating_systems/%s", moref)
+	r.reference = true
 	r.addFilter(filter)
 	resp, err := g.c.doRequest(ctx, r)
 	if err != nil {
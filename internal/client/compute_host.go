@@ -61,6 +61,7 @@ type HostFilter struct {
 
 func (h *HostClient) List(ctx context.Context, filter *HostFilter) ([]*Host, error) {
 	r := h.c.newRequest("GET", "/compute/v1/vcenters/hosts")
+	r.reference = true
 	r.addFilter(filter)
 	resp, err := h.c.doRequest(ctx, r)
 	if err != nil {
@@ -81,6 +82,7 @@ func (h *HostClient) List(ctx context.Context, filter *HostFilter) ([]*Host, err
 
 func (h *HostClient) Read(ctx context.Context, id string) (*Host, error) {
 	r := h.c.newRequest("GET", "/compute/v1/vcenters/hosts/%s", id)
+	r.reference = true
 	resp, err := h.c.doRequest(ctx, r)
 	if err != nil {
 		return nil, err
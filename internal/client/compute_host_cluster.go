@@ -48,6 +48,7 @@ func (h *HostClusterClient) List(
 	filter *HostClusterFilter) ([]*HostCluster, error) {
 
 	r := h.c.newRequest("GET", "/compute/v1/vcenters/host_clusters")
+	r.reference = true
 	r.addFilter(filter)
 	resp, err := h.c.doRequest(ctx, r)
 	if err != nil {
@@ -68,6 +69,7 @@ func (h *HostClusterClient) List(
 
 func (h *HostClusterClient) Read(ctx context.Context, id string) (*HostCluster, error) {
 	r := h.c.newRequest("GET", "/compute/v1/vcenters/host_clusters/%s", id)
+	r.reference = true
 	resp, err := h.c.doRequest(ctx, r)
 	if err != nil {
 		return nil, err
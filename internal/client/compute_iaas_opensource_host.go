@@ -61,6 +61,7 @@ func (h *OpenIaaSHostClient) List(
 	filter *OpenIaasHostFilter) ([]*OpenIaaSHost, error) {
 
 	r := h.c.newRequest("GET", "/compute/v1/open_iaas/hosts")
+	r.reference = true
 	r.addFilter(filter)
 	resp, err := h.c.doRequest(ctx, r)
 	if err != nil {
@@ -81,6 +82,7 @@ func (h *OpenIaaSHostClient) List(
 
 func (h *OpenIaaSHostClient) Read(ctx context.Context, id string) (*OpenIaaSHost, error) {
 	r := h.c.newRequest("GET", "/compute/v1/open_iaas/hosts/%s", id)
+	r.reference = true
 	resp, err := h.c.doRequest(ctx, r)
 	if err != nil {
 		return nil, err
@@ -19,6 +19,7 @@ type OpenIaaSMachineManager struct {
 
 func (v *OpenIaaSMachineManagerClient) Read(ctx context.Context, id string) (*OpenIaaSMachineManager, error) {
 	r := v.c.newRequest("GET", "/compute/v1/open_iaas/%s", id)
+	r.reference = true
 	resp, err := v.c.doRequest(ctx, r)
 	if err != nil {
 		return nil, err
@@ -39,6 +40,7 @@ func (v *OpenIaaSMachineManagerClient) Read(ctx context.Context, id string) (*Op
 
 func (v *OpenIaaSMachineManagerClient) List(ctx context.Context) ([]*OpenIaaSMachineManager, error) {
 	r := v.c.newRequest("GET", "/compute/v1/open_iaas")
+	r.reference = true
 	resp, err := v.c.doRequest(ctx, r)
 	if err != nil {
 		return nil, err
@@ -56,6 +56,29 @@ func (n *OpenIaaSNetworkClient) List(
 	return out, nil
 }
 
+// ListStrict behaves like List but requires a complete HTTP 200 answer: 206 is
+// a partial listing, and any other code (including 403) is an error rather
+// than an answer (#281).
+func (n *OpenIaaSNetworkClient) ListStrict(ctx context.Context, filter *OpenIaaSNetworkFilter) ([]*OpenIaaSNetwork, error) {
+	r := n.c.newRequest("GET", "/compute/v1/open_iaas/networks")
+	r.addFilter(filter)
+	resp, err := n.c.doRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireHttpCodes(resp, 200); err != nil {
+		return nil, err
+	}
+
+	var out []*OpenIaaSNetwork
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 func (n *OpenIaaSNetworkClient) Read(ctx context.Context, id string) (*OpenIaaSNetwork, error) {
 	r := n.c.newRequest("GET", "/compute/v1/open_iaas/networks/%s", id)
 	resp, err := n.c.doRequest(ctx, r)
@@ -91,45 +91,55 @@ type OpenIaaSNetworkAdapterFilter struct {
 // error instead of an empty result: callers using the listing as EVIDENCE
 // for state-shrinking decisions must fail closed (#273).
 func (v *OpenIaaSNetworkAdapterClient) ListStrict(ctx context.Context, filter *OpenIaaSNetworkAdapterFilter) ([]*OpenIaaSNetworkAdapter, error) {
-	r := v.c.newRequest("GET", "/compute/v1/open_iaas/network_adapters")
-	r.addFilter(filter)
-	resp, err := v.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	// Strictly 200: a 206 partial listing cannot prove an absence.
-	if err := requireHttpCodes(resp, 200); err != nil {
-		return nil, err
-	}
-
-	var out []*OpenIaaSNetworkAdapter
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, v.c, "network adapter", 0,
+		func(item *OpenIaaSNetworkAdapter) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*OpenIaaSNetworkAdapter, error) {
+			r := v.c.newRequest("GET", "/compute/v1/open_iaas/network_adapters")
+			r.addFilter(filter)
+			pg.addTo(r)
+			resp, err := v.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			// Strictly 200: a 206 partial listing cannot prove an absence.
+			if err := requireHttpCodes(resp, 200); err != nil {
+				return nil, err
+			}
+
+			var out []*OpenIaaSNetworkAdapter
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
 }
 
 func (v *OpenIaaSNetworkAdapterClient) List(ctx context.Context, filter *OpenIaaSNetworkAdapterFilter) ([]*OpenIaaSNetworkAdapter, error) {
-	r := v.c.newRequest("GET", "/compute/v1/open_iaas/network_adapters")
-	r.addFilter(filter)
-	resp, err := v.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	found, err := requireNotFoundOrOK(resp, 404)
-	if err != nil || !found {
-		return nil, err
-	}
-
-	var out []*OpenIaaSNetworkAdapter
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, v.c, "network adapter", 0,
+		func(item *OpenIaaSNetworkAdapter) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*OpenIaaSNetworkAdapter, error) {
+			r := v.c.newRequest("GET", "/compute/v1/open_iaas/network_adapters")
+			r.addFilter(filter)
+			pg.addTo(r)
+			resp, err := v.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			found, err := requireNotFoundOrOK(resp, 404)
+			if err != nil || !found {
+				return nil, err
+			}
+
+			var out []*OpenIaaSNetworkAdapter
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
 }
 
 type UpdateOpenIaasNetworkAdapterRequest struct {
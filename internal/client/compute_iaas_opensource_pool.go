@@ -42,6 +42,7 @@ func (p *OpenIaasPoolClient) List(
 	filter *OpenIaasPoolFilter) ([]*OpenIaasPool, error) {
 
 	r := p.c.newRequest("GET", "/compute/v1/open_iaas/pools")
+	r.reference = true
 	r.addFilter(filter)
 	resp, err := p.c.doRequest(ctx, r)
 	if err != nil {
@@ -62,6 +63,7 @@ func (p *OpenIaasPoolClient) List(
 
 func (p *OpenIaasPoolClient) Read(ctx context.Context, id string) (*OpenIaasPool, error) {
 	r := p.c.newRequest("GET", "/compute/v1/open_iaas/pools/%s", id)
+	r.reference = true
 	resp, err := p.c.doRequest(ctx, r)
 	if err != nil {
 		return nil, err
@@ -80,42 +80,52 @@ func (v *ComputeOpenIaaSReplicationPolicyClient) Read(ctx context.Context, id st
 // using the listing as EVIDENCE for state-shrinking decisions must fail
 // closed on access-denied or partial answers (#275 doctrine, FF-5).
 func (v *ComputeOpenIaaSReplicationPolicyClient) ListStrict(ctx context.Context) ([]*OpenIaaSReplicationPolicy, error) {
-	r := v.c.newRequest("GET", "/compute/v1/open_iaas/replication/configurations")
-	resp, err := v.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	// Strictly 200: a 206 partial listing cannot prove an absence.
-	if err := requireHttpCodes(resp, 200); err != nil {
-		return nil, err
-	}
-
-	var out []*OpenIaaSReplicationPolicy
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, v.c, "replication policy", 0,
+		func(item *OpenIaaSReplicationPolicy) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*OpenIaaSReplicationPolicy, error) {
+			r := v.c.newRequest("GET", "/compute/v1/open_iaas/replication/configurations")
+			pg.addTo(r)
+			resp, err := v.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			// Strictly 200: a 206 partial listing cannot prove an absence.
+			if err := requireHttpCodes(resp, 200); err != nil {
+				return nil, err
+			}
+
+			var out []*OpenIaaSReplicationPolicy
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
 }
 
 func (v *ComputeOpenIaaSReplicationPolicyClient) List(ctx context.Context) ([]*OpenIaaSReplicationPolicy, error) {
-	r := v.c.newRequest("GET", "/compute/v1/open_iaas/replication/configurations")
-	resp, err := v.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	if err := requireOK(resp); err != nil {
-		return nil, err
-	}
-
-	var out []*OpenIaaSReplicationPolicy
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, v.c, "replication policy", 0,
+		func(item *OpenIaaSReplicationPolicy) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*OpenIaaSReplicationPolicy, error) {
+			r := v.c.newRequest("GET", "/compute/v1/open_iaas/replication/configurations")
+			pg.addTo(r)
+			resp, err := v.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			if err := requireOK(resp); err != nil {
+				return nil, err
+			}
+
+			var out []*OpenIaaSReplicationPolicy
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
 }
 
 func (v *ComputeOpenIaaSReplicationPolicyClient) Delete(ctx context.Context, id string) (string, error) {
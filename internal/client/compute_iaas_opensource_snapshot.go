@@ -43,22 +43,84 @@ type OpenIaaSSnapshotFilter struct {
 }
 
 func (v *OpenIaaSSnapshotClient) List(ctx context.Context, filter *OpenIaaSSnapshotFilter) ([]*OpenIaaSSnapshot, error) {
-	r := v.c.newRequest("GET", "/compute/v1/open_iaas/snapshots")
-	r.addFilter(filter)
-	resp, err := v.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	found, err := requireNotFoundOrOK(resp, 404)
-	if err != nil || !found {
-		return nil, err
-	}
+	return paginate(ctx, v.c, "snapshot", 0,
+		func(item *OpenIaaSSnapshot) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*OpenIaaSSnapshot, error) {
+			r := v.c.newRequest("GET", "/compute/v1/open_iaas/snapshots")
+			r.addFilter(filter)
+			pg.addTo(r)
+			resp, err := v.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			found, err := requireNotFoundOrOK(resp, 404)
+			if err != nil || !found {
+				return nil, err
+			}
 
-	var out []*OpenIaaSSnapshot
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
+			var out []*OpenIaaSSnapshot
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
+}
+
+// ListStrict behaves like List but requires a complete HTTP 200 answer: 206 is
+// a partial listing and cannot prove an absence, and any other code (including
+// 403 and 404) is an error rather than being mapped to an empty result. The
+// snapshot resource confirms a deletion through this listing only (#281).
+func (v *OpenIaaSSnapshotClient) ListStrict(ctx context.Context, filter *OpenIaaSSnapshotFilter) ([]*OpenIaaSSnapshot, error) {
+	return paginate(ctx, v.c, "snapshot", 0,
+		func(item *OpenIaaSSnapshot) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*OpenIaaSSnapshot, error) {
+			r := v.c.newRequest("GET", "/compute/v1/open_iaas/snapshots")
+			r.addFilter(filter)
+			pg.addTo(r)
+			resp, err := v.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			if err := requireHttpCodes(resp, 200); err != nil {
+				return nil, err
+			}
+
+			var out []*OpenIaaSSnapshot
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
+}
+
+type CreateOpenIaaSSnapshotRequest struct {
+	VirtualMachineID string `json:"virtualMachineId"`
+	Name             string `json:"name"`
+	Description      string `json:"description,omitempty"`
+	// SaveMemory also captures the RAM of a running virtual machine, so a
+	// revert resumes it instead of booting it from the disks.
+	SaveMemory bool `json:"saveMemory"`
+}
+
+// Create takes a snapshot of a virtual machine and returns the activityId. The
+// completed activity's "snapshot" concerned item carries the new snapshot id.
+func (v *OpenIaaSSnapshotClient) Create(ctx context.Context, req *CreateOpenIaaSSnapshotRequest) (string, error) {
+	r := v.c.newRequest("POST", "/compute/v1/open_iaas/snapshots")
+	r.obj = req
+	return v.c.doRequestAndReturnActivity(ctx, r)
+}
+
+// Revert reverts the snapshot's virtual machine to it and returns the activityId.
+func (v *OpenIaaSSnapshotClient) Revert(ctx context.Context, id string) (string, error) {
+	r := v.c.newRequest("POST", "/compute/v1/open_iaas/snapshots/%s/revert", id)
+	return v.c.doRequestAndReturnActivity(ctx, r)
+}
 
-	return out, nil
+func (v *OpenIaaSSnapshotClient) Delete(ctx context.Context, id string) (string, error) {
+	r := v.c.newRequest("DELETE", "/compute/v1/open_iaas/snapshots/%s", id)
+	return v.c.doRequestAndReturnActivity(ctx, r)
 }
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestOpenIaaSSnapshotListStrict(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("200 scopes by virtualMachineId and returns the parsed snapshots", func(t *testing.T) {
+		var method, path string
+		var query url.Values
+		c := newPATTestClient(t, captureHandler(http.StatusOK, `[{"id":"snap-1","virtualMachineId":"vm-1"}]`, &method, &path, &query))
+		snapshots, err := c.Compute().OpenIaaS().Snapshot().ListStrict(ctx, &OpenIaaSSnapshotFilter{VirtualMachineID: "vm-1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(snapshots) != 1 || snapshots[0].ID != "snap-1" || snapshots[0].VirtualMachineID != "vm-1" {
+			t.Fatalf("unexpected snapshots: %+v", snapshots)
+		}
+		if method != http.MethodGet || path != "/compute/v1/open_iaas/snapshots" {
+			t.Fatalf("unexpected request: %s %s", method, path)
+		}
+		if query.Get("virtualMachineId") != "vm-1" {
+			t.Fatalf("expected virtualMachineId=vm-1, got query %v", query)
+		}
+	})
+
+	t.Run("404 is not an empty listing", func(t *testing.T) {
+		c := newPATTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+		if _, err := c.Compute().OpenIaaS().Snapshot().ListStrict(ctx, &OpenIaaSSnapshotFilter{VirtualMachineID: "vm-1"}); err == nil {
+			t.Fatal("a 404 must be rejected: it cannot prove the absence of a snapshot")
+		}
+	})
+
+	runListStrictRejections(t, func(c *Client) error {
+		_, err := c.Compute().OpenIaaS().Snapshot().ListStrict(ctx, &OpenIaaSSnapshotFilter{VirtualMachineID: "vm-1"})
+		return err
+	})
+}
+
+func TestOpenIaaSSnapshotCreateSendsSaveMemory(t *testing.T) {
+	for _, saveMemory := range []bool{false, true} {
+		var method, path string
+		var body map[string]interface{}
+		c := newPATTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			method, path = r.Method, r.URL.Path
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			w.Header().Set("Location", "act-1")
+			w.WriteHeader(http.StatusCreated)
+		})
+		activityID, err := c.Compute().OpenIaaS().Snapshot().Create(context.Background(), &CreateOpenIaaSSnapshotRequest{
+			VirtualMachineID: "vm-1",
+			Name:             "pre-upgrade",
+			SaveMemory:       saveMemory,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if activityID != "act-1" {
+			t.Fatalf("activityID = %q, want act-1", activityID)
+		}
+		if method != http.MethodPost || path != "/compute/v1/open_iaas/snapshots" {
+			t.Fatalf("unexpected request: %s %s", method, path)
+		}
+		// saveMemory is always sent: false must be explicit, not omitted.
+		if got, ok := body["saveMemory"].(bool); !ok || got != saveMemory {
+			t.Fatalf("saveMemory = %v (present=%v), want %v", body["saveMemory"], ok, saveMemory)
+		}
+		if _, ok := body["description"]; ok {
+			t.Fatalf("an empty description must be omitted, got body %v", body)
+		}
+	}
+}
@@ -40,6 +40,7 @@ func (h *OpenIaaSStorageRepositoryClient) List(
 	filter *StorageRepositoryFilter) ([]*OpenIaaSStorageRepository, error) {
 
 	r := h.c.newRequest("GET", "/compute/v1/open_iaas/storage_repositories")
+	r.reference = true
 	r.addFilter(filter)
 	resp, err := h.c.doRequest(ctx, r)
 	if err != nil {
@@ -60,6 +61,7 @@ func (h *OpenIaaSStorageRepositoryClient) List(
 
 func (h *OpenIaaSStorageRepositoryClient) Read(ctx context.Context, id string) (*OpenIaaSStorageRepository, error) {
 	r := h.c.newRequest("GET", "/compute/v1/open_iaas/storage_repositories/%s", id)
+	r.reference = true
 	resp, err := h.c.doRequest(ctx, r)
 	if err != nil {
 		return nil, err
@@ -51,6 +51,7 @@ func (p *OpenIaasTemplateClient) List(
 	filter *OpenIaaSTemplateFilter) ([]*OpenIaasTemplate, error) {
 
 	r := p.c.newRequest("GET", "/compute/v1/open_iaas/templates")
+	r.reference = true
 	r.addFilter(filter)
 	resp, err := p.c.doRequest(ctx, r)
 	if err != nil {
@@ -71,6 +72,7 @@ func (p *OpenIaasTemplateClient) List(
 
 func (p *OpenIaasTemplateClient) Read(ctx context.Context, id string) (*OpenIaasTemplate, error) {
 	r := p.c.newRequest("GET", "/compute/v1/open_iaas/templates/%s", id)
+	r.reference = true
 	resp, err := p.c.doRequest(ctx, r)
 	if err != nil {
 		return nil, err
@@ -81,45 +81,55 @@ type OpenIaaSVirtualDiskFilter struct {
 // maps a definitive 404 to an empty result; since #384 a genuine 403 surfaces
 // as an access-denied error rather than being masked as empty.
 func (v *OpenIaaSVirtualDiskClient) ListStrict(ctx context.Context, filter *OpenIaaSVirtualDiskFilter) ([]*OpenIaaSVirtualDisk, error) {
-	r := v.c.newRequest("GET", "/compute/v1/open_iaas/virtual_disks")
-	r.addFilter(filter)
-	resp, err := v.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	// Strictly 200: a 206 partial listing cannot prove an absence.
-	if err := requireHttpCodes(resp, 200); err != nil {
-		return nil, err
-	}
-
-	var out []*OpenIaaSVirtualDisk
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, v.c, "virtual disk", 0,
+		func(item *OpenIaaSVirtualDisk) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*OpenIaaSVirtualDisk, error) {
+			r := v.c.newRequest("GET", "/compute/v1/open_iaas/virtual_disks")
+			r.addFilter(filter)
+			pg.addTo(r)
+			resp, err := v.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			// Strictly 200: a 206 partial listing cannot prove an absence.
+			if err := requireHttpCodes(resp, 200); err != nil {
+				return nil, err
+			}
+
+			var out []*OpenIaaSVirtualDisk
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
 }
 
 func (v *OpenIaaSVirtualDiskClient) List(ctx context.Context, filter *OpenIaaSVirtualDiskFilter) ([]*OpenIaaSVirtualDisk, error) {
-	r := v.c.newRequest("GET", "/compute/v1/open_iaas/virtual_disks")
-	r.addFilter(filter)
-	resp, err := v.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	found, err := requireNotFoundOrOK(resp, 404)
-	if err != nil || !found {
-		return nil, err
-	}
-
-	var out []*OpenIaaSVirtualDisk
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, v.c, "virtual disk", 0,
+		func(item *OpenIaaSVirtualDisk) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*OpenIaaSVirtualDisk, error) {
+			r := v.c.newRequest("GET", "/compute/v1/open_iaas/virtual_disks")
+			r.addFilter(filter)
+			pg.addTo(r)
+			resp, err := v.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			found, err := requireNotFoundOrOK(resp, 404)
+			if err != nil || !found {
+				return nil, err
+			}
+
+			var out []*OpenIaaSVirtualDisk
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
 }
 
 type OpenIaaSVirtualDiskUpdateRequest struct {
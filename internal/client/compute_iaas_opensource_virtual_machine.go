@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 )
@@ -92,47 +93,57 @@ func (c *OpenIaaSVirtualMachineClient) Create(ctx context.Context, req *CreateOp
 // using the listing as EVIDENCE for state-shrinking decisions must fail
 // closed on access-denied or partial answers (#275 doctrine, FF-5).
 func (v *OpenIaaSVirtualMachineClient) ListStrict(ctx context.Context, filter *OpenIaaSVirtualMachineFilter) ([]*OpenIaaSVirtualMachine, error) {
-	r := v.c.newRequest("GET", "/compute/v1/open_iaas/virtual_machines")
-	r.addFilter(filter)
-	resp, err := v.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	// Strictly 200: a 206 partial listing cannot prove an absence.
-	if err := requireHttpCodes(resp, 200); err != nil {
-		return nil, err
-	}
+	return paginate(ctx, v.c, "virtual machine", 0,
+		func(item *OpenIaaSVirtualMachine) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*OpenIaaSVirtualMachine, error) {
+			r := v.c.newRequest("GET", "/compute/v1/open_iaas/virtual_machines")
+			r.addFilter(filter)
+			pg.addTo(r)
+			resp, err := v.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			// Strictly 200: a 206 partial listing cannot prove an absence.
+			if err := requireHttpCodes(resp, 200); err != nil {
+				return nil, err
+			}
 
-	var out []*OpenIaaSVirtualMachine
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
+			var out []*OpenIaaSVirtualMachine
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
 
-	return out, nil
+			return out, nil
+		})
 }
 
 func (v *OpenIaaSVirtualMachineClient) List(
 	ctx context.Context,
 	filter *OpenIaaSVirtualMachineFilter) ([]*OpenIaaSVirtualMachine, error) {
 
-	r := v.c.newRequest("GET", "/compute/v1/open_iaas/virtual_machines")
-	r.addFilter(filter)
-	resp, err := v.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	if err := requireOK(resp); err != nil {
-		return nil, err
-	}
+	return paginate(ctx, v.c, "virtual machine", 0,
+		func(item *OpenIaaSVirtualMachine) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*OpenIaaSVirtualMachine, error) {
+			r := v.c.newRequest("GET", "/compute/v1/open_iaas/virtual_machines")
+			r.addFilter(filter)
+			pg.addTo(r)
+			resp, err := v.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			if err := requireOK(resp); err != nil {
+				return nil, err
+			}
 
-	var out []*OpenIaaSVirtualMachine
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
+			var out []*OpenIaaSVirtualMachine
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
 
-	return out, nil
+			return out, nil
+		})
 }
 
 func (v *OpenIaaSVirtualMachineClient) Read(ctx context.Context, id string) (*OpenIaaSVirtualMachine, error) {
@@ -264,6 +275,11 @@ type newTickerFunc func(d time.Duration) (<-chan time.Time, func())
 //   - a read error returns (nil, fmt.Errorf("[WAITER] failed to read ...: %s", id, err)) —
 //     note %s, NOT %w (the original error is not wrapped);
 //   - a nil VM returns (nil, fmt.Errorf("[WAITER] the virtual machine %q could not be found", id)).
+//
+// The drivers timeout is distinct from the deadline of the operation (the
+// parent ctx or WaiterOptions.Deadline): reaching THAT deadline returns a
+// *WaitTimeoutError instead of continuing without drivers, since the caller
+// could not go on anyway.
 func waitForDrivers(
 	ctx context.Context,
 	id string,
@@ -273,6 +289,15 @@ func waitForDrivers(
 	options *WaiterOptions,
 ) (*OpenIaaSVirtualMachine, error) {
 
+	ctx, cancelDeadline := options.withDeadline(ctx)
+	defer cancelDeadline()
+	operationTimedOut := func() error {
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil
+		}
+		return options.timeoutError(ctx, fmt.Sprintf("the PV drivers of virtual machine %q", id), ctx.Err())
+	}
+
 	if timeout == 0 {
 		options.log(fmt.Sprintf(
 			"[WAITER] skipping wait for drivers for virtual machine %q (timeout = 0)",
@@ -293,6 +318,9 @@ func waitForDrivers(
 		select {
 
 		case <-timeoutCtx.Done():
+			if err := operationTimedOut(); err != nil {
+				return nil, err
+			}
 			options.log(fmt.Sprintf(
 				"[WAITER] timeout reached, continuing without PV drivers for virtual machine %q",
 				id,
@@ -302,6 +330,9 @@ func waitForDrivers(
 		case <-tickC:
 
 			if timeoutCtx.Err() != nil {
+				if err := operationTimedOut(); err != nil {
+					return nil, err
+				}
 				options.log("[WAITER] timeout reached while waiting, continuing without PV drivers")
 				return lastVM, nil
 			}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 )
@@ -191,3 +192,24 @@ func TestWaitForDriversNilVMIsNotFound(t *testing.T) {
 		t.Fatalf("a nil VM must return exactly %q, got %v", wantMsg, res.err)
 	}
 }
+
+func TestWaitForDriversOperationDeadlineIsATimeout(t *testing.T) {
+	tickCh := make(chan time.Time)
+	newTicker := func(d time.Duration) (<-chan time.Time, func()) { return tickCh, func() {} }
+	read := func(c context.Context) (*OpenIaaSVirtualMachine, error) {
+		t.Fatal("no tick, no read")
+		return nil, nil
+	}
+	// The drivers timeout (1h) outlives the operation deadline: reaching the
+	// latter must not be mistaken for the best-effort drivers timeout.
+	options := &WaiterOptions{Deadline: time.Now().Add(10 * time.Millisecond)}
+	vm, err := waitForDrivers(context.Background(), "vm-1", time.Hour, newTicker, read, options)
+
+	var timeoutErr *WaitTimeoutError
+	if !errors.As(err, &timeoutErr) || vm != nil {
+		t.Fatalf("expected a *WaitTimeoutError, got vm=%v err=%v", vm, err)
+	}
+	if !strings.Contains(err.Error(), `"vm-1"`) {
+		t.Fatalf("the timeout must name the virtual machine, got %q", err)
+	}
+}
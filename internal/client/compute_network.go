@@ -60,6 +60,29 @@ func (n *NetworkClient) List(
 	return out, nil
 }
 
+// ListStrict behaves like List but requires a complete HTTP 200 answer: 206 is
+// a partial listing, and any other code (including 403) is an error rather
+// than an answer (#281).
+func (n *NetworkClient) ListStrict(ctx context.Context, filter *NetworkFilter) ([]*Network, error) {
+	r := n.c.newRequest("GET", "/compute/v1/vcenters/networks")
+	r.addFilter(filter)
+	resp, err := n.c.doRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireHttpCodes(resp, 200); err != nil {
+		return nil, err
+	}
+
+	var out []*Network
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 func (n *NetworkClient) Read(ctx context.Context, id string) (*Network, error) {
 	r := n.c.newRequest("GET", "/compute/v1/vcenters/networks/%s", id)
 	resp, err := n.c.doRequest(ctx, r)
@@ -32,24 +32,29 @@ type NetworkAdapterFilter struct {
 }
 
 func (n *NetworkAdapterClient) List(ctx context.Context, filter *NetworkAdapterFilter) ([]*NetworkAdapter, error) {
-	r := n.c.newRequest("GET", "/compute/v1/vcenters/network_adapters")
-	r.addFilter(filter)
-	resp, err := n.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	found, err := requireNotFoundOrOK(resp, 404)
-	if err != nil || !found {
-		return nil, err
-	}
-
-	var out []*NetworkAdapter
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, n.c, "network adapter", 0,
+		func(item *NetworkAdapter) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*NetworkAdapter, error) {
+			r := n.c.newRequest("GET", "/compute/v1/vcenters/network_adapters")
+			r.addFilter(filter)
+			pg.addTo(r)
+			resp, err := n.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			found, err := requireNotFoundOrOK(resp, 404)
+			if err != nil || !found {
+				return nil, err
+			}
+
+			var out []*NetworkAdapter
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
 }
 
 // ListStrict behaves like List but requires a complete HTTP 200 answer: 206 is
@@ -58,23 +63,28 @@ func (n *NetworkAdapterClient) List(ctx context.Context, filter *NetworkAdapterF
 // the listing as state-safety evidence must fail closed on anything else
 // (#281).
 func (n *NetworkAdapterClient) ListStrict(ctx context.Context, filter *NetworkAdapterFilter) ([]*NetworkAdapter, error) {
-	r := n.c.newRequest("GET", "/compute/v1/vcenters/network_adapters")
-	r.addFilter(filter)
-	resp, err := n.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	if err := requireHttpCodes(resp, 200); err != nil {
-		return nil, err
-	}
-
-	var out []*NetworkAdapter
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, n.c, "network adapter", 0,
+		func(item *NetworkAdapter) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*NetworkAdapter, error) {
+			r := n.c.newRequest("GET", "/compute/v1/vcenters/network_adapters")
+			r.addFilter(filter)
+			pg.addTo(r)
+			resp, err := n.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			if err := requireHttpCodes(resp, 200); err != nil {
+				return nil, err
+			}
+
+			var out []*NetworkAdapter
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
 }
 
 type CreateNetworkAdapterRequest struct {
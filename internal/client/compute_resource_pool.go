@@ -48,6 +48,7 @@ type ResourcePoolFilter struct {
 
 func (rp *ResourcePoolClient) List(ctx context.Context, filter *ResourcePoolFilter) ([]*ResourcePool, error) {
 	r := rp.c.newRequest("GET", "/compute/v1/vcenters/resource_pools")
+	r.reference = true
 	r.addFilter(filter)
 	resp, err := rp.c.doRequest(ctx, r)
 	if err != nil {
@@ -68,6 +69,7 @@ func (rp *ResourcePoolClient) List(ctx context.Context, filter *ResourcePoolFilt
 
 func (rp *ResourcePoolClient) Read(ctx context.Context, id string) (*ResourcePool, error) {
 	r := rp.c.newRequest("GET", "/compute/v1/vcenters/resource_pools/%s", id)
+	r.reference = true
 	resp, err := rp.c.doRequest(ctx, r)
 	if err != nil {
 		return nil, err
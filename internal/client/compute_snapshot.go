@@ -22,22 +22,80 @@ type SnapshotFilter struct {
 }
 
 func (s *SnapshotClient) List(ctx context.Context, filter *SnapshotFilter) ([]*Snapshot, error) {
-	r := s.c.newRequest("GET", "/compute/v1/vcenters/snapshots")
-	r.addFilter(filter)
-	resp, err := s.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	found, err := requireNotFoundOrOK(resp, 404)
-	if err != nil || !found {
-		return nil, err
-	}
-
-	var out []*Snapshot
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, s.c, "snapshot", 0,
+		func(item *Snapshot) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*Snapshot, error) {
+			r := s.c.newRequest("GET", "/compute/v1/vcenters/snapshots")
+			r.addFilter(filter)
+			pg.addTo(r)
+			resp, err := s.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			found, err := requireNotFoundOrOK(resp, 404)
+			if err != nil || !found {
+				return nil, err
+			}
+
+			var out []*Snapshot
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
+}
+
+// ListStrict behaves like List but requires a complete HTTP 200 answer: 206 is
+// a partial listing and cannot prove an absence, and any other code (including
+// 403 and 404) is an error rather than being mapped to an empty result. The
+// snapshot resource reads through this listing, so it must fail closed (#281).
+func (s *SnapshotClient) ListStrict(ctx context.Context, filter *SnapshotFilter) ([]*Snapshot, error) {
+	return paginate(ctx, s.c, "snapshot", 0,
+		func(item *Snapshot) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*Snapshot, error) {
+			r := s.c.newRequest("GET", "/compute/v1/vcenters/snapshots")
+			r.addFilter(filter)
+			pg.addTo(r)
+			resp, err := s.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			if err := requireHttpCodes(resp, 200); err != nil {
+				return nil, err
+			}
+
+			var out []*Snapshot
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
+}
+
+type CreateSnapshotRequest struct {
+	VirtualMachineId string `json:"virtualMachineId"`
+	Name             string `json:"name"`
+}
+
+// Create takes a snapshot of a virtual machine and returns the activityId. The
+// completed activity's "snapshot" concerned item carries the new snapshot id.
+func (s *SnapshotClient) Create(ctx context.Context, req *CreateSnapshotRequest) (string, error) {
+	r := s.c.newRequest("POST", "/compute/v1/vcenters/snapshots")
+	r.obj = req
+	return s.c.doRequestAndReturnActivity(ctx, r)
+}
+
+// Revert reverts the snapshot's virtual machine to it and returns the activityId.
+func (s *SnapshotClient) Revert(ctx context.Context, id string) (string, error) {
+	r := s.c.newRequest("POST", "/compute/v1/vcenters/snapshots/%s/revert", id)
+	return s.c.doRequestAndReturnActivity(ctx, r)
+}
+
+func (s *SnapshotClient) Delete(ctx context.Context, id string) (string, error) {
+	r := s.c.newRequest("DELETE", "/compute/v1/vcenters/snapshots/%s", id)
+	return s.c.doRequestAndReturnActivity(ctx, r)
 }
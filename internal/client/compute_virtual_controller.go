@@ -27,24 +27,29 @@ type VirtualControllerFilter struct {
 }
 
 func (v *VirtualControllerClient) List(ctx context.Context, filter *VirtualControllerFilter) ([]*VirtualController, error) {
-	r := v.c.newRequest("GET", "/compute/v1/vcenters/virtual_controllers")
-	r.addFilter(filter)
-	resp, err := v.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	found, err := requireNotFoundOrOK(resp, 404)
-	if err != nil || !found {
-		return nil, err
-	}
-
-	var out []*VirtualController
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, v.c, "virtual controller", 0,
+		func(item *VirtualController) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*VirtualController, error) {
+			r := v.c.newRequest("GET", "/compute/v1/vcenters/virtual_controllers")
+			r.addFilter(filter)
+			pg.addTo(r)
+			resp, err := v.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			found, err := requireNotFoundOrOK(resp, 404)
+			if err != nil || !found {
+				return nil, err
+			}
+
+			var out []*VirtualController
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
 }
 
 // ListStrict behaves like List but requires a complete HTTP 200 answer: 206 is
@@ -53,23 +58,28 @@ func (v *VirtualControllerClient) List(ctx context.Context, filter *VirtualContr
 // the listing as state-safety evidence must fail closed on anything else
 // (#281).
 func (v *VirtualControllerClient) ListStrict(ctx context.Context, filter *VirtualControllerFilter) ([]*VirtualController, error) {
-	r := v.c.newRequest("GET", "/compute/v1/vcenters/virtual_controllers")
-	r.addFilter(filter)
-	resp, err := v.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	if err := requireHttpCodes(resp, 200); err != nil {
-		return nil, err
-	}
-
-	var out []*VirtualController
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, v.c, "virtual controller", 0,
+		func(item *VirtualController) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*VirtualController, error) {
+			r := v.c.newRequest("GET", "/compute/v1/vcenters/virtual_controllers")
+			r.addFilter(filter)
+			pg.addTo(r)
+			resp, err := v.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			if err := requireHttpCodes(resp, 200); err != nil {
+				return nil, err
+			}
+
+			var out []*VirtualController
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
 }
 
 type CreateVirtualControllerRequest struct {
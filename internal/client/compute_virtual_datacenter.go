@@ -28,6 +28,7 @@ func (v *VirtualDatacenterClient) List(
 	filter *VirtualDatacenterFilter) ([]*VirtualDatacenter, error) {
 
 	r := v.c.newRequest("GET", "/compute/v1/vcenters/virtual_datacenters")
+	r.reference = true
 	r.addFilter(filter)
 	resp, err := v.c.doRequest(ctx, r)
 	if err != nil {
@@ -48,6 +49,7 @@ func (v *VirtualDatacenterClient) List(
 
 func (v *VirtualDatacenterClient) Read(ctx context.Context, id string) (*VirtualDatacenter, error) {
 	r := v.c.newRequest("GET", "/compute/v1/vcenters/virtual_datacenters/%s", id)
+	r.reference = true
 	resp, err := v.c.doRequest(ctx, r)
 	if err != nil {
 		return nil, err
@@ -37,24 +37,29 @@ type VirtualDiskFilter struct {
 }
 
 func (v *VirtualDiskClient) List(ctx context.Context, filter *VirtualDiskFilter) ([]*VirtualDisk, error) {
-	r := v.c.newRequest("GET", "/compute/v1/vcenters/virtual_disks")
-	r.addFilter(filter)
-	resp, err := v.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	found, err := requireNotFoundOrOK(resp, 404)
-	if err != nil || !found {
-		return nil, err
-	}
-
-	var out []*VirtualDisk
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, v.c, "virtual disk", 0,
+		func(item *VirtualDisk) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*VirtualDisk, error) {
+			r := v.c.newRequest("GET", "/compute/v1/vcenters/virtual_disks")
+			r.addFilter(filter)
+			pg.addTo(r)
+			resp, err := v.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			found, err := requireNotFoundOrOK(resp, 404)
+			if err != nil || !found {
+				return nil, err
+			}
+
+			var out []*VirtualDisk
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
 }
 
 // ListStrict behaves like List but requires a complete HTTP 200 answer: 206 is
@@ -63,23 +68,28 @@ func (v *VirtualDiskClient) List(ctx context.Context, filter *VirtualDiskFilter)
 // the listing as state-safety evidence must fail closed on anything else
 // (#281).
 func (v *VirtualDiskClient) ListStrict(ctx context.Context, filter *VirtualDiskFilter) ([]*VirtualDisk, error) {
-	r := v.c.newRequest("GET", "/compute/v1/vcenters/virtual_disks")
-	r.addFilter(filter)
-	resp, err := v.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	if err := requireHttpCodes(resp, 200); err != nil {
-		return nil, err
-	}
-
-	var out []*VirtualDisk
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, v.c, "virtual disk", 0,
+		func(item *VirtualDisk) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*VirtualDisk, error) {
+			r := v.c.newRequest("GET", "/compute/v1/vcenters/virtual_disks")
+			r.addFilter(filter)
+			pg.addTo(r)
+			resp, err := v.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			if err := requireHttpCodes(resp, 200); err != nil {
+				return nil, err
+			}
+
+			var out []*VirtualDisk
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
 }
 
 type CreateVirtualDiskRequest struct {
@@ -157,23 +157,28 @@ type VirtualMachineFilter struct {
 }
 
 func (v *VirtualMachineClient) List(ctx context.Context, filter *VirtualMachineFilter) ([]*VirtualMachine, error) {
-	r := v.c.newRequest("GET", "/compute/v1/vcenters/virtual_machines")
-	r.addFilter(filter)
-	resp, err := v.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	if err := requireOK(resp); err != nil {
-		return nil, err
-	}
-
-	var out []*VirtualMachine
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, v.c, "virtual machine", 0,
+		func(item *VirtualMachine) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*VirtualMachine, error) {
+			r := v.c.newRequest("GET", "/compute/v1/vcenters/virtual_machines")
+			r.addFilter(filter)
+			pg.addTo(r)
+			resp, err := v.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			if err := requireOK(resp); err != nil {
+				return nil, err
+			}
+
+			var out []*VirtualMachine
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
 }
 
 // ListStrict behaves like List but requires a complete HTTP 200 answer: 206 is
@@ -182,23 +187,28 @@ func (v *VirtualMachineClient) List(ctx context.Context, filter *VirtualMachineF
 // the listing as state-safety evidence must fail closed on anything else
 // (#281).
 func (v *VirtualMachineClient) ListStrict(ctx context.Context, filter *VirtualMachineFilter) ([]*VirtualMachine, error) {
-	r := v.c.newRequest("GET", "/compute/v1/vcenters/virtual_machines")
-	r.addFilter(filter)
-	resp, err := v.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	if err := requireHttpCodes(resp, 200); err != nil {
-		return nil, err
-	}
-
-	var out []*VirtualMachine
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, v.c, "virtual machine", 0,
+		func(item *VirtualMachine) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*VirtualMachine, error) {
+			r := v.c.newRequest("GET", "/compute/v1/vcenters/virtual_machines")
+			r.addFilter(filter)
+			pg.addTo(r)
+			resp, err := v.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			if err := requireHttpCodes(resp, 200); err != nil {
+				return nil, err
+			}
+
+			var out []*VirtualMachine
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
 }
 
 type CreateVirtualMachineRequest struct {
@@ -30,6 +30,7 @@ func (v *VirtualSwitchClient) List(
 	filter *VirtualSwitchFilter) ([]*VirtualSwitch, error) {
 
 	r := v.c.newRequest("GET", "/compute/v1/vcenters/virtual_switchs")
+	r.reference = true
 	r.addFilter(filter)
 	resp, err := v.c.doRequest(ctx, r)
 	if err != nil {
@@ -50,6 +51,7 @@ func (v *VirtualSwitchClient) List(
 
 func (v *VirtualSwitchClient) Read(ctx context.Context, id string) (*VirtualSwitch, error) {
 	r := v.c.newRequest("GET", "/compute/v1/vcenters/virtual_switchs/%s", id)
+	r.reference = true
 	resp, err := v.c.doRequest(ctx, r)
 	if err != nil {
 		return nil, err
@@ -176,3 +176,40 @@ func TestVirtualControllerListStrict(t *testing.T) {
 		return err
 	})
 }
+
+func TestSnapshotListStrict(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("200 scopes by virtualMachineId and returns the parsed snapshots", func(t *testing.T) {
+		var method, path string
+		var query url.Values
+		c := newPATTestClient(t, captureHandler(http.StatusOK, `[{"id":"snap-1","virtualMachineId":"vm-1"}]`, &method, &path, &query))
+		snapshots, err := c.Compute().Snapshot().ListStrict(ctx, &SnapshotFilter{VirtualMachineID: "vm-1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(snapshots) != 1 || snapshots[0].ID != "snap-1" || snapshots[0].VirtualMachineId != "vm-1" {
+			t.Fatalf("unexpected snapshots: %+v", snapshots)
+		}
+		if method != http.MethodGet || path != "/compute/v1/vcenters/snapshots" {
+			t.Fatalf("unexpected request: %s %s", method, path)
+		}
+		if query.Get("virtualMachineId") != "vm-1" {
+			t.Fatalf("expected virtualMachineId=vm-1, got query %v", query)
+		}
+	})
+
+	t.Run("404 is not an empty listing", func(t *testing.T) {
+		c := newPATTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+		if _, err := c.Compute().Snapshot().ListStrict(ctx, &SnapshotFilter{VirtualMachineID: "vm-1"}); err == nil {
+			t.Fatal("a 404 must be rejected: it cannot prove the absence of a snapshot")
+		}
+	})
+
+	runListStrictRejections(t, func(c *Client) error {
+		_, err := c.Compute().Snapshot().ListStrict(ctx, &SnapshotFilter{VirtualMachineID: "vm-1"})
+		return err
+	})
+}
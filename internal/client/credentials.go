@@ -0,0 +1,172 @@
+package client
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	ProfileEnvName               = "CLOUDTEMPLE_PROFILE"
+	SharedCredentialsFileEnvName = "CLOUDTEMPLE_SHARED_CREDENTIALS_FILE"
+
+	// DefaultProfile is the profile used when none is named.
+	DefaultProfile = "default"
+)
+
+// ErrProfileNotFound is returned by LoadProfile for a profile the shared
+// credentials file does not define.
+var ErrProfileNotFound = errors.New("profile not found")
+
+// DefaultSharedCredentialsFile returns the path of the shared credentials file
+// used when none is named: ~/.cloudtemple/credentials.
+func DefaultSharedCredentialsFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".cloudtemple", "credentials")
+}
+
+// Profile is a named profile of the shared credentials file. The file is
+// INI-style, one section per profile:
+//
+//	[default]
+//	client_id = 12345678-1234-1234-1234-123456789abc
+//	secret_id = …
+//
+//	[vmware]
+//	address   = shiva.cloud-temple.com
+//	scheme    = https
+//	client_id = …
+//	secret_id = …
+//
+// Lines starting with '#' or ';' are comments. An unset key keeps the value
+// of the configuration it is applied to.
+type Profile struct {
+	Address  string
+	Scheme   string
+	ClientID string
+	SecretID string
+}
+
+// LoadProfile reads the profile name of the shared credentials file at path.
+func LoadProfile(path, name string) (*Profile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	profiles := map[string]*Profile{}
+	var current *Profile
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") {
+			if !strings.HasSuffix(text, "]") {
+				return nil, fmt.Errorf("%s:%d: invalid section %q", path, line, text)
+			}
+			section := strings.TrimSpace(text[1 : len(text)-1])
+			if section == "" {
+				return nil, fmt.Errorf("%s:%d: empty profile name", path, line)
+			}
+			if profiles[section] != nil {
+				return nil, fmt.Errorf("%s:%d: profile %q is defined twice", path, line, section)
+			}
+			current = &Profile{}
+			profiles[section] = current
+			continue
+		}
+
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected \"key = value\"", path, line)
+		}
+		if current == nil {
+			return nil, fmt.Errorf("%s:%d: %q is not in a profile section", path, line, strings.TrimSpace(key))
+		}
+		value = strings.TrimSpace(value)
+		switch key = strings.TrimSpace(key); key {
+		case "address":
+			current.Address = value
+		case "scheme":
+			current.Scheme = value
+		case "client_id":
+			current.ClientID = value
+		case "secret_id":
+			current.SecretID = value
+		default:
+			return nil, fmt.Errorf("%s:%d: unknown key %q (expected address, scheme, client_id or secret_id)", path, line, key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q in %s", ErrProfileNotFound, name, path)
+	}
+	return profile, nil
+}
+
+// apply sets the values of the profile on config.
+func (p *Profile) apply(config *Config) {
+	if p.Address != "" {
+		config.Address = p.Address
+	}
+	if p.Scheme != "" {
+		config.Scheme = p.Scheme
+	}
+	if p.ClientID != "" {
+		config.ClientID = p.ClientID
+	}
+	if p.SecretID != "" {
+		config.SecretID = p.SecretID
+	}
+}
+
+// loadSharedCredentials applies the profile of the shared credentials file to
+// config. An empty path or profile falls back to its environment variable,
+// then to the default. The default file, or its default profile, may be
+// missing; a file or a profile that was named must exist.
+func loadSharedCredentials(config *Config, path, profile string) error {
+	if path == "" {
+		path = os.Getenv(SharedCredentialsFileEnvName)
+	}
+	if profile == "" {
+		profile = os.Getenv(ProfileEnvName)
+	}
+	named := path != "" || profile != ""
+	if path == "" {
+		path = DefaultSharedCredentialsFile()
+	}
+	if profile == "" {
+		profile = DefaultProfile
+	}
+	if path == "" {
+		if named {
+			return fmt.Errorf("cannot locate the shared credentials file: no home directory")
+		}
+		return nil
+	}
+
+	p, err := LoadProfile(path, profile)
+	if err != nil {
+		if !named && (errors.Is(err, fs.ErrNotExist) || errors.Is(err, ErrProfileNotFound)) {
+			return nil
+		}
+		return fmt.Errorf("failed to load profile %q: %w", profile, err)
+	}
+	p.apply(config)
+	return nil
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testCredentials = `# Cloud Temple tenants
+[default]
+client_id = default-id
+secret_id = default-secret
+
+; the VMware tenant
+[vmware]
+address   = vmware.example
+scheme    = https
+client_id = vmware-id
+secret_id = vmware-secret = with an equal sign
+`
+
+// isolateCredentials points the credential lookups at an empty home and clears
+// every variable DefaultConfig reads, so the developer's own file and
+// environment never leak into a test.
+func isolateCredentials(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	for _, name := range []string{
+		SharedCredentialsFileEnvName, ProfileEnvName,
+		HTTPAddrEnvName, HTTPSchemeEnvName, HTTPClientIDEnvName, HTTPClientSecretEnvName,
+	} {
+		t.Setenv(name, "")
+	}
+	return home
+}
+
+func writeCredentials(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestLoadProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	writeCredentials(t, path, testCredentials)
+
+	p, err := LoadProfile(path, "vmware")
+	require.NoError(t, err)
+	require.Equal(t, &Profile{
+		Address:  "vmware.example",
+		Scheme:   "https",
+		ClientID: "vmware-id",
+		SecretID: "vmware-secret = with an equal sign",
+	}, p)
+
+	_, err = LoadProfile(path, "openiaas")
+	require.True(t, errors.Is(err, ErrProfileNotFound), err)
+}
+
+func TestLoadProfileRejectsMalformedFiles(t *testing.T) {
+	for name, content := range map[string]string{
+		"key outside a section": "client_id = x\n",
+		"unknown key":           "[default]\nclient_secret = x\n",
+		"missing equal sign":    "[default]\nclient_id\n",
+		"unterminated section":  "[default\n",
+		"duplicate profile":     "[default]\n[default]\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "credentials")
+			writeCredentials(t, path, content)
+			_, err := LoadProfile(path, "default")
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestDefaultConfigProfiles(t *testing.T) {
+	t.Run("no file keeps the defaults", func(t *testing.T) {
+		isolateCredentials(t)
+		config, err := DefaultConfig()
+		require.NoError(t, err)
+		require.Equal(t, "shiva.cloud-temple.com", config.Address)
+		require.Empty(t, config.ClientID)
+	})
+
+	t.Run("default profile of the default file", func(t *testing.T) {
+		home := isolateCredentials(t)
+		writeCredentials(t, filepath.Join(home, ".cloudtemple", "credentials"), testCredentials)
+		config, err := DefaultConfig()
+		require.NoError(t, err)
+		require.Equal(t, "default-id", config.ClientID)
+		require.Equal(t, "shiva.cloud-temple.com", config.Address)
+	})
+
+	t.Run("profile named by the environment", func(t *testing.T) {
+		home := isolateCredentials(t)
+		writeCredentials(t, filepath.Join(home, ".cloudtemple", "credentials"), testCredentials)
+		t.Setenv(ProfileEnvName, "vmware")
+		config, err := DefaultConfig()
+		require.NoError(t, err)
+		require.Equal(t, "vmware-id", config.ClientID)
+		require.Equal(t, "vmware.example", config.Address)
+	})
+
+	t.Run("environment variables win over the profile", func(t *testing.T) {
+		isolateCredentials(t)
+		path := filepath.Join(t.TempDir(), "credentials")
+		writeCredentials(t, path, testCredentials)
+		t.Setenv(HTTPClientIDEnvName, "env-id")
+		config, err := LoadConfig(path, "vmware")
+		require.NoError(t, err)
+		require.Equal(t, "env-id", config.ClientID)
+		require.Equal(t, "vmware-secret = with an equal sign", config.SecretID)
+	})
+
+	t.Run("a named profile must exist", func(t *testing.T) {
+		home := isolateCredentials(t)
+		writeCredentials(t, filepath.Join(home, ".cloudtemple", "credentials"), testCredentials)
+		_, err := LoadConfig("", "openiaas")
+		require.True(t, errors.Is(err, ErrProfileNotFound), err)
+	})
+
+	t.Run("a named file must exist", func(t *testing.T) {
+		isolateCredentials(t)
+		t.Setenv(SharedCredentialsFileEnvName, filepath.Join(t.TempDir(), "missing"))
+		_, err := DefaultConfig()
+		require.True(t, errors.Is(err, os.ErrNotExist), err)
+	})
+}
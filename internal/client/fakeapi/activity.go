@@ -0,0 +1,107 @@
+package fakeapi
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// activity is a write in progress. It is running for the first reads, then
+// completes: its change is applied, or it fails.
+type activity struct {
+	object Object
+	reads  int
+	hidden int
+	done   bool
+	// failure is the reason the activity fails with, if any.
+	failure string
+	apply   func() string
+}
+
+// startActivity answers a write with the activity applying it, in the Location
+// header. apply runs when the activity completes and returns its result, the
+// id of the object concerned.
+func (s *Server) startActivity(w http.ResponseWriter, status int, description string, items []Object, apply func() string) {
+	id := s.newID()
+	now := time.Now().UTC().Format(time.RFC3339)
+	a := &activity{
+		object: Object{
+			"id":             id,
+			"tenantId":       TenantID,
+			"description":    description,
+			"type":           strings.SplitN(description, " ", 2)[0],
+			"tags":           []string{},
+			"creationDate":   now,
+			"concernedItems": items,
+			"state": Object{
+				"running": Object{"startDate": now, "progression": 0},
+			},
+		},
+		hidden: s.hiddenActivityRead,
+		apply:  apply,
+	}
+	if len(s.activityFailures) > 0 {
+		a.failure = s.activityFailures[0]
+		s.activityFailures = s.activityFailures[1:]
+	}
+	s.activities[id] = a
+	s.activityOrder = append(s.activityOrder, id)
+
+	w.Header().Set("Location", id)
+	w.WriteHeader(status)
+}
+
+// advance moves the activity forward on a read.
+func (s *Server) advance(a *activity) {
+	if a.done {
+		return
+	}
+	a.reads++
+	if a.reads <= s.runningReads {
+		a.object["state"] = Object{
+			"running": Object{
+				"startDate":   a.object["creationDate"],
+				"progression": 100 * a.reads / (s.runningReads + 1),
+			},
+		}
+		return
+	}
+
+	a.done = true
+	now := time.Now().UTC().Format(time.RFC3339)
+	state := Object{
+		"startDate":   a.object["creationDate"],
+		"stopDate":    now,
+		"progression": 100,
+	}
+	if a.failure != "" {
+		state["reason"] = a.failure
+		a.object["state"] = Object{"failed": state}
+		return
+	}
+	state["result"] = a.apply()
+	a.object["state"] = Object{"completed": state}
+}
+
+func (s *Server) readActivity(w http.ResponseWriter, r *http.Request) {
+	a := s.activities[r.PathValue("id")]
+	if a == nil {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if a.hidden > 0 {
+		a.hidden--
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	s.advance(a)
+	writeJSON(w, http.StatusOK, a.object)
+}
+
+func (s *Server) listActivities(w http.ResponseWriter, r *http.Request) {
+	out := make([]Object, 0, len(s.activityOrder))
+	for _, id := range s.activityOrder {
+		out = append(out, s.activities[id].object)
+	}
+	writeJSON(w, http.StatusOK, page(out, r.URL.Query()))
+}
@@ -0,0 +1,512 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+func (s *Server) registerCompute() {
+	const vmware = "/compute/v1/vcenters"
+	s.mux.HandleFunc("GET "+vmware+"/virtual_machines", s.listHandler(VMwareVirtualMachine, nil))
+	s.mux.HandleFunc("GET "+vmware+"/virtual_machines/{id}", s.readHandler(VMwareVirtualMachine, "id"))
+	s.mux.HandleFunc("POST "+vmware+"/virtual_machines", s.createVMwareVirtualMachine)
+	s.mux.HandleFunc("PATCH "+vmware+"/virtual_machines", s.updateVMwareVirtualMachine)
+	s.mux.HandleFunc("PATCH "+vmware+"/virtual_machines/power", s.powerVMwareVirtualMachine)
+	s.mux.HandleFunc("PATCH "+vmware+"/virtual_machines/rename", s.renameVMwareVirtualMachine)
+	s.mux.HandleFunc("DELETE "+vmware+"/virtual_machines/{id}", s.deleteVMwareVirtualMachine)
+
+	s.mux.HandleFunc("GET "+vmware+"/virtual_disks", s.listHandler(VMwareVirtualDisk, nil))
+	s.mux.HandleFunc("GET "+vmware+"/virtual_disks/{id}", s.readHandler(VMwareVirtualDisk, "id"))
+	s.mux.HandleFunc("POST "+vmware+"/virtual_disks", s.createVMwareVirtualDisk)
+	s.mux.HandleFunc("PATCH "+vmware+"/virtual_disks", s.updateVMwareVirtualDisk)
+	s.mux.HandleFunc("DELETE "+vmware+"/virtual_disks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.remove(w, VMwareVirtualDisk, r.PathValue("id"), "virtual_disk", nil)
+	})
+
+	s.mux.HandleFunc("GET "+vmware+"/network_adapters", s.listHandler(VMwareNetworkAdapter, nil))
+	s.mux.HandleFunc("GET "+vmware+"/network_adapters/{id}", s.readHandler(VMwareNetworkAdapter, "id"))
+	s.mux.HandleFunc("POST "+vmware+"/network_adapters", s.createVMwareNetworkAdapter)
+	s.mux.HandleFunc("PATCH "+vmware+"/network_adapters", s.updateVMwareNetworkAdapter)
+	s.mux.HandleFunc("PATCH "+vmware+"/network_adapters/connect", s.connectVMwareNetworkAdapter(true))
+	s.mux.HandleFunc("PATCH "+vmware+"/network_adapters/disconnect", s.connectVMwareNetworkAdapter(false))
+	s.mux.HandleFunc("DELETE "+vmware+"/network_adapters/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.remove(w, VMwareNetworkAdapter, r.PathValue("id"), "network_adapter", nil)
+	})
+
+	const openIaaS = "/compute/v1/open_iaas"
+	s.mux.HandleFunc("GET "+openIaaS+"/virtual_machines", s.listHandler(OpenIaaSVirtualMachine, nil))
+	s.mux.HandleFunc("GET "+openIaaS+"/virtual_machines/{id}", s.readHandler(OpenIaaSVirtualMachine, "id"))
+	s.mux.HandleFunc("POST "+openIaaS+"/virtual_machines", s.createOpenIaaSVirtualMachine)
+	s.mux.HandleFunc("PATCH "+openIaaS+"/virtual_machines/{id}", s.updateOpenIaaSVirtualMachine)
+	s.mux.HandleFunc("PATCH "+openIaaS+"/virtual_machines/{id}/power", s.powerOpenIaaSVirtualMachine)
+	s.mux.HandleFunc("DELETE "+openIaaS+"/virtual_machines/{id}", s.deleteOpenIaaSVirtualMachine)
+
+	s.mux.HandleFunc("GET "+openIaaS+"/virtual_disks", s.listHandler(OpenIaaSVirtualDisk, matchOpenIaaSVirtualDisk))
+	s.mux.HandleFunc("GET "+openIaaS+"/virtual_disks/{id}", s.readHandler(OpenIaaSVirtualDisk, "id"))
+	s.mux.HandleFunc("POST "+openIaaS+"/virtual_disks", s.createOpenIaaSVirtualDisk)
+	s.mux.HandleFunc("PATCH "+openIaaS+"/virtual_disks/{id}", s.updateOpenIaaSVirtualDisk)
+	s.mux.HandleFunc("PATCH "+openIaaS+"/virtual_disks/{id}/attach", s.attachOpenIaaSVirtualDisk(true))
+	s.mux.HandleFunc("PATCH "+openIaaS+"/virtual_disks/{id}/detach", s.attachOpenIaaSVirtualDisk(false))
+	s.mux.HandleFunc("PATCH "+openIaaS+"/virtual_disks/{id}/connect", s.connectOpenIaaSVirtualDisk(true))
+	s.mux.HandleFunc("PATCH "+openIaaS+"/virtual_disks/{id}/disconnect", s.connectOpenIaaSVirtualDisk(false))
+	s.mux.HandleFunc("DELETE "+openIaaS+"/virtual_disks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.remove(w, OpenIaaSVirtualDisk, r.PathValue("id"), "virtual_disk", nil)
+	})
+
+	s.mux.HandleFunc("GET "+openIaaS+"/network_adapters", s.listHandler(OpenIaaSNetworkAdapter, nil))
+	s.mux.HandleFunc("GET "+openIaaS+"/network_adapters/{id}", s.readHandler(OpenIaaSNetworkAdapter, "id"))
+	s.mux.HandleFunc("POST "+openIaaS+"/network_adapters", s.createOpenIaaSNetworkAdapter)
+	s.mux.HandleFunc("PATCH "+openIaaS+"/network_adapters/{id}", s.updateOpenIaaSNetworkAdapter)
+	s.mux.HandleFunc("PATCH "+openIaaS+"/network_adapters/{id}/connect", s.connectOpenIaaSNetworkAdapter(true))
+	s.mux.HandleFunc("PATCH "+openIaaS+"/network_adapters/{id}/disconnect", s.connectOpenIaaSNetworkAdapter(false))
+	s.mux.HandleFunc("DELETE "+openIaaS+"/network_adapters/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.remove(w, OpenIaaSNetworkAdapter, r.PathValue("id"), "network_adapter", nil)
+	})
+}
+
+// requireParent answers 404 unless the object of kind with the given id
+// exists, e.g. the virtual machine a disk is created on.
+func (s *Server) requireParent(w http.ResponseWriter, kind Kind, id string) bool {
+	if s.collection(kind).objects[id] == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %q not found", kind, id))
+		return false
+	}
+	return true
+}
+
+// newMAC returns a MAC address in the range VMware assigns.
+func (s *Server) newMAC() string {
+	s.seq++
+	return fmt.Sprintf("00:50:56:%02x:%02x:%02x", (s.seq>>16)&0xff, (s.seq>>8)&0xff, s.seq&0xff)
+}
+
+func (s *Server) createVMwareVirtualMachine(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	if str(body, "name") == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	id := s.newID()
+	vm := Object{
+		"id":                   id,
+		"name":                 body["name"],
+		"moref":                "vm-" + id[len(id)-4:],
+		"machineManager":       ref(""),
+		"datacenter":           ref(body["datacenterId"]),
+		"hostCluster":          ref(body["hostClusterId"]),
+		"datastore":            ref(body["datastoreId"]),
+		"datastoreCluster":     ref(body["datastoreClusterId"]),
+		"powerState":           "stopped",
+		"cpu":                  body["cpu"],
+		"numCoresPerSocket":    1,
+		"memory":               body["memory"],
+		"operatingSystemMoref": body["guestOperatingSystemMoref"],
+		"extraConfig":          []Object{},
+		"bootOptions":          Object{"firmware": "bios"},
+	}
+	s.create(w, VMwareVirtualMachine, "virtual_machine", vm, nil)
+}
+
+func (s *Server) updateVMwareVirtualMachine(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	s.update(w, VMwareVirtualMachine, str(body, "id"), "virtual_machine", func(vm Object) {
+		copyFields(vm, body, map[string]string{
+			"ram":                          "memory",
+			"cpu":                          "cpu",
+			"corePerSocket":                "numCoresPerSocket",
+			"hotCpuAdd":                    "cpuHotAddEnabled",
+			"hotCpuRemove":                 "cpuHotRemoveEnabled",
+			"hotMemAdd":                    "memoryHotAddEnabled",
+			"exposeHardwareVirtualization": "exposeHardwareVirtualization",
+		})
+		if options, ok := body["bootOptions"].(Object); ok {
+			boot, _ := vm["bootOptions"].(Object)
+			if boot == nil {
+				boot = Object{}
+			}
+			for key, value := range options {
+				boot[key] = value
+			}
+			vm["bootOptions"] = boot
+		}
+	})
+}
+
+func (s *Server) powerVMwareVirtualMachine(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	state := map[string]string{"on": "running", "off": "stopped", "reset": "running", "suspend": "suspended"}[str(body, "powerAction")]
+	if state == "" {
+		writeError(w, http.StatusBadRequest, "invalid powerAction")
+		return
+	}
+	s.update(w, VMwareVirtualMachine, str(body, "id"), "virtual_machine", func(vm Object) {
+		vm["powerState"] = state
+	})
+}
+
+func (s *Server) renameVMwareVirtualMachine(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	s.update(w, VMwareVirtualMachine, str(body, "id"), "virtual_machine", func(vm Object) {
+		vm["name"] = body["name"]
+	})
+}
+
+// deleteVMwareVirtualMachine deletes the virtual machine with its disks and
+// network adapters.
+func (s *Server) deleteVMwareVirtualMachine(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	s.remove(w, VMwareVirtualMachine, id, "virtual_machine", func() {
+		ofVM := func(obj Object) bool { return obj["virtualMachineId"] == id }
+		s.removeWhere(VMwareVirtualDisk, ofVM)
+		s.removeWhere(VMwareNetworkAdapter, ofVM)
+	})
+}
+
+func (s *Server) createVMwareVirtualDisk(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok || !s.requireParent(w, VMwareVirtualMachine, str(body, "virtualMachineId")) {
+		return
+	}
+	disks := s.collection(VMwareVirtualDisk).list(url.Values{"virtualMachineId": {str(body, "virtualMachineId")}}, matchFields)
+	disk := Object{
+		"id":               s.newID(),
+		"name":             fmt.Sprintf("Hard disk %d", len(disks)+1),
+		"virtualMachineId": body["virtualMachineId"],
+		"machineManager":   ref(""),
+		"datastore":        ref(body["datastoreId"]),
+		"capacity":         body["capacity"],
+		"diskUnitNumber":   len(disks),
+		"provisioningType": body["provisioningType"],
+		"diskMode":         body["diskMode"],
+		"editable":         true,
+		"controller":       Object{"id": body["controllerId"], "busNumber": 0, "type": "SCSI"},
+	}
+	s.create(w, VMwareVirtualDisk, "virtual_disk", disk, nil)
+}
+
+func (s *Server) updateVMwareVirtualDisk(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	s.update(w, VMwareVirtualDisk, str(body, "id"), "virtual_disk", func(disk Object) {
+		copyFields(disk, body, map[string]string{"newCapacity": "capacity", "diskMode": "diskMode"})
+	})
+}
+
+func (s *Server) createVMwareNetworkAdapter(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok || !s.requireParent(w, VMwareVirtualMachine, str(body, "virtualMachineId")) {
+		return
+	}
+	adapters := s.collection(VMwareNetworkAdapter).list(url.Values{"virtualMachineId": {str(body, "virtualMachineId")}}, matchFields)
+	mac, macType := str(body, "macAddress"), "MANUAL"
+	if mac == "" {
+		mac, macType = s.newMAC(), "ASSIGNED"
+	}
+	adapter := Object{
+		"id":               s.newID(),
+		"virtualMachineId": body["virtualMachineId"],
+		"name":             fmt.Sprintf("Network adapter %d", len(adapters)+1),
+		"network":          ref(body["networkId"]),
+		"type":             body["type"],
+		"macType":          macType,
+		"macAddress":       mac,
+		"connected":        false,
+		"autoConnect":      false,
+	}
+	s.create(w, VMwareNetworkAdapter, "network_adapter", adapter, nil)
+}
+
+func (s *Server) updateVMwareNetworkAdapter(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	s.update(w, VMwareNetworkAdapter, str(body, "id"), "network_adapter", func(adapter Object) {
+		copyFields(adapter, body, map[string]string{"autoConnect": "autoConnect", "macAddress": "macAddress"})
+		if network := str(body, "newNetworkId"); network != "" {
+			adapter["network"] = ref(network)
+		}
+	})
+}
+
+func (s *Server) connectVMwareNetworkAdapter(connected bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, ok := decode(w, r)
+		if !ok {
+			return
+		}
+		s.update(w, VMwareNetworkAdapter, str(body, "id"), "network_adapter", func(adapter Object) {
+			adapter["connected"] = connected
+		})
+	}
+}
+
+func (s *Server) createOpenIaaSVirtualMachine(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	if str(body, "name") == "" || str(body, "templateId") == "" {
+		writeError(w, http.StatusBadRequest, "name and templateId are required")
+		return
+	}
+	id := s.newID()
+	vm := Object{
+		"id":                  id,
+		"name":                body["name"],
+		"internalId":          "xo-" + id[len(id)-4:],
+		"powerState":          "Halted",
+		"secureBoot":          false,
+		"highAvailability":    "disabled",
+		"bootFirmware":        "bios",
+		"autoPowerOn":         false,
+		"dvdDrive":            Object{"name": "", "attached": false},
+		"bootOrder":           []string{"Hard-Drive", "DVD-Drive", "Network"},
+		"operatingSystemName": "",
+		"cpu":                 body["cpu"],
+		"numCoresPerSocket":   1,
+		"memory":              body["memory"],
+		"addresses":           Object{},
+		"machineManager":      ref(""),
+		"host":                ref(""),
+		"pool":                ref(""),
+	}
+
+	// The adapters of the request are created with the virtual machine.
+	var adapters []Object
+	requested, _ := body["networkAdapters"].([]interface{})
+	for i, a := range requested {
+		a, _ := a.(Object)
+		mac := str(a, "mac")
+		if mac == "" {
+			mac = s.newMAC()
+		}
+		adapters = append(adapters, Object{
+			"id":               s.newID(),
+			"name":             fmt.Sprintf("VIF %d", i),
+			"virtualMachineId": id,
+			"macAddress":       mac,
+			"mtu":              1500,
+			"attached":         true,
+			"txChecksumming":   true,
+			"network":          ref(a["networkId"]),
+			"machineManager":   ref(""),
+		})
+	}
+	s.create(w, OpenIaaSVirtualMachine, "virtual_machine", vm, func() {
+		for _, adapter := range adapters {
+			s.collection(OpenIaaSNetworkAdapter).put(str(adapter, "id"), adapter, s.hiddenReads)
+		}
+	})
+}
+
+func (s *Server) updateOpenIaaSVirtualMachine(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	s.update(w, OpenIaaSVirtualMachine, r.PathValue("id"), "virtual_machine", func(vm Object) {
+		copyFields(vm, body, map[string]string{
+			"name":              "name",
+			"cpu":               "cpu",
+			"numCoresPerSocket": "numCoresPerSocket",
+			"memory":            "memory",
+			"secureBoot":        "secureBoot",
+			"bootFirmware":      "bootFirmware",
+			"autoPowerOn":       "autoPowerOn",
+			"highAvailability":  "highAvailability",
+		})
+	})
+}
+
+func (s *Server) powerOpenIaaSVirtualMachine(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	state := map[string]string{"on": "Running", "off": "Halted"}[str(body, "powerState")]
+	if state == "" {
+		writeError(w, http.StatusBadRequest, "invalid powerState")
+		return
+	}
+	s.update(w, OpenIaaSVirtualMachine, r.PathValue("id"), "virtual_machine", func(vm Object) {
+		vm["powerState"] = state
+	})
+}
+
+// deleteOpenIaaSVirtualMachine deletes the virtual machine with its network
+// adapters, and detaches its disks.
+func (s *Server) deleteOpenIaaSVirtualMachine(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	s.remove(w, OpenIaaSVirtualMachine, id, "virtual_machine", func() {
+		s.removeWhere(OpenIaaSNetworkAdapter, func(obj Object) bool { return obj["virtualMachineId"] == id })
+		for _, disk := range s.collection(OpenIaaSVirtualDisk).objects {
+			setDiskVirtualMachine(disk, id, nil)
+		}
+	})
+}
+
+// matchOpenIaaSVirtualDisk filters the disks on the virtual machines they are
+// attached to.
+func matchOpenIaaSVirtualDisk(disk Object, query url.Values) bool {
+	vm := query.Get("virtualMachineId")
+	if vm == "" {
+		return matchFields(disk, query)
+	}
+	vms, _ := disk["virtualMachines"].([]Object)
+	for _, v := range vms {
+		if v["id"] == vm {
+			return true
+		}
+	}
+	return false
+}
+
+// setDiskVirtualMachine replaces the connection of disk to the virtual machine
+// vm, removes it when conn is nil.
+func setDiskVirtualMachine(disk Object, vm string, conn Object) {
+	vms, _ := disk["virtualMachines"].([]Object)
+	out := []Object{}
+	for _, v := range vms {
+		if v["id"] != vm {
+			out = append(out, v)
+		}
+	}
+	if conn != nil {
+		out = append(out, conn)
+	}
+	disk["virtualMachines"] = out
+}
+
+func (s *Server) createOpenIaaSVirtualDisk(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	vm := str(body, "virtualMachineId")
+	if vm != "" && !s.requireParent(w, OpenIaaSVirtualMachine, vm) {
+		return
+	}
+	disk := Object{
+		"id":                s.newID(),
+		"name":              body["name"],
+		"size":              body["size"],
+		"usage":             0,
+		"isSnapshot":        false,
+		"storageRepository": ref(body["storageRepositoryId"]),
+		"virtualMachines":   []Object{},
+		"templates":         []Object{},
+	}
+	if vm != "" {
+		setDiskVirtualMachine(disk, vm, Object{"id": vm, "name": "", "readOnly": str(body, "mode") == "RO", "connected": true})
+	}
+	s.create(w, OpenIaaSVirtualDisk, "virtual_disk", disk, nil)
+}
+
+func (s *Server) updateOpenIaaSVirtualDisk(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	s.update(w, OpenIaaSVirtualDisk, r.PathValue("id"), "virtual_disk", func(disk Object) {
+		copyFields(disk, body, map[string]string{"name": "name", "size": "size"})
+	})
+}
+
+func (s *Server) attachOpenIaaSVirtualDisk(attach bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, ok := decode(w, r)
+		if !ok {
+			return
+		}
+		vm := str(body, "virtualMachineId")
+		if attach && !s.requireParent(w, OpenIaaSVirtualMachine, vm) {
+			return
+		}
+		s.update(w, OpenIaaSVirtualDisk, r.PathValue("id"), "virtual_disk", func(disk Object) {
+			var conn Object
+			if attach {
+				conn = Object{"id": vm, "name": "", "readOnly": str(body, "mode") == "RO", "connected": true}
+			}
+			setDiskVirtualMachine(disk, vm, conn)
+		})
+	}
+}
+
+func (s *Server) connectOpenIaaSVirtualDisk(connected bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, ok := decode(w, r)
+		if !ok {
+			return
+		}
+		vm := str(body, "virtualMachineId")
+		s.update(w, OpenIaaSVirtualDisk, r.PathValue("id"), "virtual_disk", func(disk Object) {
+			vms, _ := disk["virtualMachines"].([]Object)
+			for _, v := range vms {
+				if v["id"] == vm {
+					v["connected"] = connected
+				}
+			}
+		})
+	}
+}
+
+func (s *Server) createOpenIaaSNetworkAdapter(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok || !s.requireParent(w, OpenIaaSVirtualMachine, str(body, "virtualMachineId")) {
+		return
+	}
+	adapters := s.collection(OpenIaaSNetworkAdapter).list(url.Values{"virtualMachineId": {str(body, "virtualMachineId")}}, matchFields)
+	mac := str(body, "mac")
+	if mac == "" {
+		mac = s.newMAC()
+	}
+	adapter := Object{
+		"id":               s.newID(),
+		"name":             fmt.Sprintf("VIF %d", len(adapters)),
+		"virtualMachineId": body["virtualMachineId"],
+		"macAddress":       mac,
+		"mtu":              1500,
+		"attached":         true,
+		"txChecksumming":   true,
+		"network":          ref(body["networkId"]),
+		"machineManager":   ref(""),
+	}
+	s.create(w, OpenIaaSNetworkAdapter, "network_adapter", adapter, nil)
+}
+
+func (s *Server) updateOpenIaaSNetworkAdapter(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	s.update(w, OpenIaaSNetworkAdapter, r.PathValue("id"), "network_adapter", func(adapter Object) {
+		copyFields(adapter, body, map[string]string{"mac": "macAddress", "attached": "attached", "txChecksumming": "txChecksumming"})
+		if network := str(body, "networkId"); network != "" {
+			adapter["network"] = ref(network)
+		}
+	})
+}
+
+func (s *Server) connectOpenIaaSNetworkAdapter(attached bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.update(w, OpenIaaSNetworkAdapter, r.PathValue("id"), "network_adapter", func(adapter Object) {
+			adapter["attached"] = attached
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package fakeapi
+
+import (
+	"net/http"
+	"strings"
+)
+
+// fault answers the next count requests matching method and path with status.
+type fault struct {
+	method string
+	path   string
+	status int
+	count  int
+}
+
+// FailRequests answers the next count requests whose method is method (any
+// method when empty) and whose path starts with path with status, e.g. a burst
+// of 502 from the gateway. The faults are checked in the order they were set.
+func (s *Server) FailRequests(method, path string, status, count int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.faults = append(s.faults, &fault{method: method, path: path, status: status, count: count})
+}
+
+// FailActivities makes the next count activities fail with reason, without
+// applying their change. TransientReason is a reason the client retries.
+func (s *Server) FailActivities(reason string, count int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for i := 0; i < count; i++ {
+		s.activityFailures = append(s.activityFailures, reason)
+	}
+}
+
+// HideCreated makes the objects created from now on answer 404 to their first
+// reads, and be left out of the listings meanwhile, as the live API does
+// while its inventory catches up. 0 turns it off.
+func (s *Server) HideCreated(reads int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.hiddenReads = reads
+}
+
+// HideActivities makes the activities started from now on answer 404 to their
+// first reads. 0 turns it off.
+func (s *Server) HideActivities(reads int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.hiddenActivityRead = reads
+}
+
+func (s *Server) takeFault(r *http.Request) *fault {
+	for i, f := range s.faults {
+		if (f.method != "" && f.method != r.Method) || !strings.HasPrefix(r.URL.Path, f.path) {
+			continue
+		}
+		f.count--
+		if f.count <= 0 {
+			s.faults = append(s.faults[:i], s.faults[i+1:]...)
+		}
+		return f
+	}
+	return nil
+}
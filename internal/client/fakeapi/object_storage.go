@@ -0,0 +1,125 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+func (s *Server) registerObjectStorage() {
+	const base = "/storage/object/v1"
+	s.mux.HandleFunc("GET "+base+"/buckets", s.listHandler(Bucket, nil))
+	s.mux.HandleFunc("GET "+base+"/buckets/{name}", s.readHandler(Bucket, "name"))
+	s.mux.HandleFunc("POST "+base+"/buckets", s.createBucket)
+	s.mux.HandleFunc("PUT "+base+"/buckets/{name}/whitelist", s.updateBucketWhitelist)
+	s.mux.HandleFunc("PUT "+base+"/buckets/{name}/versioning", s.updateBucketVersioning)
+	s.mux.HandleFunc("DELETE "+base+"/buckets/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.remove(w, Bucket, r.PathValue("name"), "bucket", nil)
+	})
+	s.mux.HandleFunc("GET "+base+"/buckets/{name}/storage_accounts", s.listACLEntries(Bucket))
+
+	s.mux.HandleFunc("GET "+base+"/storage_accounts", s.listHandler(StorageAccount, nil))
+	s.mux.HandleFunc("GET "+base+"/storage_accounts/{name}", s.readHandler(StorageAccount, "name"))
+	s.mux.HandleFunc("POST "+base+"/storage_accounts", s.createStorageAccount)
+	s.mux.HandleFunc("DELETE "+base+"/storage_accounts/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.remove(w, StorageAccount, r.PathValue("name"), "storage_account", nil)
+	})
+	s.mux.HandleFunc("GET "+base+"/storage_accounts/{name}/buckets", s.listACLEntries(StorageAccount))
+}
+
+func (s *Server) createBucket(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	name := str(body, "name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	if s.collection(Bucket).objects[name] != nil {
+		writeError(w, http.StatusConflict, fmt.Sprintf("bucket %q already exists", name))
+		return
+	}
+	bucket := Object{
+		"id":                  s.newID(),
+		"name":                name,
+		"namespace":           "fakeapi",
+		"retentionPeriod":     0,
+		"versioning":          "Suspended",
+		"endpoint":            "https://" + name + ".s3.fakeapi.invalid",
+		"totalSize":           "0",
+		"totalSizeUnit":       "B",
+		"totalObjects":        0,
+		"tags":                []Object{},
+		"totalObjectsDeleted": "0",
+		"totalSizeDeleted":    "0",
+		"accessType":          body["accessType"],
+		"whitelist":           body["whitelist"],
+	}
+	s.create(w, Bucket, "bucket", bucket, nil)
+}
+
+func (s *Server) updateBucketWhitelist(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	s.update(w, Bucket, r.PathValue("name"), "bucket", func(bucket Object) {
+		copyFields(bucket, body, map[string]string{"accessType": "accessType", "whitelist": "whitelist"})
+	})
+}
+
+func (s *Server) updateBucketVersioning(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	s.update(w, Bucket, r.PathValue("name"), "bucket", func(bucket Object) {
+		bucket["versioning"] = body["status"]
+	})
+}
+
+// createStorageAccount answers synchronously, with the only copy of the secret
+// access key.
+func (s *Server) createStorageAccount(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	name := str(body, "name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	if s.collection(StorageAccount).objects[name] != nil {
+		writeError(w, http.StatusConflict, fmt.Sprintf("storage account %q already exists", name))
+		return
+	}
+	id := s.newID()
+	accessKeyID := fmt.Sprintf("FAKEAPI%013d", s.seq)
+	account := Object{
+		"id":          id,
+		"name":        name,
+		"accessKeyId": accessKeyID,
+		"arn":         "arn:aws:iam::fakeapi:user/" + name,
+		"createDate":  time.Now().UTC().Format(time.RFC3339),
+		"path":        "/",
+		"tags":        []Object{},
+	}
+	s.collection(StorageAccount).put(name, account, s.hiddenReads)
+	writeJSON(w, http.StatusCreated, Object{
+		"accessKeyId":     accessKeyID,
+		"secretAccessKey": "fakeapi-secret-" + id,
+	})
+}
+
+func (s *Server) listACLEntries(kind Kind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.collection(kind).visible(r.PathValue("name")) {
+			writeError(w, http.StatusNotFound, "not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, []Object{})
+	}
+}
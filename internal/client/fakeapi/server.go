@@ -0,0 +1,466 @@
+// Package fakeapi is an in-process, stateful fake of the Cloud Temple API. It
+// keeps the objects created through it, runs the writes as activities that
+// complete over successive reads, and injects the faults the live API is known
+// for, so the client, the provider and ct-validate can run whole
+// create/update/delete cycles without a tenant.
+//
+// It covers the VMware and Open IaaS virtual machines, disks and network
+// adapters, the object storage buckets and storage accounts, the VPC static
+// and floating IPs, and the tags. The objects are served with the fields the
+// client reads, not every field of the live API.
+package fakeapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// The identifiers carried by the tokens of the fake.
+const (
+	TenantID  = "00000000-0000-4000-8000-00000000000a"
+	CompanyID = "00000000-0000-4000-8000-00000000000b"
+	UserID    = "00000000-0000-4000-8000-00000000000c"
+)
+
+// TransientReason is a failure reason the client knows to be transient (see
+// client.IsTransientActivityFailure), for FailActivities.
+const TransientReason = "None of the workers were able to respond"
+
+// Object is an object of the fake, encoded as the API would.
+type Object = map[string]interface{}
+
+// Kind names a collection of objects of the fake.
+type Kind string
+
+const (
+	VMwareVirtualMachine   Kind = "vmware_virtual_machine"
+	VMwareVirtualDisk      Kind = "vmware_virtual_disk"
+	VMwareNetworkAdapter   Kind = "vmware_network_adapter"
+	OpenIaaSVirtualMachine Kind = "open_iaas_virtual_machine"
+	OpenIaaSVirtualDisk    Kind = "open_iaas_virtual_disk"
+	OpenIaaSNetworkAdapter Kind = "open_iaas_network_adapter"
+	Bucket                 Kind = "bucket"
+	StorageAccount         Kind = "storage_account"
+	StaticIP               Kind = "static_ip"
+	FloatingIP             Kind = "floating_ip"
+)
+
+// Server is the fake API, listening on a local address.
+type Server struct {
+	// URL is the address of the server, for client.Config.Address.
+	URL string
+
+	srv *httptest.Server
+	mux *http.ServeMux
+
+	// lock serializes the requests: the fake handles one at a time.
+	lock sync.Mutex
+
+	// runningReads is the number of reads an activity stays running for.
+	runningReads int
+
+	seq           int
+	tokens        map[string]bool
+	activities    map[string]*activity
+	activityOrder []string
+	collections   map[Kind]*collection
+	tags          map[string][]Object
+	requests      []string
+
+	faults             []*fault
+	activityFailures   []string
+	hiddenReads        int
+	hiddenActivityRead int
+}
+
+// NewServer starts a fake API with no object. It is closed by Close.
+func NewServer() *Server {
+	s := &Server{
+		mux:         http.NewServeMux(),
+		tokens:      map[string]bool{},
+		activities:  map[string]*activity{},
+		collections: map[Kind]*collection{},
+		tags:        map[string][]Object{},
+	}
+	s.mux.HandleFunc("POST /iam/v2/auth/personal_access_token", s.login)
+	s.mux.HandleFunc("GET /activity/v1/activities", s.listActivities)
+	s.mux.HandleFunc("GET /activity/v1/activities/{id}", s.readActivity)
+	s.registerCompute()
+	s.registerObjectStorage()
+	s.registerVPC()
+	s.registerTags()
+
+	s.srv = httptest.NewServer(s)
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Config returns a client configuration using the server.
+func (s *Server) Config() *client.Config {
+	return &client.Config{
+		Address:  s.URL,
+		ClientID: "fake-client-id",
+		SecretID: "fake-secret-id",
+	}
+}
+
+// Requests returns the requests served so far, as "METHOD /path".
+func (s *Server) Requests() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// SetRunningReads sets the number of reads an activity stays running for
+// before it completes, 0 by default. A write only takes effect once its
+// activity is completed.
+func (s *Server) SetRunningReads(n int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.runningReads = n
+}
+
+// RevokeTokens makes the tokens issued so far invalid: the requests using them
+// get a 401 until the client logs in again.
+func (s *Server) RevokeTokens() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.tokens = map[string]bool{}
+}
+
+// Add stores obj in the collection of kind, as if it had always existed, e.g.
+// the virtual machine a disk is created on. An id is set when obj has none;
+// it is returned.
+func (s *Server) Add(kind Kind, obj Object) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := obj["id"]; !ok {
+		obj["id"] = s.newID()
+	}
+	s.collection(kind).put(keyOf(kind, obj), obj, 0)
+	return obj["id"].(string)
+}
+
+// Get returns a copy of the object of kind with the given id (the name for
+// buckets and storage accounts), or nil.
+func (s *Server) Get(kind Kind, key string) Object {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	obj := s.collection(kind).objects[key]
+	if obj == nil {
+		return nil
+	}
+	data, _ := json.Marshal(obj)
+	var out Object
+	_ = json.Unmarshal(data, &out)
+	return out
+}
+
+// Len returns the number of objects of kind.
+func (s *Server) Len(kind Kind) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.collection(kind).objects)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// The client adds the /api suffix of the live address when asked to.
+	r.URL.Path = strings.TrimPrefix(r.URL.Path, "/api")
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+
+	if f := s.takeFault(r); f != nil {
+		w.WriteHeader(f.status)
+		fmt.Fprintf(w, `{"message":"injected %d"}`, f.status)
+		return
+	}
+	if !strings.HasSuffix(r.URL.Path, "/auth/personal_access_token") {
+		token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !s.tokens[token] {
+			writeError(w, http.StatusUnauthorized, "invalid or expired token")
+			return
+		}
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	if body["id"] == "" || body["secret"] == "" || body["id"] == nil || body["secret"] == nil {
+		writeError(w, http.StatusUnauthorized, "invalid credentials")
+		return
+	}
+
+	s.seq++
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"jti":       fmt.Sprint(s.seq),
+		"userId":    UserID,
+		"companyId": CompanyID,
+		"scope":     map[string]interface{}{"id": TenantID},
+		"exp":       time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("fakeapi"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	s.tokens[token] = true
+	_, _ = w.Write([]byte(token))
+}
+
+// newID returns a new identifier, shaped as a UUID like the live ones.
+func (s *Server) newID() string {
+	s.seq++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.seq)
+}
+
+// collection is the set of objects of a kind, by key.
+type collection struct {
+	objects map[string]Object
+	order   []string
+	// hidden counts the reads an object still answers 404 to.
+	hidden map[string]int
+}
+
+func (s *Server) collection(kind Kind) *collection {
+	c := s.collections[kind]
+	if c == nil {
+		c = &collection{objects: map[string]Object{}, hidden: map[string]int{}}
+		s.collections[kind] = c
+	}
+	return c
+}
+
+func (c *collection) put(key string, obj Object, hidden int) {
+	if _, found := c.objects[key]; !found {
+		c.order = append(c.order, key)
+	}
+	c.objects[key] = obj
+	if hidden > 0 {
+		c.hidden[key] = hidden
+	}
+}
+
+func (c *collection) remove(key string) {
+	delete(c.objects, key)
+	delete(c.hidden, key)
+	for i, k := range c.order {
+		if k == key {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// visible reports whether the object is readable, consuming one of its hidden
+// reads otherwise.
+func (c *collection) visible(key string) bool {
+	if c.objects[key] == nil {
+		return false
+	}
+	if c.hidden[key] > 0 {
+		c.hidden[key]--
+		return false
+	}
+	return true
+}
+
+// list returns the visible objects matching query, in creation order.
+func (c *collection) list(query url.Values, match func(Object, url.Values) bool) []Object {
+	out := []Object{}
+	for _, key := range c.order {
+		obj := c.objects[key]
+		if c.hidden[key] > 0 || !match(obj, query) {
+			continue
+		}
+		out = append(out, obj)
+	}
+	return out
+}
+
+// page returns the window of objs the limit and offset of query ask for, all of
+// them when there is no limit, as the paginated endpoints of the API do.
+func page(objs []Object, query url.Values) []Object {
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 {
+		return objs
+	}
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	if offset < 0 || offset >= len(objs) {
+		return []Object{}
+	}
+	return objs[offset:min(offset+limit, len(objs))]
+}
+
+func keyOf(kind Kind, obj Object) string {
+	if kind == Bucket || kind == StorageAccount {
+		name, _ := obj["name"].(string)
+		return name
+	}
+	id, _ := obj["id"].(string)
+	return id
+}
+
+// matchFields keeps the objects whose top-level string fields equal the query
+// parameters of the same name; the parameters naming no such field are ignored.
+func matchFields(obj Object, query url.Values) bool {
+	for name, values := range query {
+		if v, ok := obj[name].(string); ok && len(values) > 0 && v != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) readHandler(kind Kind, param string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c := s.collection(kind)
+		key := r.PathValue(param)
+		if !c.visible(key) {
+			writeError(w, http.StatusNotFound, "not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, c.objects[key])
+	}
+}
+
+func (s *Server) listHandler(kind Kind, match func(Object, url.Values) bool) http.HandlerFunc {
+	if match == nil {
+		match = matchFields
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, page(s.collection(kind).list(r.URL.Query(), match), r.URL.Query()))
+	}
+}
+
+// create starts the activity creating obj in the collection of kind. The object
+// appears when the activity completes, hidden for the reads set by
+// HideCreated.
+func (s *Server) create(w http.ResponseWriter, kind Kind, itemType string, obj Object, then func()) {
+	id := obj["id"].(string)
+	s.startActivity(w, http.StatusCreated, "create "+itemType, []Object{{"id": id, "type": itemType}}, func() string {
+		s.collection(kind).put(keyOf(kind, obj), obj, s.hiddenReads)
+		if then != nil {
+			then()
+		}
+		return id
+	})
+}
+
+// update starts the activity applying change to the object of kind with the
+// given key; it answers 404 when there is none.
+func (s *Server) update(w http.ResponseWriter, kind Kind, key, itemType string, change func(obj Object)) {
+	obj := s.collection(kind).objects[key]
+	if obj == nil {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	id, _ := obj["id"].(string)
+	s.startActivity(w, http.StatusOK, "update "+itemType, []Object{{"id": id, "type": itemType}}, func() string {
+		if current := s.collection(kind).objects[key]; current != nil {
+			change(current)
+		}
+		return id
+	})
+}
+
+// remove starts the activity deleting the object of kind with the given key;
+// then runs with it, e.g. to delete the objects depending on it.
+func (s *Server) remove(w http.ResponseWriter, kind Kind, key, itemType string, then func()) {
+	obj := s.collection(kind).objects[key]
+	if obj == nil {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	id, _ := obj["id"].(string)
+	s.startActivity(w, http.StatusOK, "delete "+itemType, []Object{{"id": id, "type": itemType}}, func() string {
+		s.collection(kind).remove(key)
+		if then != nil {
+			then()
+		}
+		return id
+	})
+}
+
+// removeWhere deletes the objects of kind matching match.
+func (s *Server) removeWhere(kind Kind, match func(Object) bool) {
+	c := s.collection(kind)
+	for _, key := range append([]string(nil), c.order...) {
+		if match(c.objects[key]) {
+			c.remove(key)
+		}
+	}
+}
+
+// copyFields copies the fields of src present in names to dst, under the name
+// names maps them to.
+func copyFields(dst, src Object, names map[string]string) {
+	keys := make([]string, 0, len(names))
+	for key := range names {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if v, ok := src[key]; ok {
+			dst[names[key]] = v
+		}
+	}
+}
+
+func ref(id interface{}) Object {
+	return Object{"id": id, "name": ""}
+}
+
+func str(obj Object, key string) string {
+	v, _ := obj[key].(string)
+	return v
+}
+
+func decode(w http.ResponseWriter, r *http.Request) (Object, bool) {
+	body := Object{}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: "+err.Error())
+		return nil, false
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return body, true
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: "+err.Error())
+		return nil, false
+	}
+	return body, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, Object{"message": message})
+}
@@ -0,0 +1,364 @@
+package fakeapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/stretchr/testify/require"
+)
+
+func newClient(t *testing.T, s *Server) *client.Client {
+	t.Helper()
+	c, err := client.NewClient(s.Config())
+	require.NoError(t, err)
+	return c
+}
+
+func newServer(t *testing.T) *Server {
+	t.Helper()
+	s := NewServer()
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestVMwareVirtualMachineLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s := newServer(t)
+	c := newClient(t, s)
+
+	activityID, err := c.Compute().VirtualMachine().Create(ctx, &client.CreateVirtualMachineRequest{
+		Name:   "vm",
+		Memory: 2147483648,
+		CPU:    2,
+	})
+	require.NoError(t, err)
+	id, err := c.Activity().WaitForCreatedID(ctx, activityID, "virtual machine", nil)
+	require.NoError(t, err)
+
+	vm, err := c.Compute().VirtualMachine().Read(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, "vm", vm.Name)
+	require.Equal(t, "stopped", vm.PowerState)
+
+	activityID, err = c.Compute().VirtualDisk().Create(ctx, &client.CreateVirtualDiskRequest{
+		ProvisioningType: "dynamic",
+		DiskMode:         "persistent",
+		Capacity:         10737418240,
+		VirtualMachineId: id,
+	})
+	require.NoError(t, err)
+	diskID, err := c.Activity().WaitForCreatedID(ctx, activityID, "virtual disk", nil)
+	require.NoError(t, err)
+
+	activityID, err = c.Compute().NetworkAdapter().Create(ctx, &client.CreateNetworkAdapterRequest{
+		VirtualMachineId: id,
+		NetworkId:        "network",
+		Type:             "VMXNET3",
+	})
+	require.NoError(t, err)
+	adapterID, err := c.Activity().WaitForCreatedID(ctx, activityID, "network adapter", nil)
+	require.NoError(t, err)
+	adapter, err := c.Compute().NetworkAdapter().Read(ctx, adapterID)
+	require.NoError(t, err)
+	require.Regexp(t, "^00:50:56:", adapter.MacAddress)
+
+	activityID, err = c.Compute().VirtualMachine().Power(ctx, &client.PowerRequest{ID: id, PowerAction: "on"})
+	require.NoError(t, err)
+	_, err = c.Activity().WaitForCompletion(ctx, activityID, nil)
+	require.NoError(t, err)
+	vm, err = c.Compute().VirtualMachine().Read(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, "running", vm.PowerState)
+
+	disks, err := c.Compute().VirtualDisk().List(ctx, &client.VirtualDiskFilter{VirtualMachineID: id})
+	require.NoError(t, err)
+	require.Len(t, disks, 1)
+	require.Equal(t, diskID, disks[0].ID)
+	require.Equal(t, "Hard disk 1", disks[0].Name)
+
+	// Deleting the virtual machine deletes its disks and adapters.
+	activityID, err = c.Compute().VirtualMachine().Delete(ctx, id)
+	require.NoError(t, err)
+	_, err = c.Activity().WaitForCompletion(ctx, activityID, nil)
+	require.NoError(t, err)
+	vm, err = c.Compute().VirtualMachine().Read(ctx, id)
+	require.NoError(t, err)
+	require.Nil(t, vm)
+	require.Zero(t, s.Len(VMwareVirtualDisk))
+	require.Zero(t, s.Len(VMwareNetworkAdapter))
+}
+
+func TestOpenIaaSVirtualMachineLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s := newServer(t)
+	c := newClient(t, s)
+
+	activityID, err := c.Compute().OpenIaaS().VirtualMachine().Create(ctx, &client.CreateOpenIaasVirtualMachineRequest{
+		Name:            "vm",
+		TemplateID:      "template",
+		CPU:             1,
+		Memory:          1073741824,
+		NetworkAdapters: []client.OSNetworkAdapter{{NetworkID: "network", MAC: "aa:bb:cc:dd:ee:ff"}},
+	})
+	require.NoError(t, err)
+	id, err := c.Activity().WaitForCreatedID(ctx, activityID, "virtual machine", nil)
+	require.NoError(t, err)
+
+	adapters, err := c.Compute().OpenIaaS().NetworkAdapter().List(ctx, &client.OpenIaaSNetworkAdapterFilter{VirtualMachineID: id})
+	require.NoError(t, err)
+	require.Len(t, adapters, 1)
+	require.Equal(t, "aa:bb:cc:dd:ee:ff", adapters[0].MacAddress)
+
+	activityID, err = c.Compute().OpenIaaS().VirtualDisk().Create(ctx, &client.OpenIaaSVirtualDiskCreateRequest{
+		Name:                "data",
+		Size:                1073741824,
+		Mode:                "RW",
+		StorageRepositoryID: "repository",
+		VirtualMachineID:    id,
+	})
+	require.NoError(t, err)
+	diskID, err := c.Activity().WaitForCreatedID(ctx, activityID, "virtual disk", nil)
+	require.NoError(t, err)
+	disks, err := c.Compute().OpenIaaS().VirtualDisk().List(ctx, &client.OpenIaaSVirtualDiskFilter{VirtualMachineID: id})
+	require.NoError(t, err)
+	require.Len(t, disks, 1)
+	require.Equal(t, diskID, disks[0].ID)
+
+	activityID, err = c.Compute().OpenIaaS().VirtualMachine().Power(ctx, id, &client.UpdateOpenIaasVirtualMachinePowerRequest{PowerState: "on"})
+	require.NoError(t, err)
+	_, err = c.Activity().WaitForCompletion(ctx, activityID, nil)
+	require.NoError(t, err)
+	vm, err := c.Compute().OpenIaaS().VirtualMachine().Read(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, "Running", vm.PowerState)
+
+	activityID, err = c.Compute().OpenIaaS().VirtualMachine().Delete(ctx, id)
+	require.NoError(t, err)
+	_, err = c.Activity().WaitForCompletion(ctx, activityID, nil)
+	require.NoError(t, err)
+	require.Zero(t, s.Len(OpenIaaSVirtualMachine))
+	require.Zero(t, s.Len(OpenIaaSNetworkAdapter))
+	require.Empty(t, s.Get(OpenIaaSVirtualDisk, diskID)["virtualMachines"])
+}
+
+func TestObjectStorageLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s := newServer(t)
+	c := newClient(t, s)
+
+	activityID, err := c.ObjectStorage().Bucket().Create(ctx, &client.CreateBucketRequest{Name: "bucket", AccessType: "private"})
+	require.NoError(t, err)
+	_, err = c.Activity().WaitForCompletion(ctx, activityID, nil)
+	require.NoError(t, err)
+	bucket, err := c.ObjectStorage().Bucket().Read(ctx, "bucket")
+	require.NoError(t, err)
+	require.Equal(t, "bucket", bucket.Name)
+
+	_, err = c.ObjectStorage().Bucket().Create(ctx, &client.CreateBucketRequest{Name: "bucket", AccessType: "private"})
+	require.Error(t, err)
+
+	account, err := c.ObjectStorage().StorageAccount().Create(ctx, &client.CreateStorageAccountRequest{Name: "account"})
+	require.NoError(t, err)
+	require.NotEmpty(t, account.AccessKeyID)
+	require.NotEmpty(t, account.SecretAccessKey)
+
+	activityID, err = c.ObjectStorage().Bucket().Delete(ctx, "bucket")
+	require.NoError(t, err)
+	_, err = c.Activity().WaitForCompletion(ctx, activityID, nil)
+	require.NoError(t, err)
+	activityID, err = c.ObjectStorage().StorageAccount().Delete(ctx, "account")
+	require.NoError(t, err)
+	_, err = c.Activity().WaitForCompletion(ctx, activityID, nil)
+	require.NoError(t, err)
+	require.Zero(t, s.Len(Bucket))
+	require.Zero(t, s.Len(StorageAccount))
+}
+
+func TestFloatingIPLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s := newServer(t)
+	c := newClient(t, s)
+
+	staticID, err := c.VPC().StaticIP().Create(ctx, "network", &client.CreateStaticIPRequest{
+		MacAddress:          "00:50:56:00:00:01",
+		ResourceDescription: "static",
+	}, nil)
+	require.NoError(t, err)
+	fipID, err := c.VPC().FloatingIP().Provision(ctx, nil)
+	require.NoError(t, err)
+
+	activityID, err := c.VPC().FloatingIP().Bind(ctx, fipID, staticID)
+	require.NoError(t, err)
+	_, err = c.Activity().WaitForCompletion(ctx, activityID, nil)
+	require.NoError(t, err)
+	fip, err := c.VPC().FloatingIP().Read(ctx, fipID)
+	require.NoError(t, err)
+	require.NotNil(t, fip.StaticIP)
+	require.Equal(t, staticID, fip.StaticIP.ID)
+
+	// A bound floating IP is not released.
+	require.Error(t, c.VPC().FloatingIP().DeprovisionUnbound(ctx, fipID, nil))
+
+	activityID, err = c.VPC().FloatingIP().Unbind(ctx, fipID, staticID)
+	require.NoError(t, err)
+	_, err = c.Activity().WaitForCompletion(ctx, activityID, nil)
+	require.NoError(t, err)
+	require.NoError(t, c.VPC().FloatingIP().DeprovisionUnbound(ctx, fipID, nil))
+	require.Zero(t, s.Len(FloatingIP))
+
+	activityID, err = c.VPC().StaticIP().Delete(ctx, staticID)
+	require.NoError(t, err)
+	_, err = c.Activity().WaitForCompletion(ctx, activityID, nil)
+	require.NoError(t, err)
+	require.Zero(t, s.Len(StaticIP))
+}
+
+func TestTags(t *testing.T) {
+	ctx := context.Background()
+	s := newServer(t)
+	c := newClient(t, s)
+
+	resource := []*client.CreateTagRequestResource{{UUID: "resource", Type: "virtual_machine", Source: "vmware"}}
+	require.NoError(t, c.Tag().Resource().Create(ctx, &client.CreateTagRequest{Key: "env", Value: "dev", Resources: resource}))
+	require.NoError(t, c.Tag().Resource().Create(ctx, &client.CreateTagRequest{Key: "env", Value: "prod", Resources: resource}))
+
+	tags, err := c.Tag().Resource().Read(ctx, "resource")
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	require.Equal(t, "prod", tags[0].Value)
+
+	require.NoError(t, c.Tag().Resource().Delete(ctx, "resource", "env"))
+	tags, err = c.Tag().Resource().Read(ctx, "resource")
+	require.NoError(t, err)
+	require.Empty(t, tags)
+}
+
+func TestFailRequests(t *testing.T) {
+	ctx := context.Background()
+	s := newServer(t)
+	config := s.Config()
+	config.ReadRetryMax = 3
+	config.ReadRetryBackoffBase = time.Millisecond
+	config.ReadRetryBackoffMax = time.Millisecond
+	c, err := client.NewClient(config)
+	require.NoError(t, err)
+
+	id := s.Add(VMwareVirtualMachine, Object{"name": "vm"})
+	s.FailRequests("GET", "/compute/v1/vcenters/virtual_machines", 502, 2)
+	vm, err := c.Compute().VirtualMachine().Read(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, "vm", vm.Name)
+
+	s.FailRequests("GET", "/compute/v1/vcenters/virtual_machines", 502, 3)
+	_, err = c.Compute().VirtualMachine().Read(ctx, id)
+	require.Error(t, err)
+}
+
+func TestHideCreated(t *testing.T) {
+	ctx := context.Background()
+	s := newServer(t)
+	c := newClient(t, s)
+
+	s.HideCreated(1)
+	activityID, err := c.ObjectStorage().Bucket().Create(ctx, &client.CreateBucketRequest{Name: "bucket", AccessType: "private"})
+	require.NoError(t, err)
+	_, err = c.Activity().WaitForCompletion(ctx, activityID, nil)
+	require.NoError(t, err)
+
+	buckets, err := c.ObjectStorage().Bucket().List(ctx)
+	require.NoError(t, err)
+	require.Empty(t, buckets)
+	bucket, err := c.ObjectStorage().Bucket().Read(ctx, "bucket")
+	require.NoError(t, err)
+	require.Nil(t, bucket)
+
+	bucket, err = c.ObjectStorage().Bucket().Read(ctx, "bucket")
+	require.NoError(t, err)
+	require.NotNil(t, bucket)
+}
+
+func TestFailActivities(t *testing.T) {
+	ctx := context.Background()
+	s := newServer(t)
+	c := newClient(t, s)
+
+	s.FailActivities(TransientReason, 1)
+	activityID, err := c.ObjectStorage().Bucket().Create(ctx, &client.CreateBucketRequest{Name: "bucket", AccessType: "private"})
+	require.NoError(t, err)
+	_, err = c.Activity().WaitForCompletion(ctx, activityID, nil)
+	require.Error(t, err)
+	require.True(t, client.IsTransientActivityFailure(err))
+	require.Zero(t, s.Len(Bucket))
+
+	// The failure is used up: the retry goes through.
+	activityID, err = c.ObjectStorage().Bucket().Create(ctx, &client.CreateBucketRequest{Name: "bucket", AccessType: "private"})
+	require.NoError(t, err)
+	_, err = c.Activity().WaitForCompletion(ctx, activityID, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, s.Len(Bucket))
+}
+
+func TestActivityStates(t *testing.T) {
+	ctx := context.Background()
+	s := newServer(t)
+	c := newClient(t, s)
+
+	s.SetRunningReads(1)
+	s.HideActivities(1)
+	activityID, err := c.ObjectStorage().Bucket().Create(ctx, &client.CreateBucketRequest{Name: "bucket", AccessType: "private"})
+	require.NoError(t, err)
+
+	activity, err := c.Activity().Read(ctx, activityID)
+	require.NoError(t, err)
+	require.Nil(t, activity)
+	activity, err = c.Activity().Read(ctx, activityID)
+	require.NoError(t, err)
+	require.Contains(t, activity.State, "running")
+	require.Len(t, activity.ConcernedItems, 1)
+	require.Equal(t, "bucket", activity.ConcernedItems[0].Type)
+	require.Zero(t, s.Len(Bucket))
+
+	activity, err = c.Activity().Read(ctx, activityID)
+	require.NoError(t, err)
+	require.Contains(t, activity.State, "completed")
+	require.Equal(t, activity.ConcernedItems[0].ID, s.Get(Bucket, "bucket")["id"])
+}
+
+func TestRevokeTokens(t *testing.T) {
+	ctx := context.Background()
+	s := newServer(t)
+	c := newClient(t, s)
+
+	_, err := c.ObjectStorage().Bucket().List(ctx)
+	require.NoError(t, err)
+	s.RevokeTokens()
+
+	// The client logs in again and sends the request once more.
+	activityID, err := c.ObjectStorage().Bucket().Create(ctx, &client.CreateBucketRequest{Name: "bucket", AccessType: "private"})
+	require.NoError(t, err)
+	_, err = c.Activity().WaitForCompletion(ctx, activityID, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, s.Len(Bucket))
+	require.Equal(t, 2, strings.Count(strings.Join(s.Requests(), "\n"), "POST /iam/v2/auth/personal_access_token"))
+}
+
+func TestListPages(t *testing.T) {
+	ctx := context.Background()
+	s := newServer(t)
+	config := s.Config()
+	config.ListPageSize = 2
+	c, err := client.NewClient(config)
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		s.Add(VMwareVirtualMachine, Object{"name": fmt.Sprintf("vm-%d", i)})
+	}
+	vms, err := c.Compute().VirtualMachine().ListStrict(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, vms, 5)
+	require.Equal(t, 3, strings.Count(strings.Join(s.Requests(), "\n"), "GET /compute/v1/vcenters/virtual_machines"))
+}
@@ -0,0 +1,58 @@
+package fakeapi
+
+import "net/http"
+
+func (s *Server) registerTags() {
+	s.mux.HandleFunc("POST /tag/v1/tags", s.createTag)
+	s.mux.HandleFunc("GET /tag/v1/tags/resources/{resource}", s.readTags)
+	s.mux.HandleFunc("DELETE /tag/v1/tags/resources/{resource}/keys/{key}", s.deleteTag)
+}
+
+// createTag sets a tag on each of the resources of the request, replacing the
+// value of an existing key. The tags are written synchronously.
+func (s *Server) createTag(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	key := str(body, "key")
+	resources, _ := body["resources"].([]interface{})
+	if key == "" || len(resources) == 0 {
+		writeError(w, http.StatusBadRequest, "key and resources are required")
+		return
+	}
+	for _, resource := range resources {
+		resource, _ := resource.(Object)
+		id := str(resource, "uuid")
+		s.removeTag(id, key)
+		s.tags[id] = append(s.tags[id], Object{"key": key, "value": body["value"], "tenant": TenantID, "resource": id})
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) readTags(w http.ResponseWriter, r *http.Request) {
+	tags := s.tags[r.PathValue("resource")]
+	if tags == nil {
+		tags = []Object{}
+	}
+	writeJSON(w, http.StatusOK, tags)
+}
+
+func (s *Server) deleteTag(w http.ResponseWriter, r *http.Request) {
+	if !s.removeTag(r.PathValue("resource"), r.PathValue("key")) {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) removeTag(resource, key string) bool {
+	tags := s.tags[resource]
+	for i, tag := range tags {
+		if tag["key"] == key {
+			s.tags[resource] = append(tags[:i], tags[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,206 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// VPCID is the VPC of the private networks of the fake.
+const VPCID = "00000000-0000-4000-8000-00000000000d"
+
+func (s *Server) registerVPC() {
+	const base = "/vpc/v1"
+	s.mux.HandleFunc("GET "+base+"/private_networks/{network}/static_ips", s.listStaticIPs)
+	s.mux.HandleFunc("POST "+base+"/private_networks/{network}/static_ips", s.createStaticIP)
+	s.mux.HandleFunc("GET "+base+"/static_ips/{id}", s.readHandler(StaticIP, "id"))
+	s.mux.HandleFunc("GET "+base+"/static_ips/mac/{mac}", s.readStaticIPByMAC)
+	s.mux.HandleFunc("PATCH "+base+"/static_ips/{id}", s.updateStaticIP)
+	s.mux.HandleFunc("DELETE "+base+"/static_ips/{id}", s.deleteStaticIP)
+
+	s.mux.HandleFunc("GET "+base+"/floating_ips", s.listHandler(FloatingIP, matchFloatingIP))
+	s.mux.HandleFunc("GET "+base+"/floating_ips/{id}", s.readHandler(FloatingIP, "id"))
+	s.mux.HandleFunc("POST "+base+"/floating_ips", s.provisionFloatingIP)
+	s.mux.HandleFunc("PATCH "+base+"/floating_ips/{id}", s.updateFloatingIP)
+	s.mux.HandleFunc("DELETE "+base+"/floating_ips/{id}", s.deprovisionFloatingIP)
+	s.mux.HandleFunc("POST "+base+"/floating_ips/{id}/bind/static_ips/{static}", s.bindFloatingIP)
+	s.mux.HandleFunc("DELETE "+base+"/floating_ips/{id}/unbind/static_ips/{static}", s.unbindFloatingIP)
+}
+
+func (s *Server) listStaticIPs(w http.ResponseWriter, r *http.Request) {
+	network := r.PathValue("network")
+	vm := r.URL.Query().Get("virtualMachineId")
+	ips := s.collection(StaticIP).list(nil, func(ip Object, _ url.Values) bool {
+		if ref, _ := ip["privateNetwork"].(Object); ref["id"] != network {
+			return false
+		}
+		ref, _ := ip["virtualMachine"].(Object)
+		return vm == "" || (ref != nil && ref["id"] == vm)
+	})
+	writeJSON(w, http.StatusOK, page(ips, r.URL.Query()))
+}
+
+// createStaticIP answers 201 with the activity reserving the address. The
+// address is allocated in 10.0.0.0/16 when the request sets none.
+func (s *Server) createStaticIP(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	network := r.PathValue("network")
+	mac := strings.ToLower(str(body, "macAddress"))
+	if strings.TrimSpace(str(body, "resourceDescription")) == "" || mac == "" {
+		writeError(w, http.StatusBadRequest, "macAddress and resourceDescription are required")
+		return
+	}
+	for _, ip := range s.collection(StaticIP).objects {
+		if ip["macAddress"] == mac {
+			writeError(w, http.StatusConflict, fmt.Sprintf("a static IP is already reserved for %s", mac))
+			return
+		}
+	}
+	id := s.newID()
+	address := str(body, "ipAddress")
+	if address == "" {
+		address = fmt.Sprintf("10.0.%d.%d", (s.seq/250)%250, s.seq%250+2)
+	}
+	ip := Object{
+		"id":                  id,
+		"ipAddress":           address,
+		"macAddress":          mac,
+		"virtualMachine":      nil,
+		"networkAdapter":      nil,
+		"source":              "custom",
+		"resourceDescription": body["resourceDescription"],
+		"floatingIp":          nil,
+		"vpc":                 ref(VPCID),
+		"privateNetwork":      ref(network),
+	}
+	s.create(w, StaticIP, "static_ip", ip, nil)
+}
+
+func (s *Server) readStaticIPByMAC(w http.ResponseWriter, r *http.Request) {
+	mac := strings.ToLower(r.PathValue("mac"))
+	for _, ip := range s.collection(StaticIP).list(nil, func(Object, url.Values) bool { return true }) {
+		if ip["macAddress"] == mac {
+			writeJSON(w, http.StatusOK, ip)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "not found")
+}
+
+func (s *Server) updateStaticIP(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	s.update(w, StaticIP, r.PathValue("id"), "static_ip", func(ip Object) {
+		copyFields(ip, body, map[string]string{"resourceDescription": "resourceDescription", "macAddress": "macAddress"})
+	})
+}
+
+// deleteStaticIP refuses to delete a static IP a floating IP is bound to.
+func (s *Server) deleteStaticIP(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if ip := s.collection(StaticIP).objects[id]; ip != nil && ip["floatingIp"] != nil {
+		writeError(w, http.StatusConflict, "the static IP is bound to a floating IP")
+		return
+	}
+	s.remove(w, StaticIP, id, "static_ip", nil)
+}
+
+func matchFloatingIP(fip Object, query url.Values) bool {
+	vpc := query.Get("vpcId")
+	if vpc == "" {
+		return true
+	}
+	ref, _ := fip["vpc"].(Object)
+	return ref != nil && ref["id"] == vpc
+}
+
+// provisionFloatingIP answers 201 with the activity allocating the address, in
+// 203.0.113.0/24. The associations of an unbound floating IP are explicit
+// nulls, as the client requires before a deprovision.
+func (s *Server) provisionFloatingIP(w http.ResponseWriter, r *http.Request) {
+	if _, ok := decode(w, r); !ok {
+		return
+	}
+	id := s.newID()
+	fip := Object{
+		"id":             id,
+		"ipAddress":      fmt.Sprintf("203.0.113.%d", s.seq%254+1),
+		"description":    "",
+		"staticIp":       nil,
+		"vpc":            nil,
+		"privateNetwork": nil,
+	}
+	s.create(w, FloatingIP, "floating_ip", fip, nil)
+}
+
+func (s *Server) updateFloatingIP(w http.ResponseWriter, r *http.Request) {
+	body, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	s.update(w, FloatingIP, r.PathValue("id"), "floating_ip", func(fip Object) {
+		fip["description"] = body["description"]
+	})
+}
+
+// deprovisionFloatingIP refuses to release a bound floating IP.
+func (s *Server) deprovisionFloatingIP(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if fip := s.collection(FloatingIP).objects[id]; fip != nil && fip["staticIp"] != nil {
+		writeError(w, http.StatusConflict, "the floating IP is bound")
+		return
+	}
+	s.remove(w, FloatingIP, id, "floating_ip", nil)
+}
+
+// bindFloatingIP answers 409 when the floating IP is already bound to the same
+// static IP, and 400 when it is bound to another one.
+func (s *Server) bindFloatingIP(w http.ResponseWriter, r *http.Request) {
+	fipID, staticID := r.PathValue("id"), r.PathValue("static")
+	fip, ip := s.collection(FloatingIP).objects[fipID], s.collection(StaticIP).objects[staticID]
+	if fip == nil || ip == nil {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if bound, _ := fip["staticIp"].(Object); bound != nil {
+		if bound["id"] == staticID {
+			writeError(w, http.StatusConflict, "already bound")
+		} else {
+			writeError(w, http.StatusBadRequest, "the floating IP is bound to another static IP")
+		}
+		return
+	}
+	s.startActivity(w, http.StatusCreated, "bind floating_ip", []Object{{"id": fipID, "type": "floating_ip"}, {"id": staticID, "type": "static_ip"}}, func() string {
+		fip["staticIp"] = Object{"id": staticID, "address": ip["ipAddress"]}
+		fip["vpc"] = ip["vpc"]
+		fip["privateNetwork"] = ip["privateNetwork"]
+		ip["floatingIp"] = Object{"id": fipID, "ipAddress": fip["ipAddress"]}
+		return fipID
+	})
+}
+
+// unbindFloatingIP answers 409 when the floating IP is not bound to the static
+// IP.
+func (s *Server) unbindFloatingIP(w http.ResponseWriter, r *http.Request) {
+	fipID, staticID := r.PathValue("id"), r.PathValue("static")
+	fip, ip := s.collection(FloatingIP).objects[fipID], s.collection(StaticIP).objects[staticID]
+	if fip == nil || ip == nil {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if bound, _ := fip["staticIp"].(Object); bound == nil || bound["id"] != staticID {
+		writeError(w, http.StatusConflict, "not bound")
+		return
+	}
+	s.startActivity(w, http.StatusOK, "unbind floating_ip", []Object{{"id": fipID, "type": "floating_ip"}, {"id": staticID, "type": "static_ip"}}, func() string {
+		fip["staticIp"], fip["vpc"], fip["privateNetwork"] = nil, nil, nil
+		ip["floatingIp"] = nil
+		return fipID
+	})
+}
@@ -27,22 +27,27 @@ type Token struct {
 }
 
 func (p *PATClient) List(ctx context.Context) ([]*Token, error) {
-	r := p.c.newRequest("GET", "/iam/v2/personal_access_tokens")
-	resp, err := p.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	if err := requireOK(resp); err != nil {
-		return nil, err
-	}
-
-	var out []*Token
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, p.c, "personal access token", 0,
+		func(item *Token) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*Token, error) {
+			r := p.c.newRequest("GET", "/iam/v2/personal_access_tokens")
+			pg.addTo(r)
+			resp, err := p.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			if err := requireOK(resp); err != nil {
+				return nil, err
+			}
+
+			var out []*Token
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
 }
 
 // ListStrict behaves like List but requires a complete HTTP 200 answer.
@@ -51,22 +56,27 @@ func (p *PATClient) List(ctx context.Context) ([]*Token, error) {
 // absence, 201/403/5xx are not usable evidence either. Unlike List, it does
 // NOT go through requireOK (which also accepts 201 and 206).
 func (p *PATClient) ListStrict(ctx context.Context) ([]*Token, error) {
-	r := p.c.newRequest("GET", "/iam/v2/personal_access_tokens")
-	resp, err := p.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	if err := requireHttpCodes(resp, 200); err != nil {
-		return nil, err
-	}
-
-	var out []*Token
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, p.c, "personal access token", 0,
+		func(item *Token) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*Token, error) {
+			r := p.c.newRequest("GET", "/iam/v2/personal_access_tokens")
+			pg.addTo(r)
+			resp, err := p.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			if err := requireHttpCodes(resp, 200); err != nil {
+				return nil, err
+			}
+
+			var out []*Token
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
 }
 
 func (p *PATClient) Read(ctx context.Context, patID string) (*Token, error) {
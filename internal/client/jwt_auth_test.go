@@ -138,3 +138,153 @@ func TestJWTCacheHitGuardsExpClaim(t *testing.T) {
 		})
 	}
 }
+
+// TestNewAccessTokenBypassesTheCache: the ephemeral access token must be a fresh
+// exchange, and must not replace the token the provider itself authenticates
+// with.
+func TestNewAccessTokenBypassesTheCache(t *testing.T) {
+	var calls int32
+	fresh := signedJWT(t, jwt.MapClaims{"exp": float64(time.Now().Add(time.Hour).Unix())})
+	c, err := NewClient(&Config{Address: "https://shiva.example", Transport: authStub(fresh, &calls)})
+	require.NoError(t, err)
+	cached := &jwt.Token{Raw: "cached", Claims: jwt.MapClaims{"exp": float64(time.Now().Add(time.Hour).Unix())}}
+	c.SavedToken = cached
+
+	token, err := c.NewAccessToken(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, fresh, token.Raw)
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls), "a valid cached token must not be served")
+	require.Same(t, cached, c.SavedToken, "the cached token must be left in place")
+}
+
+// revokingStub is a transport whose auth endpoint issues tokens in turn and
+// whose other endpoints answer 401 to the revoked ones, so a test can check a
+// request is sent again with a new token.
+type revokingStub struct {
+	tokens  []string
+	revoked map[string]bool
+	// write is the answer to the writes sent with a valid token.
+	write   func() *http.Response
+	auths   int32
+	bodies  []string
+	headers http.Header
+}
+
+func (s *revokingStub) RoundTrip(r *http.Request) (*http.Response, error) {
+	if strings.HasSuffix(r.URL.Path, "/auth/personal_access_token") {
+		n := atomic.AddInt32(&s.auths, 1)
+		return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: io.NopCloser(strings.NewReader(s.tokens[n-1]))}, nil
+	}
+	if r.Body != nil {
+		body, _ := io.ReadAll(r.Body)
+		s.bodies = append(s.bodies, string(body))
+	}
+	if s.revoked[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")] {
+		return &http.Response{StatusCode: http.StatusUnauthorized, Header: s.headers, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+	}
+	if r.Method != http.MethodGet {
+		return s.write(), nil
+	}
+	return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: io.NopCloser(strings.NewReader("{}"))}, nil
+}
+
+func newRevokingStub(t *testing.T, n int) *revokingStub {
+	t.Helper()
+	s := &revokingStub{revoked: map[string]bool{}, headers: make(http.Header)}
+	for i := 0; i < n; i++ {
+		s.tokens = append(s.tokens, signedJWT(t, jwt.MapClaims{"exp": float64(time.Now().Add(time.Hour).Unix()), "n": i}))
+	}
+	s.write = func() *http.Response {
+		header := make(http.Header)
+		header.Set("Location", "activity")
+		return &http.Response{StatusCode: http.StatusCreated, Header: header, Body: io.NopCloser(strings.NewReader(""))}
+	}
+	return s
+}
+
+// TestUnauthorizedReauthenticatesOnce: a token the API revoked before its
+// expiry is dropped, and the request sent once more with a new token, instead
+// of failing every request until the process restarts.
+func TestUnauthorizedReauthenticatesOnce(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("a read is sent again with a new token", func(t *testing.T) {
+		stub := newRevokingStub(t, 2)
+		c, err := NewClient(&Config{Address: "https://shiva.example", Transport: stub})
+		require.NoError(t, err)
+		first, err := c.JWT(ctx)
+		require.NoError(t, err)
+		stub.revoked[first.Raw] = true
+
+		resp, err := c.doRequest(ctx, c.newRequest("GET", "/compute/v1/vcenters/virtual_machines"))
+		require.NoError(t, err)
+		require.NoError(t, requireOK(resp))
+		require.Equal(t, int32(2), atomic.LoadInt32(&stub.auths))
+		require.Equal(t, stub.tokens[1], c.SavedToken.Raw, "the new token must be cached")
+
+		// The next requests use the new token without authenticating again.
+		resp, err = c.doRequest(ctx, c.newRequest("GET", "/compute/v1/vcenters/virtual_machines"))
+		require.NoError(t, err)
+		require.NoError(t, requireOK(resp))
+		require.Equal(t, int32(2), atomic.LoadInt32(&stub.auths))
+	})
+
+	t.Run("a second 401 is returned, not retried", func(t *testing.T) {
+		stub := newRevokingStub(t, 2)
+		c, err := NewClient(&Config{Address: "https://shiva.example", Transport: stub})
+		require.NoError(t, err)
+		stub.revoked[stub.tokens[0]] = true
+		stub.revoked[stub.tokens[1]] = true
+
+		resp, err := c.doRequest(ctx, c.newRequest("GET", "/compute/v1/vcenters/virtual_machines"))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		require.Equal(t, int32(2), atomic.LoadInt32(&stub.auths), "the request must be replayed exactly once")
+	})
+
+	t.Run("a write rejected before any side effect is sent again with its body", func(t *testing.T) {
+		stub := newRevokingStub(t, 2)
+		c, err := NewClient(&Config{Address: "https://shiva.example", Transport: stub})
+		require.NoError(t, err)
+		stub.revoked[stub.tokens[0]] = true
+
+		r := c.newRequest("POST", "/compute/v1/vcenters/virtual_machines")
+		r.obj = map[string]string{"name": "vm"}
+		activityID, err := c.doRequestAndReturnActivity(ctx, r)
+		require.NoError(t, err)
+		require.Equal(t, "activity", activityID)
+		require.Len(t, stub.bodies, 2)
+		require.Equal(t, stub.bodies[0], stub.bodies[1], "the replayed write must carry the same body")
+		require.Contains(t, stub.bodies[1], `"name":"vm"`)
+	})
+
+	t.Run("a write that started an activity is not sent again", func(t *testing.T) {
+		stub := newRevokingStub(t, 2)
+		stub.headers.Set("Location", "activity")
+		c, err := NewClient(&Config{Address: "https://shiva.example", Transport: stub})
+		require.NoError(t, err)
+		stub.revoked[stub.tokens[0]] = true
+
+		r := c.newRequest("POST", "/compute/v1/vcenters/virtual_machines")
+		r.obj = map[string]string{"name": "vm"}
+		_, err = c.doRequestAndReturnActivity(ctx, r)
+		require.Error(t, err)
+		require.Len(t, stub.bodies, 1)
+		require.Equal(t, int32(1), atomic.LoadInt32(&stub.auths))
+	})
+
+	t.Run("requests rejected together share one re-authentication", func(t *testing.T) {
+		stub := newRevokingStub(t, 3)
+		c, err := NewClient(&Config{Address: "https://shiva.example", Transport: stub})
+		require.NoError(t, err)
+		stale, err := c.JWT(ctx)
+		require.NoError(t, err)
+
+		renewed, err := c.renewJWT(ctx, stale)
+		require.NoError(t, err)
+		again, err := c.renewJWT(ctx, stale)
+		require.NoError(t, err)
+		require.Same(t, renewed, again, "a token already renewed must be reused")
+		require.Equal(t, int32(2), atomic.LoadInt32(&stub.auths))
+	})
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// TestNetworkListStrict pins the strict listings of the VMware, Open IaaS and
+// VPC networks: a complete 200 array is the only usable answer.
+func TestNetworkListStrict(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name string
+		path string
+		list func(c *Client) ([]string, error)
+	}{
+		{"vmware", "/compute/v1/vcenters/networks", func(c *Client) ([]string, error) {
+			networks, err := c.Compute().Network().ListStrict(ctx, &NetworkFilter{})
+			ids := []string{}
+			for _, network := range networks {
+				ids = append(ids, network.ID)
+			}
+			return ids, err
+		}},
+		{"open iaas", "/compute/v1/open_iaas/networks", func(c *Client) ([]string, error) {
+			networks, err := c.Compute().OpenIaaS().Network().ListStrict(ctx, &OpenIaaSNetworkFilter{})
+			ids := []string{}
+			for _, network := range networks {
+				ids = append(ids, network.ID)
+			}
+			return ids, err
+		}},
+		{"vpc private", "/vpc/v1/private_networks", func(c *Client) ([]string, error) {
+			networks, err := c.VPC().PrivateNetwork().ListStrict(ctx, &PrivateNetworkFilter{})
+			ids := []string{}
+			for _, network := range networks {
+				ids = append(ids, network.ID)
+			}
+			return ids, err
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Run("200 returns the parsed networks", func(t *testing.T) {
+				var method, path string
+				var query url.Values
+				c := newPATTestClient(t, captureHandler(http.StatusOK, `[{"id":"net-1"},{"id":"net-2"}]`, &method, &path, &query))
+				ids, err := tc.list(c)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if len(ids) != 2 || ids[0] != "net-1" || ids[1] != "net-2" {
+					t.Fatalf("unexpected networks: %v", ids)
+				}
+				if method != http.MethodGet || path != tc.path {
+					t.Fatalf("unexpected request: %s %s", method, path)
+				}
+			})
+
+			runListStrictRejections(t, func(c *Client) error {
+				_, err := tc.list(c)
+				return err
+			})
+		})
+	}
+}
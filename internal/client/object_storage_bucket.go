@@ -29,22 +29,27 @@ type Bucket struct {
 }
 
 func (c *BucketClient) List(ctx context.Context) ([]*Bucket, error) {
-	r := c.c.newRequest("GET", "/storage/object/v1/buckets")
-	resp, err := c.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	if err := requireOK(resp); err != nil {
-		return nil, err
-	}
-
-	var out []*Bucket
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, c.c, "bucket", 0,
+		func(item *Bucket) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*Bucket, error) {
+			r := c.c.newRequest("GET", "/storage/object/v1/buckets")
+			pg.addTo(r)
+			resp, err := c.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			if err := requireOK(resp); err != nil {
+				return nil, err
+			}
+
+			var out []*Bucket
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
 }
 
 // ListStrict behaves like List but requires a complete HTTP 200 answer: 206 is
@@ -53,22 +58,27 @@ func (c *BucketClient) List(ctx context.Context) ([]*Bucket, error) {
 // the listing as state-safety evidence must fail closed on anything else
 // (#281).
 func (c *BucketClient) ListStrict(ctx context.Context) ([]*Bucket, error) {
-	r := c.c.newRequest("GET", "/storage/object/v1/buckets")
-	resp, err := c.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	if err := requireHttpCodes(resp, 200); err != nil {
-		return nil, err
-	}
-
-	var out []*Bucket
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, c.c, "bucket", 0,
+		func(item *Bucket) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*Bucket, error) {
+			r := c.c.newRequest("GET", "/storage/object/v1/buckets")
+			pg.addTo(r)
+			resp, err := c.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			if err := requireHttpCodes(resp, 200); err != nil {
+				return nil, err
+			}
+
+			var out []*Bucket
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
 }
 
 func (c *BucketClient) Read(ctx context.Context, name string) (*Bucket, error) {
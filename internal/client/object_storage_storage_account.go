@@ -24,22 +24,27 @@ type StorageAccount struct {
 }
 
 func (c *StorageAccountClient) List(ctx context.Context) ([]*StorageAccount, error) {
-	r := c.c.newRequest("GET", "/storage/object/v1/storage_accounts")
-	resp, err := c.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	if err := requireOK(resp); err != nil {
-		return nil, err
-	}
-
-	var out []*StorageAccount
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, c.c, "storage account", 0,
+		func(item *StorageAccount) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*StorageAccount, error) {
+			r := c.c.newRequest("GET", "/storage/object/v1/storage_accounts")
+			pg.addTo(r)
+			resp, err := c.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			if err := requireOK(resp); err != nil {
+				return nil, err
+			}
+
+			var out []*StorageAccount
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
 }
 
 // ListStrict behaves like List but requires a complete HTTP 200 answer: 206 is
@@ -48,22 +53,27 @@ func (c *StorageAccountClient) List(ctx context.Context) ([]*StorageAccount, err
 // the listing as state-safety evidence must fail closed on anything else
 // (#281).
 func (c *StorageAccountClient) ListStrict(ctx context.Context) ([]*StorageAccount, error) {
-	r := c.c.newRequest("GET", "/storage/object/v1/storage_accounts")
-	resp, err := c.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	if err := requireHttpCodes(resp, 200); err != nil {
-		return nil, err
-	}
-
-	var out []*StorageAccount
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, c.c, "storage account", 0,
+		func(item *StorageAccount) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*StorageAccount, error) {
+			r := c.c.newRequest("GET", "/storage/object/v1/storage_accounts")
+			pg.addTo(r)
+			resp, err := c.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			if err := requireHttpCodes(resp, 200); err != nil {
+				return nil, err
+			}
+
+			var out []*StorageAccount
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
 }
 
 func (c *StorageAccountClient) Read(ctx context.Context, name string) (*StorageAccount, error) {
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// page is the window of a paginated listing a request asks for.
+type page struct {
+	limit  int
+	offset int
+}
+
+// addTo sets the limit and offset query parameters of r.
+func (p page) addTo(r *request) {
+	r.params.Set("limit", strconv.Itoa(p.limit))
+	r.params.Set("offset", strconv.Itoa(p.offset))
+}
+
+// paginate reads a listing page by page with limit and offset, so a tenant with
+// more items than a page holds is never silently truncated. readPage sends the
+// request of one page, with the success contract of its caller (requireOK, or
+// 200 only for the strict listings used as absence evidence). maxPageSize, when
+// > 0, is the largest limit the endpoint accepts; key identifies an item. what
+// names the items in the errors.
+//
+// A short page ends the listing, and so does a page longer than the limit: the
+// endpoint does not paginate and answered the whole listing at once. An
+// endpoint that does not paginate either and holds exactly a page of items
+// answers the second page like the first one; see ignoresPagination. Otherwise
+// it fails closed rather than loop or truncate when a full page brings no new
+// item (the API is ignoring offset), and when the listing grows past
+// listMaxItems. An item without a key never counts as new.
+func paginate[T any](ctx context.Context, c *Client, what string, maxPageSize int, key func(*T) string, readPage func(ctx context.Context, p page) ([]*T, error)) ([]*T, error) {
+	size := c.listPageSize
+	if size <= 0 {
+		size = defaultListPageSize
+	}
+	if maxPageSize > 0 && size > maxPageSize {
+		size = maxPageSize
+	}
+
+	var out []*T
+	var first []*T
+	seen := make(map[string]struct{})
+	for offset := 0; ; offset += size {
+		items, err := readPage(ctx, page{limit: size, offset: offset})
+		if err != nil {
+			return nil, err
+		}
+
+		// Deduplicate across pages and count the items this page contributes: a
+		// well-behaved server never repeats an item across offsets.
+		added := 0
+		for _, item := range items {
+			if item == nil {
+				continue
+			}
+			k := key(item)
+			if k == "" {
+				out = append(out, item)
+				continue
+			}
+			if _, dup := seen[k]; dup {
+				continue
+			}
+			seen[k] = struct{}{}
+			out = append(out, item)
+			added++
+		}
+		if offset == 0 {
+			first = items
+		}
+
+		if c.listMaxItems > 0 && len(out) > c.listMaxItems {
+			return nil, fmt.Errorf("%s listing has more than %d items, the maximum set by list_max_items (%s); refusing to truncate it",
+				what, c.listMaxItems, ListMaxItemsEnvName)
+		}
+		if len(items) != size {
+			break
+		}
+		// A FULL page that contributes no new item means the server is not
+		// honouring offset (it keeps returning the same page). Fail closed rather
+		// than loop forever or return a partial listing as the complete set.
+		if added == 0 {
+			if offset == size && samePage(first, items, key) {
+				complete, err := ignoresPagination(ctx, size, readPage)
+				if err != nil {
+					return nil, err
+				}
+				if complete {
+					return out, nil
+				}
+			}
+			return nil, fmt.Errorf("%s listing made no progress at offset %d (the API may be ignoring pagination); refusing to loop or truncate", what, offset)
+		}
+	}
+	return out, nil
+}
+
+// samePage reports whether two pages hold the same items, in the same order.
+func samePage[T any](a, b []*T, key func(*T) string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] == nil || b[i] == nil {
+			if a[i] != b[i] {
+				return false
+			}
+			continue
+		}
+		if k := key(a[i]); k == "" || k != key(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ignoresPagination tells an endpoint ignoring limit and offset, whose first
+// page was the complete listing, from one honouring limit but not offset,
+// which may hold more items: it asks for one item less than a page, which
+// only the former answers in full. A page of one item cannot be told apart.
+func ignoresPagination[T any](ctx context.Context, size int, readPage func(ctx context.Context, p page) ([]*T, error)) (bool, error) {
+	if size < 2 {
+		return false, nil
+	}
+	items, err := readPage(ctx, page{limit: size - 1, offset: 0})
+	if err != nil {
+		return false, err
+	}
+	return len(items) == size, nil
+}
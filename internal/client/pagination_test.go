@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// bucketPages serves total buckets, honouring limit and offset unless
+// ignoreLimit, and records the limit and offset of each request.
+func bucketPages(total int, ignoreLimit bool, seen *[][2]int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		*seen = append(*seen, [2]int{limit, offset})
+		if ignoreLimit {
+			limit, offset = total, 0
+		}
+		page := []map[string]any{}
+		for n := offset; n < offset+limit && n < total; n++ {
+			page = append(page, map[string]any{"id": fmt.Sprintf("bucket-%d", n), "name": fmt.Sprintf("bucket%d", n)})
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}
+}
+
+func TestListPaginatesWithThePageSize(t *testing.T) {
+	var seen [][2]int
+	c := newPATTestClient(t, bucketPages(5, false, &seen))
+	c.listPageSize = 2
+
+	buckets, err := c.ObjectStorage().Bucket().ListStrict(context.Background())
+	require.NoError(t, err)
+	require.Len(t, buckets, 5)
+	require.Equal(t, "bucket-4", buckets[4].ID)
+	require.Equal(t, [][2]int{{2, 0}, {2, 2}, {2, 4}}, seen)
+}
+
+// TestListAcceptsAnEndpointThatDoesNotPaginate: a page longer than the limit is
+// the whole listing, not a reason to ask for the next page.
+func TestListAcceptsAnEndpointThatDoesNotPaginate(t *testing.T) {
+	var seen [][2]int
+	c := newPATTestClient(t, bucketPages(5, true, &seen))
+	c.listPageSize = 2
+
+	buckets, err := c.ObjectStorage().Bucket().List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, buckets, 5)
+	require.Len(t, seen, 1)
+}
+
+func TestListFailsPastTheMaxItems(t *testing.T) {
+	var seen [][2]int
+	c := newPATTestClient(t, bucketPages(5, false, &seen))
+	c.listPageSize = 2
+	c.listMaxItems = 3
+
+	buckets, err := c.ObjectStorage().Bucket().List(context.Background())
+	require.ErrorContains(t, err, "more than 3 items")
+	require.Nil(t, buckets, "a listing past the cap must not be returned truncated")
+	require.Len(t, seen, 2, "the listing must stop at the first page past the cap")
+}
+
+func TestListFailsWhenAPageBringsNothingNew(t *testing.T) {
+	var seen [][2]int
+	pages := bucketPages(2, false, &seen)
+	c := newPATTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// Ignore offset: always answer the first page.
+		q := r.URL.Query()
+		q.Set("offset", "0")
+		r.URL.RawQuery = q.Encode()
+		pages(w, r)
+	})
+	c.listPageSize = 2
+
+	_, err := c.ObjectStorage().Bucket().List(context.Background())
+	require.ErrorContains(t, err, "made no progress at offset 2")
+	require.Equal(t, [][2]int{{2, 0}, {2, 0}, {1, 0}}, seen, "honouring limit, the endpoint may hold more items than a page")
+}
+
+// TestListAcceptsAnEndpointThatDoesNotPaginateWithAPageOfItems: an endpoint
+// ignoring limit and offset answers the second page like the first one when it
+// holds exactly a page of items, which is then the complete listing.
+func TestListAcceptsAnEndpointThatDoesNotPaginateWithAPageOfItems(t *testing.T) {
+	var seen [][2]int
+	c := newPATTestClient(t, bucketPages(2, true, &seen))
+	c.listPageSize = 2
+
+	buckets, err := c.ObjectStorage().Bucket().ListStrict(context.Background())
+	require.NoError(t, err)
+	require.Len(t, buckets, 2)
+	require.Equal(t, [][2]int{{2, 0}, {2, 2}, {1, 0}}, seen)
+}
+
+// TestListFailsWhenKeylessItemsRepeat: an item without a key is never new, so
+// a server ignoring offset cannot loop until list_max_items.
+func TestListFailsWhenKeylessItemsRepeat(t *testing.T) {
+	var requests int
+	c := newPATTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		page := make([]map[string]any, limit)
+		for n := range page {
+			page[n] = map[string]any{"name": fmt.Sprintf("bucket%d", n)}
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	})
+	c.listPageSize = 2
+
+	_, err := c.ObjectStorage().Bucket().List(context.Background())
+	require.ErrorContains(t, err, "made no progress at offset 0")
+	require.Equal(t, 1, requests)
+}
+
+func TestPaginateCapsThePageSizeOfTheEndpoint(t *testing.T) {
+	c, err := NewClient(&Config{Address: "example.test", ListPageSize: 500})
+	require.NoError(t, err)
+
+	var limits []int
+	_, err = paginate(context.Background(), c, "item", 200,
+		func(s *string) string { return *s },
+		func(ctx context.Context, pg page) ([]*string, error) {
+			limits = append(limits, pg.limit)
+			return nil, nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, []int{200}, limits)
+}
+
+func TestDefaultConfigReadsListEnv(t *testing.T) {
+	t.Run("unset keeps the defaults", func(t *testing.T) {
+		t.Setenv(ListPageSizeEnvName, "")
+		t.Setenv(ListMaxItemsEnvName, "")
+		config := defaultConfig(t)
+		require.Equal(t, defaultListPageSize, config.ListPageSize)
+		require.Equal(t, defaultListMaxItems, config.ListMaxItems)
+	})
+	t.Run("valid values honored", func(t *testing.T) {
+		t.Setenv(ListPageSizeEnvName, "50")
+		t.Setenv(ListMaxItemsEnvName, "1000")
+		config := defaultConfig(t)
+		require.Equal(t, 50, config.ListPageSize)
+		require.Equal(t, 1000, config.ListMaxItems)
+	})
+	t.Run("invalid values keep the defaults (fail-safe)", func(t *testing.T) {
+		t.Setenv(ListPageSizeEnvName, "0")
+		t.Setenv(ListMaxItemsEnvName, "many")
+		config := defaultConfig(t)
+		require.Equal(t, defaultListPageSize, config.ListPageSize)
+		require.Equal(t, defaultListMaxItems, config.ListMaxItems)
+	})
+}
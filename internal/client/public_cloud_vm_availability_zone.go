@@ -41,6 +41,7 @@ type PublicCloudVMAvailabilityZoneFilter struct {
 // filtered by region.
 func (a *PublicCloudVMAvailabilityZoneClient) List(ctx context.Context, filter *PublicCloudVMAvailabilityZoneFilter) ([]*PublicCloudVMAvailabilityZone, error) {
 	req := a.c.newRequest("GET", "/vm_instances/v1/availability_zones")
+	req.reference = true
 	req.addFilter(filter)
 	resp, err := a.c.doRequest(ctx, req)
 	if err != nil {
@@ -63,6 +64,7 @@ func (a *PublicCloudVMAvailabilityZoneClient) List(ctx context.Context, filter *
 // (nil, nil); any other non-OK code (403, 5xx) fails closed with an error.
 func (a *PublicCloudVMAvailabilityZoneClient) Read(ctx context.Context, id string) (*PublicCloudVMAvailabilityZone, error) {
 	req := a.c.newRequest("GET", "/vm_instances/v1/availability_zones/%s", id)
+	req.reference = true
 	resp, err := a.c.doRequest(ctx, req)
 	if err != nil {
 		return nil, err
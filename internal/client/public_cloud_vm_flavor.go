@@ -38,6 +38,7 @@ type publicCloudVMFlavorListResponse struct {
 // List returns the flavors of the tenant, optionally filtered by instance family.
 func (f *PublicCloudVMFlavorClient) List(ctx context.Context, filter *PublicCloudVMFlavorFilter) ([]*PublicCloudVMFlavor, error) {
 	req := f.c.newRequest("GET", "/vm_instances/v1/flavors")
+	req.reference = true
 	req.addFilter(filter)
 	resp, err := f.c.doRequest(ctx, req)
 	if err != nil {
@@ -1,10 +1,6 @@
 package client
 
-import (
-	"context"
-	"fmt"
-	"strconv"
-)
+import "context"
 
 type PublicCloudVMInstanceClient struct {
 	c *Client
@@ -23,7 +19,7 @@ func (v *PublicCloudVMClient) Instance() *PublicCloudVMInstanceClient {
 // publicCloudVMInstanceListPageSize is the API's maximum page size for
 // GET /vm_instances/v1/virtual_machines (limit max = 200). List/ListStrict page
 // through with limit+offset so a tenant with more than one page of VMs is never
-// silently truncated (E0-6 / #414); the provider has no other pagination.
+// silently truncated (E0-6 / #414); a larger list_page_size is capped to it.
 const publicCloudVMInstanceListPageSize = 200
 
 // PublicCloudVMInstanceRef is the {id, name} shape used for the resolved
@@ -85,50 +81,16 @@ func (i *PublicCloudVMInstanceClient) ListStrict(ctx context.Context, filter *Pu
 }
 
 func (i *PublicCloudVMInstanceClient) list(ctx context.Context, filter *PublicCloudVMInstanceFilter, strict bool) ([]*PublicCloudVMInstance, error) {
-	var out []*PublicCloudVMInstance
-	seen := make(map[string]struct{})
-	for offset := 0; ; offset += publicCloudVMInstanceListPageSize {
-		req := i.c.newRequest("GET", "/vm_instances/v1/virtual_machines")
-		if filter != nil {
-			req.addFilter(filter)
-		}
-		req.params.Add("limit", strconv.Itoa(publicCloudVMInstanceListPageSize))
-		req.params.Add("offset", strconv.Itoa(offset))
-
-		page, err := i.readPage(ctx, req, strict)
-		if err != nil {
-			return nil, err
-		}
-
-		// Deduplicate across pages and count the ids this page contributes: a
-		// well-behaved server never repeats an id across offsets.
-		newInPage := 0
-		for _, vm := range page {
-			if vm == nil {
-				continue
-			}
-			if _, dup := seen[vm.ID]; dup {
-				continue
+	return paginate(ctx, i.c, "virtual machine", publicCloudVMInstanceListPageSize,
+		func(vm *PublicCloudVMInstance) string { return vm.ID },
+		func(ctx context.Context, pg page) ([]*PublicCloudVMInstance, error) {
+			req := i.c.newRequest("GET", "/vm_instances/v1/virtual_machines")
+			if filter != nil {
+				req.addFilter(filter)
 			}
-			seen[vm.ID] = struct{}{}
-			out = append(out, vm)
-			newInPage++
-		}
-
-		// A short (or empty) page means the collection is exhausted.
-		if len(page) < publicCloudVMInstanceListPageSize {
-			break
-		}
-		// A FULL page that contributes no new id means the server is not honouring
-		// `offset` (it keeps returning the same page). Fail closed rather than loop
-		// forever (unbounded memory / spin) or silently truncate — a partial
-		// listing must never be mistaken for the complete set (E0-6 anti-truncation;
-		// E0-9 relies on a complete listing as absence evidence).
-		if newInPage == 0 {
-			return nil, fmt.Errorf("virtual machine listing made no progress at offset %d (the API may be ignoring pagination); refusing to loop or truncate", offset)
-		}
-	}
-	return out, nil
+			pg.addTo(req)
+			return i.readPage(ctx, req, strict)
+		})
 }
 
 // publicCloudVMInstanceListResponse is the wrapped shape of the list endpoint,
@@ -27,6 +27,7 @@ type PublicCloudVMInstanceFamily struct {
 // List returns the instance families of the tenant (bare JSON array, no filter).
 func (f *PublicCloudVMInstanceFamilyClient) List(ctx context.Context) ([]*PublicCloudVMInstanceFamily, error) {
 	req := f.c.newRequest("GET", "/vm_instances/v1/instance_families")
+	req.reference = true
 	resp, err := f.c.doRequest(ctx, req)
 	if err != nil {
 		return nil, err
@@ -48,6 +49,7 @@ func (f *PublicCloudVMInstanceFamilyClient) List(ctx context.Context) ([]*Public
 // any other non-OK code (403, 5xx) fails closed with an error.
 func (f *PublicCloudVMInstanceFamilyClient) Read(ctx context.Context, id string) (*PublicCloudVMInstanceFamily, error) {
 	req := f.c.newRequest("GET", "/vm_instances/v1/instance_families/%s", id)
+	req.reference = true
 	resp, err := f.c.doRequest(ctx, req)
 	if err != nil {
 		return nil, err
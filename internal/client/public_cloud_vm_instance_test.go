@@ -120,18 +120,20 @@ func TestPublicCloudVMInstanceListPaginates(t *testing.T) {
 }
 
 // TestPublicCloudVMInstanceListRefusesRunawayPagination is the E0-6 fail-closed
-// guard: if the server ignores `offset` and keeps returning the same full page,
-// the client must ERROR after a bounded number of requests — never loop forever
-// (unbounded memory / spin) and never silently truncate. Deleting the progress
-// guard turns this RED with an infinite loop.
+// guard: if the server honours `limit` but ignores `offset` and keeps returning
+// the same full page, the client must ERROR after a bounded number of requests —
+// never loop forever (unbounded memory / spin) and never silently truncate.
+// Deleting the progress guard turns this RED with an infinite loop. (A server
+// ignoring `limit` too answers its complete listing; see pagination_test.go.)
 func TestPublicCloudVMInstanceListRefusesRunawayPagination(t *testing.T) {
 	ctx := context.Background()
 	calls := 0
 	c := newPATTestClient(t, func(w http.ResponseWriter, r *http.Request) {
 		calls++
-		// Ignore offset entirely: always return the SAME full page of distinct ids.
-		page := make([]map[string]any, publicCloudVMInstanceListPageSize)
-		for n := 0; n < publicCloudVMInstanceListPageSize; n++ {
+		// Ignore offset entirely: always return the SAME first `limit` ids.
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		page := make([]map[string]any, limit)
+		for n := 0; n < limit; n++ {
 			page[n] = map[string]any{"id": fmt.Sprintf("vm-%d", n)}
 		}
 		w.WriteHeader(http.StatusOK)
@@ -142,8 +144,8 @@ func TestPublicCloudVMInstanceListRefusesRunawayPagination(t *testing.T) {
 	if err == nil {
 		t.Fatal("a server that ignores offset (repeats a full page) must fail closed, not loop or truncate")
 	}
-	if calls != 2 {
-		t.Fatalf("runaway must be caught after exactly 2 pages (first full page of new ids, second all-duplicate), got %d calls", calls)
+	if calls != 3 {
+		t.Fatalf("runaway must be caught after exactly 3 requests (first full page of new ids, second all-duplicate, a shorter page honouring limit), got %d calls", calls)
 	}
 }
 
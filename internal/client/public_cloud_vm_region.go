@@ -31,6 +31,7 @@ type PublicCloudVMRegion struct {
 // filter or pagination on this endpoint).
 func (r *PublicCloudVMRegionClient) List(ctx context.Context) ([]*PublicCloudVMRegion, error) {
 	req := r.c.newRequest("GET", "/vm_instances/v1/regions")
+	req.reference = true
 	resp, err := r.c.doRequest(ctx, req)
 	if err != nil {
 		return nil, err
@@ -54,6 +55,7 @@ func (r *PublicCloudVMRegionClient) List(ctx context.Context) ([]*PublicCloudVMR
 // absence).
 func (r *PublicCloudVMRegionClient) Read(ctx context.Context, id string) (*PublicCloudVMRegion, error) {
 	req := r.c.newRequest("GET", "/vm_instances/v1/regions/%s", id)
+	req.reference = true
 	resp, err := r.c.doRequest(ctx, req)
 	if err != nil {
 		return nil, err
@@ -32,6 +32,7 @@ type publicCloudVMStorageTypeListResponse struct {
 // server-side filter).
 func (s *PublicCloudVMStorageTypeClient) List(ctx context.Context) ([]*PublicCloudVMStorageType, error) {
 	req := s.c.newRequest("GET", "/vm_instances/v1/storage_types")
+	req.reference = true
 	resp, err := s.c.doRequest(ctx, req)
 	if err != nil {
 		return nil, err
@@ -43,6 +43,7 @@ type PublicCloudVMTemplateFilter struct {
 // by instance family and/or availability zone.
 func (t *PublicCloudVMTemplateClient) List(ctx context.Context, filter *PublicCloudVMTemplateFilter) ([]*PublicCloudVMTemplate, error) {
 	req := t.c.newRequest("GET", "/vm_instances/v1/templates")
+	req.reference = true
 	req.addFilter(filter)
 	resp, err := t.c.doRequest(ctx, req)
 	if err != nil {
@@ -65,6 +66,7 @@ func (t *PublicCloudVMTemplateClient) List(ctx context.Context, filter *PublicCl
 // other non-OK code (403, 5xx) fails closed with an error.
 func (t *PublicCloudVMTemplateClient) Read(ctx context.Context, id string) (*PublicCloudVMTemplate, error) {
 	req := t.c.newRequest("GET", "/vm_instances/v1/templates/%s", id)
+	req.reference = true
 	resp, err := t.c.doRequest(ctx, req)
 	if err != nil {
 		return nil, err
@@ -53,7 +53,7 @@ func (c *TagResourceClient) Read(ctx context.Context, resourceId string) ([]*Tag
 }
 
 func (c *TagResourceClient) Delete(ctx context.Context, resourceId string, key string) error {
-	r := c.c.newRequest("DELETE", "/tag/v1/tags/resources/%s/keys/%s", resourceId, key)
+	r := c.c.newEscapedRequest("DELETE", "/tag/v1/tags/resources/%s/keys/%s", resourceId, key)
 	resp, err := c.c.doRequest(ctx, r)
 	if err != nil {
 		return err
@@ -44,7 +44,11 @@ func TestMain(m *testing.M) {
 		os.Exit(1)
 	}
 
-	config := clientpkg.DefaultConfig()
+	config, err := clientpkg.DefaultConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+		os.Exit(1)
+	}
 	config.ClientID = os.Getenv(testClientIDEnvName)
 	config.SecretID = os.Getenv(testSecretIDEnvName)
 
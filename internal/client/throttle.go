@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// maxRetryAfter bounds the wait a Retry-After header can ask for, so a broken
+// gateway cannot stall an apply for hours.
+const maxRetryAfter = time.Minute
+
+// throttle bounds the load the client puts on the API: a token bucket limits
+// the request rate, a semaphore the number of requests in flight, and a 429 or
+// 503 carrying a Retry-After pauses every request of the client, not only the
+// one that got it. A zero throttle lets everything through.
+type throttle struct {
+	limiter  *rate.Limiter
+	inFlight chan struct{}
+
+	lock        sync.Mutex
+	pausedUntil time.Time
+}
+
+func newThrottle(config *Config) *throttle {
+	t := &throttle{}
+	if config.RateLimit > 0 {
+		burst := config.RateBurst
+		if burst < 1 {
+			burst = 1
+		}
+		t.limiter = rate.NewLimiter(rate.Limit(config.RateLimit), burst)
+	}
+	if config.MaxInFlight > 0 {
+		t.inFlight = make(chan struct{}, config.MaxInFlight)
+	}
+	return t
+}
+
+// acquire waits for the client to be allowed to send a request. The returned
+// function releases the in-flight slot; it must be called once the response
+// headers are received, or the request failed.
+func (t *throttle) acquire(ctx context.Context) (func(), error) {
+	if t == nil {
+		return func() {}, nil
+	}
+
+	if err := t.waitPause(ctx); err != nil {
+		return nil, err
+	}
+
+	release := func() {}
+	if t.inFlight != nil {
+		select {
+		case t.inFlight <- struct{}{}:
+			release = func() { <-t.inFlight }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
+
+// pause holds every request until d has elapsed. A shorter pause than the one
+// in progress is ignored.
+func (t *throttle) pause(d time.Duration) {
+	if t == nil || d <= 0 {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if until := time.Now().Add(d); until.After(t.pausedUntil) {
+		t.pausedUntil = until
+	}
+}
+
+func (t *throttle) waitPause(ctx context.Context) error {
+	t.lock.Lock()
+	wait := time.Until(t.pausedUntil)
+	t.lock.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// newThrottledTestClient builds a client with the throttle of config against
+// an httptest server, with a pre-seeded token.
+func newThrottledTestClient(t *testing.T, config *Config, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	config.Address = srv.URL
+	c, err := NewClient(config)
+	require.NoError(t, err)
+	c.SavedToken = &jwt.Token{Claims: jwt.MapClaims{"exp": float64(time.Now().Add(time.Hour).Unix())}}
+	return c
+}
+
+func TestThrottleCapsRequestsInFlight(t *testing.T) {
+	var current, peak int32
+	c := newThrottledTestClient(t, &Config{MaxInFlight: 2}, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		defer atomic.AddInt32(&current, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := c.doRequest(context.Background(), c.newRequest("GET", "/ping"))
+			if err == nil {
+				closeResponseBody(resp)
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(2), atomic.LoadInt32(&peak))
+}
+
+func TestThrottleLimitsTheRate(t *testing.T) {
+	c := newThrottledTestClient(t, &Config{RateLimit: 50, RateBurst: 1}, func(w http.ResponseWriter, r *http.Request) {})
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		resp, err := c.doRequest(context.Background(), c.newRequest("GET", "/ping"))
+		require.NoError(t, err)
+		closeResponseBody(resp)
+	}
+	// The first request uses the burst, the 5 others wait 20ms each.
+	require.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+}
+
+func TestThrottlePauseHoldsEveryRequest(t *testing.T) {
+	th := newThrottle(&Config{})
+	th.pause(80 * time.Millisecond)
+	th.pause(time.Millisecond) // a shorter pause does not cut the current one
+
+	start := time.Now()
+	release, err := th.acquire(context.Background())
+	require.NoError(t, err)
+	release()
+	require.GreaterOrEqual(t, time.Since(start), 70*time.Millisecond)
+
+	th.pause(time.Hour)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = th.acquire(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// TestDoWithRetryHonorsRetryAfter: a 429 waits the Retry-After asked for, and
+// pauses the other requests of the client meanwhile.
+func TestDoWithRetryHonorsRetryAfter(t *testing.T) {
+	var calls int32
+	c := newThrottledTestClient(t, &Config{ReadRetryMax: 2}, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	})
+	c.readRetryBackoffBase = 0
+
+	start := time.Now()
+	resp, err := c.doRequest(context.Background(), c.newRequest("GET", "/ping"))
+	require.NoError(t, err)
+	closeResponseBody(resp)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	require.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+
+	c.throttle.lock.Lock()
+	paused := c.throttle.pausedUntil
+	c.throttle.lock.Unlock()
+	require.False(t, paused.IsZero(), "the 429 must pause the other requests")
+}
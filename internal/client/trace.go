@@ -0,0 +1,134 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxTracedBody bounds the part of a body an HTTPTrace carries.
+const maxTracedBody = 16 << 10
+
+const redacted = "<redacted>"
+
+// HTTPTrace describes one request sent to the API and its outcome. The
+// secrets it carries are redacted before it reaches the tracer.
+type HTTPTrace struct {
+	Method string
+	Path   string
+	Query  string
+
+	// Status is 0 when the request failed without a response, Err is then set.
+	Status  int
+	Latency time.Duration
+	// Location is the activity started by the request, if any.
+	Location string
+
+	RequestBody  string
+	ResponseBody string
+
+	Err error
+}
+
+// HTTPTracer receives the trace of every request sent to the API. It is
+// called from the goroutine that sent the request, possibly concurrently.
+type HTTPTracer func(ctx context.Context, trace *HTTPTrace)
+
+var (
+	// sensitiveJSONValue matches the value of a secret-bearing JSON key. The
+	// closing quote anchors each key, so "secret" never partially matches
+	// "secretAccessKey"; the value matcher is JSON-string aware, so a value
+	// holding an escaped quote is redacted in full.
+	sensitiveJSONValue = regexp.MustCompile(`(?i)("(?:secretAccessKey|accessSecretKey|secretKey|secret|password|domainAdminPassword|accessKey|accessKeyId|accessToken|refreshToken|token)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+	// bearerToken matches an Authorization header value and a bare JWT, such as
+	// the body of the personal access token login.
+	bearerToken = regexp.MustCompile(`(?i)bearer\s+[^\s"]+|eyJ[\w-]*\.[\w-]+\.[\w-]*`)
+
+	// sensitiveQueryKey matches the query parameters whose value is redacted.
+	sensitiveQueryKey = regexp.MustCompile(`(?i)secret|password|token|access_?key`)
+)
+
+// RedactHTTPBody redacts the secrets of a logged body: the values of the
+// secret-bearing JSON keys, bearer tokens and JWTs.
+func RedactHTTPBody(body string) string {
+	body = sensitiveJSONValue.ReplaceAllString(body, `$1"`+redacted+`"`)
+	return bearerToken.ReplaceAllString(body, redacted)
+}
+
+// RedactHTTPQuery encodes query with the value of the secret-bearing parameters
+// redacted.
+func RedactHTTPQuery(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+	clean := url.Values{}
+	for key, values := range query {
+		for _, v := range values {
+			if sensitiveQueryKey.MatchString(key) {
+				v = redacted
+			}
+			clean.Add(key, v)
+		}
+	}
+	return clean.Encode()
+}
+
+// doTraced sends req and reports it to the tracer of the client. The response
+// body is read up to maxTracedBody and handed back intact to the caller.
+func (c *Client) doTraced(ctx context.Context, req *http.Request) (*http.Response, error) {
+	trace := &HTTPTrace{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  RedactHTTPQuery(req.URL.Query()),
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			trace.RequestBody = readTracedBody(body)
+			body.Close()
+		}
+	}
+
+	start := time.Now()
+	resp, err := c.config.HttpClient.Do(req)
+	trace.Latency = time.Since(start)
+	trace.Err = err
+	if resp != nil {
+		trace.Status = resp.StatusCode
+		trace.Location = resp.Header.Get("Location")
+		if resp.Body != nil {
+			head, _ := io.ReadAll(io.LimitReader(resp.Body, maxTracedBody+1))
+			trace.ResponseBody = tracedBody(head)
+			resp.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(head), resp.Body), resp.Body}
+		}
+	}
+
+	c.config.Tracer(ctx, trace)
+	return resp, err
+}
+
+func readTracedBody(r io.Reader) string {
+	head, _ := io.ReadAll(io.LimitReader(r, maxTracedBody+1))
+	return tracedBody(head)
+}
+
+func tracedBody(head []byte) string {
+	body := string(head)
+	truncated := len(head) > maxTracedBody
+	if truncated {
+		body = body[:maxTracedBody]
+	}
+	body = RedactHTTPBody(strings.TrimSpace(body))
+	if truncated {
+		body += "…(truncated)"
+	}
+	return body
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// selfSignedPEM returns a PEM-encoded self-signed certificate and its key.
+func selfSignedPEM(t *testing.T) (certPEM, keyPEM string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "ct-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func serverCAPEM(srv *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+}
+
+func getThrough(t *testing.T, config *Config, url string) (*http.Response, error) {
+	t.Helper()
+	c, err := NewClient(config)
+	require.NoError(t, err)
+	resp, err := c.config.HttpClient.Get(url)
+	if err == nil {
+		t.Cleanup(func() { resp.Body.Close() })
+	}
+	return resp, err
+}
+
+func TestConfigureTransportProxy(t *testing.T) {
+	var proxied, auth string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		auth = r.Header.Get("Proxy-Authorization")
+	}))
+	t.Cleanup(proxy.Close)
+
+	resp, err := getThrough(t, &Config{Address: "api.example.test", ProxyURL: "http://user:pass@" + proxy.Listener.Addr().String()}, "http://api.example.test/ping")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "http://api.example.test/ping", proxied)
+	require.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("user:pass")), auth)
+}
+
+func TestConfigureTransportTLS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(srv.Close)
+
+	_, err := getThrough(t, &Config{Address: "example.test"}, srv.URL)
+	require.Error(t, err, "the test server's certificate must not be trusted by default")
+
+	_, err = getThrough(t, &Config{Address: "example.test", CACertPEM: serverCAPEM(srv)}, srv.URL)
+	require.NoError(t, err)
+
+	_, err = getThrough(t, &Config{Address: "example.test", InsecureSkipVerify: true}, srv.URL)
+	require.NoError(t, err)
+}
+
+func TestConfigureTransportClientCertificate(t *testing.T) {
+	var presented int
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presented = len(r.TLS.PeerCertificates)
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	certPEM, keyPEM := selfSignedPEM(t)
+	_, err := getThrough(t, &Config{Address: "example.test", CACertPEM: serverCAPEM(srv), ClientCertPEM: certPEM, ClientKeyPEM: keyPEM}, srv.URL)
+	require.NoError(t, err)
+	require.Equal(t, 1, presented)
+}
+
+func TestConfigureTransportRejectsInvalidOptions(t *testing.T) {
+	certPEM, _ := selfSignedPEM(t)
+	for name, config := range map[string]*Config{
+		"proxy scheme":      {ProxyURL: "ftp://proxy:21"},
+		"proxy host":        {ProxyURL: "http://"},
+		"CA without PEM":    {CACertPEM: "not a certificate"},
+		"missing CA file":   {CACertFile: "/nonexistent/ca.pem"},
+		"certificate only":  {ClientCertPEM: certPEM},
+		"wrapped transport": {ProxyURL: "http://proxy:3128", Transport: roundTripperFunc(nil)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config.Address = "example.test"
+			_, err := NewClient(config)
+			require.Error(t, err)
+		})
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
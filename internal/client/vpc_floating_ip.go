@@ -49,23 +49,28 @@ type FloatingIPFilter struct {
 
 // List retrieves floating IPs, optionally filtered by VPC ID.
 func (f *VPCFloatingIPClient) List(ctx context.Context, filter *FloatingIPFilter) ([]*FloatingIP, error) {
-	r := f.c.newRequest("GET", "/vpc/v1/floating_ips")
-	r.addFilter(filter)
-	resp, err := f.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	if err := requireOK(resp); err != nil {
-		return nil, err
-	}
-
-	var out []*FloatingIP
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
-
-	return out, nil
+	return paginate(ctx, f.c, "floating IP", 0,
+		func(item *FloatingIP) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*FloatingIP, error) {
+			r := f.c.newRequest("GET", "/vpc/v1/floating_ips")
+			r.addFilter(filter)
+			pg.addTo(r)
+			resp, err := f.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			if err := requireOK(resp); err != nil {
+				return nil, err
+			}
+
+			var out []*FloatingIP
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		})
 }
 
 // Read retrieves a single floating IP by ID, for DATASOURCE use. It returns
@@ -249,36 +254,41 @@ func (f *VPCFloatingIPClient) ResolveBinding(ctx context.Context, fipID, staticI
 // No vpcId filter is applied: the corroboration must be able to observe the
 // target floating IP wherever it lives.
 func (f *VPCFloatingIPClient) ListStrict(ctx context.Context) ([]*FloatingIP, error) {
-	r := f.c.newRequest("GET", "/vpc/v1/floating_ips")
-	resp, err := f.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	if err := requireHttpCodes(resp, 200); err != nil {
-		return nil, err
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-	trimmed := bytes.TrimSpace(body)
-	if len(trimmed) == 0 || trimmed[0] != '[' {
-		return nil, fmt.Errorf("strict floating IP listing returned a 200 that is not a JSON array, so it cannot prove the binding state: %.64s", string(trimmed))
-	}
-
-	var out []*FloatingIP
-	if err := json.Unmarshal(trimmed, &out); err != nil {
-		return nil, err
-	}
-	for i, fip := range out {
-		if fip == nil || fip.ID == "" {
-			return nil, fmt.Errorf("strict floating IP listing has an entry (index %d) without an id; refusing to use a structurally incomplete listing as corroboration", i)
-		}
-	}
-
-	return out, nil
+	return paginate(ctx, f.c, "floating IP", 0,
+		func(item *FloatingIP) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*FloatingIP, error) {
+			r := f.c.newRequest("GET", "/vpc/v1/floating_ips")
+			pg.addTo(r)
+			resp, err := f.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			if err := requireHttpCodes(resp, 200); err != nil {
+				return nil, err
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			trimmed := bytes.TrimSpace(body)
+			if len(trimmed) == 0 || trimmed[0] != '[' {
+				return nil, fmt.Errorf("strict floating IP listing returned a 200 that is not a JSON array, so it cannot prove the binding state: %.64s", string(trimmed))
+			}
+
+			var out []*FloatingIP
+			if err := json.Unmarshal(trimmed, &out); err != nil {
+				return nil, err
+			}
+			for i, fip := range out {
+				if fip == nil || fip.ID == "" {
+					return nil, fmt.Errorf("strict floating IP listing has an entry (index %d) without an id; refusing to use a structurally incomplete listing as corroboration", i)
+				}
+			}
+
+			return out, nil
+		})
 }
 
 // Bind associates a PRE-EXISTING floating IP with a static IP (asynchronous).
@@ -376,7 +386,7 @@ func (f *VPCFloatingIPClient) ProvisionStart(ctx context.Context) (activityID, s
 	if err != nil {
 		return "", "", err
 	}
-	resp, err := f.c.doRequestWithToken(ctx, r, token.Raw)
+	resp, err := f.c.doRequestWithJWT(ctx, r, token)
 	if err != nil {
 		return "", "", err
 	}
@@ -470,7 +480,7 @@ func (f *VPCFloatingIPClient) UpdateDescription(ctx context.Context, fipID, desc
 	if err != nil {
 		return "", err
 	}
-	resp, err := f.c.doRequestWithToken(ctx, r, token.Raw)
+	resp, err := f.c.doRequestWithJWT(ctx, r, token)
 	if err != nil {
 		return "", err
 	}
@@ -511,7 +521,7 @@ func (f *VPCFloatingIPClient) deprovisionRaw(ctx context.Context, fipID string)
 	if err != nil {
 		return "", false, err
 	}
-	resp, err := f.c.doRequestWithToken(ctx, r, token.Raw)
+	resp, err := f.c.doRequestWithJWT(ctx, r, token)
 	if err != nil {
 		return "", false, err
 	}
@@ -36,7 +36,10 @@ func TestVPCLiveContractProbe(t *testing.T) {
 		t.Skip("opt-in live probe; set CT_VPC_LIVE_PROBE=1 (read-only) and CT_VPC_LIVE_PROBE_WRITE=1 (controlled write)")
 	}
 
-	cfg := DefaultConfig()
+	cfg, err := DefaultConfig()
+	if err != nil {
+		t.Fatalf("DefaultConfig: %v", err)
+	}
 	if cfg.Address != probeHost {
 		t.Fatalf("refusing to run: CLOUDTEMPLE_HTTP_ADDR=%q but this probe is authorised ONLY for %q", cfg.Address, probeHost)
 	}
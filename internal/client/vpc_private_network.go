@@ -48,6 +48,29 @@ func (p *VPCPrivateNetworkClient) List(ctx context.Context, filter *PrivateNetwo
 	return out, nil
 }
 
+// ListStrict behaves like List but requires a complete HTTP 200 answer: 206 is
+// a partial listing, and any other code (including 403) is an error rather
+// than an answer (#281).
+func (p *VPCPrivateNetworkClient) ListStrict(ctx context.Context, filter *PrivateNetworkFilter) ([]*PrivateNetwork, error) {
+	r := p.c.newRequest("GET", "/vpc/v1/private_networks")
+	r.addFilter(filter)
+	resp, err := p.c.doRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireHttpCodes(resp, 200); err != nil {
+		return nil, err
+	}
+
+	var out []*PrivateNetwork
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 // Read retrieves a single private network by ID. It returns (nil, nil) when the
 // private network does not exist (404; since #384 the VPC API returns 404 for an absent resource, 403 only for access denied).
 func (p *VPCPrivateNetworkClient) Read(ctx context.Context, id string) (*PrivateNetwork, error) {
@@ -50,23 +50,28 @@ type StaticIPFilter struct {
 // List retrieves the static IP mappings of a private network, optionally
 // filtered by virtual machine ID.
 func (s *VPCStaticIPClient) List(ctx context.Context, privateNetworkID string, filter *StaticIPFilter) ([]*StaticIP, error) {
-	r := s.c.newRequest("GET", "/vpc/v1/private_networks/%s/static_ips", privateNetworkID)
-	r.addFilter(filter)
-	resp, err := s.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	if err := requireOK(resp); err != nil {
-		return nil, err
-	}
+	return paginate(ctx, s.c, "static IP", 0,
+		func(item *StaticIP) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*StaticIP, error) {
+			r := s.c.newRequest("GET", "/vpc/v1/private_networks/%s/static_ips", privateNetworkID)
+			r.addFilter(filter)
+			pg.addTo(r)
+			resp, err := s.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			if err := requireOK(resp); err != nil {
+				return nil, err
+			}
 
-	var out []*StaticIP
-	if err := decodeBody(resp, &out); err != nil {
-		return nil, err
-	}
+			var out []*StaticIP
+			if err := decodeBody(resp, &out); err != nil {
+				return nil, err
+			}
 
-	return out, nil
+			return out, nil
+		})
 }
 
 // ListStrict retrieves the static IP mappings of a private network and accepts
@@ -89,40 +94,45 @@ func (s *VPCStaticIPClient) List(ctx context.Context, privateNetworkID string, f
 // No VirtualMachineID filter is applied: the confirmation must see every static
 // IP on the network, not a VM-scoped subset.
 func (s *VPCStaticIPClient) ListStrict(ctx context.Context, privateNetworkID string) ([]*StaticIP, error) {
-	r := s.c.newRequest("GET", "/vpc/v1/private_networks/%s/static_ips", privateNetworkID)
-	resp, err := s.c.doRequest(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-	defer closeResponseBody(resp)
-	if err := requireHttpCodes(resp, 200); err != nil {
-		return nil, err
-	}
+	return paginate(ctx, s.c, "static IP", 0,
+		func(item *StaticIP) string { return item.ID },
+		func(ctx context.Context, pg page) ([]*StaticIP, error) {
+			r := s.c.newRequest("GET", "/vpc/v1/private_networks/%s/static_ips", privateNetworkID)
+			pg.addTo(r)
+			resp, err := s.c.doRequest(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			defer closeResponseBody(resp)
+			if err := requireHttpCodes(resp, 200); err != nil {
+				return nil, err
+			}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-	// The body must be a real JSON array. Reject "null"/empty/object outright:
-	// they are not a provable listing and must not be read as an empty network.
-	trimmed := bytes.TrimSpace(body)
-	if len(trimmed) == 0 || trimmed[0] != '[' {
-		return nil, fmt.Errorf("strict static IP listing of private network %s returned a 200 that is not a JSON array, so it cannot prove completeness: %.64s", privateNetworkID, string(trimmed))
-	}
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			// The body must be a real JSON array. Reject "null"/empty/object outright:
+			// they are not a provable listing and must not be read as an empty network.
+			trimmed := bytes.TrimSpace(body)
+			if len(trimmed) == 0 || trimmed[0] != '[' {
+				return nil, fmt.Errorf("strict static IP listing of private network %s returned a 200 that is not a JSON array, so it cannot prove completeness: %.64s", privateNetworkID, string(trimmed))
+			}
 
-	var out []*StaticIP
-	if err := json.Unmarshal(trimmed, &out); err != nil {
-		return nil, err
-	}
-	// A complete listing has a usable id for every entry. A missing id makes the
-	// snapshot structurally incomplete, so it cannot prove presence/absence.
-	for i, si := range out {
-		if si == nil || si.ID == "" {
-			return nil, fmt.Errorf("strict static IP listing of private network %s has an entry (index %d) without an id; refusing to use a structurally incomplete listing as deletion evidence", privateNetworkID, i)
-		}
-	}
+			var out []*StaticIP
+			if err := json.Unmarshal(trimmed, &out); err != nil {
+				return nil, err
+			}
+			// A complete listing has a usable id for every entry. A missing id makes the
+			// snapshot structurally incomplete, so it cannot prove presence/absence.
+			for i, si := range out {
+				if si == nil || si.ID == "" {
+					return nil, fmt.Errorf("strict static IP listing of private network %s has an entry (index %d) without an id; refusing to use a structurally incomplete listing as deletion evidence", privateNetworkID, i)
+				}
+			}
 
-	return out, nil
+			return out, nil
+		})
 }
 
 // Read retrieves a single static IP by ID. It returns (nil, nil) when the static
@@ -202,7 +212,7 @@ func (s *VPCStaticIPClient) CreateStart(ctx context.Context, privateNetworkID st
 	if err != nil {
 		return "", "", err
 	}
-	resp, err := s.c.doRequestWithToken(ctx, r, token.Raw)
+	resp, err := s.c.doRequestWithJWT(ctx, r, token)
 	if err != nil {
 		return "", "", err
 	}
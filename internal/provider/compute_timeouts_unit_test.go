@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestComputeResourcesDeclareTimeouts pins the timeouts block of the VMware and
+// Open IaaS resources. The VMware creates (CreateWithoutTimeout) stay unbounded
+// unless timeouts.create is configured; every other operation keeps the SDK's
+// historical 20 minutes by default.
+func TestComputeResourcesDeclareTimeouts(t *testing.T) {
+	cases := map[string]struct {
+		res       *schema.Resource
+		create    time.Duration
+		hasUpdate bool
+	}{
+		"cloudtemple_compute_virtual_machine":                    {resourceVirtualMachine(), 0, true},
+		"cloudtemple_compute_virtual_disk":                       {resourceVirtualDisk(), 0, true},
+		"cloudtemple_compute_network_adapter":                    {resourceNetworkAdapter(), 0, true},
+		"cloudtemple_compute_virtual_controller":                 {resourceVirtualController(), defaultComputeTimeout, true},
+		"cloudtemple_compute_iaas_opensource_virtual_machine":    {resourceOpenIaasVirtualMachine(), defaultComputeTimeout, true},
+		"cloudtemple_compute_iaas_opensource_virtual_disk":       {resourceOpenIaasVirtualDisk(), defaultComputeTimeout, true},
+		"cloudtemple_compute_iaas_opensource_network_adapter":    {resourceOpenIaasNetworkAdapter(), defaultComputeTimeout, true},
+		"cloudtemple_compute_iaas_opensource_replication_policy": {resourceOpenIaasReplicationPolicy(), defaultComputeTimeout, false},
+	}
+	for name, tc := range cases {
+		to := tc.res.Timeouts
+		if to == nil || to.Create == nil || to.Delete == nil {
+			t.Errorf("%s: create and delete timeouts must be declared", name)
+			continue
+		}
+		if *to.Create != tc.create {
+			t.Errorf("%s: default create timeout = %s, want %s", name, *to.Create, tc.create)
+		}
+		if *to.Delete != defaultComputeTimeout {
+			t.Errorf("%s: default delete timeout = %s, want %s", name, *to.Delete, defaultComputeTimeout)
+		}
+		if (to.Update != nil) != tc.hasUpdate {
+			t.Errorf("%s: update timeout declared = %v, want %v", name, to.Update != nil, tc.hasUpdate)
+		}
+	}
+}
+
+func TestWithConfiguredTimeout(t *testing.T) {
+	ctx, cancel := withConfiguredTimeout(context.Background(), 0)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Fatal("an unset timeout must leave the create unbounded")
+	}
+
+	ctx, cancel = withConfiguredTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if _, ok := ctx.Deadline(); !ok {
+		t.Fatal("a configured timeout must bound the create")
+	}
+}
+
+func TestGetWaiterOptionsCarriesTheDeadline(t *testing.T) {
+	if o := getWaiterOptions(context.Background()); !o.Deadline.IsZero() {
+		t.Fatalf("no context deadline must leave the waiters unbounded, got %s", o.Deadline)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	want, _ := ctx.Deadline()
+	if o := getWaiterOptions(ctx); !o.Deadline.Equal(want) {
+		t.Fatalf("Deadline = %s, want the context deadline %s", o.Deadline, want)
+	}
+}
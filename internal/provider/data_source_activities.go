@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/provider/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceActivities() *schema.Resource {
+	// Each listed element is a Computed id plus the shared computed attributes.
+	elem := activityComputedAttributes()
+	elem["id"] = &schema.Schema{Type: schema.TypeString, Computed: true, Description: "The ID of the activity."}
+
+	return &schema.Resource{
+		Description: "Used to retrieve the activities of the tenant, optionally filtered, e.g. to expose the platform's view of failed operations in outputs or drift checks.",
+
+		ReadContext: activitiesRead,
+
+		Schema: map[string]*schema.Schema{
+			// In — filters
+			"concerned_item_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsUUID,
+				Description:  "Only return the activities concerning this item (e.g. a virtual machine ID).",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the activities of this type.",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the activities whose current state has this name (e.g. `failed`, `completed`, `running`). The current state of a finished activity is its terminal state (`completed` or `failed`), not the states it went through.",
+			},
+			"created_after": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "Only return the activities created at or after this date (RFC3339).",
+			},
+			"created_before": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "Only return the activities created strictly before this date (RFC3339).",
+			},
+
+			// Out
+			"activities": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of activities matching the filters.",
+				Elem: &schema.Resource{
+					Schema: elem,
+				},
+			},
+		},
+	}
+}
+
+// activitiesFilter holds the datasource filters; a zero field does not filter.
+type activitiesFilter struct {
+	concernedItemID string
+	activityType    string
+	state           string
+	createdAfter    time.Time
+	createdBefore   time.Time
+}
+
+// match reports whether the activity satisfies every set filter. The creation
+// window is half-open: [createdAfter, createdBefore).
+func (f activitiesFilter) match(a *client.Activity) bool {
+	if a == nil {
+		return false
+	}
+	if f.activityType != "" && a.Type != f.activityType {
+		return false
+	}
+	if f.state != "" && currentActivityState(a) != f.state {
+		return false
+	}
+	if !f.createdAfter.IsZero() && a.CreationDate.Before(f.createdAfter) {
+		return false
+	}
+	if !f.createdBefore.IsZero() && !a.CreationDate.Before(f.createdBefore) {
+		return false
+	}
+	if f.concernedItemID != "" {
+		for _, item := range a.ConcernedItems {
+			if sameUUID(item.ID, f.concernedItemID) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// currentActivityState returns the name of the state the activity is in: its
+// terminal state once it finished, otherwise the state started last. The
+// State map also holds the states the activity went through.
+func currentActivityState(a *client.Activity) string {
+	for _, terminal := range []string{"failed", "completed"} {
+		if _, ok := a.State[terminal]; ok {
+			return terminal
+		}
+	}
+	var current string
+	var currentStart time.Time
+	for name, state := range a.State {
+		start, _ := time.Parse(time.RFC3339, state.StartDate)
+		switch {
+		case current == "",
+			start.After(currentStart),
+			start.Equal(currentStart) && name < current:
+			current, currentStart = name, start
+		}
+	}
+	return current
+}
+
+func activitiesRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := getClient(meta)
+
+	filter := activitiesFilter{
+		concernedItemID: d.Get("concerned_item_id").(string),
+		activityType:    d.Get("type").(string),
+		state:           d.Get("state").(string),
+	}
+	// The dates were validated by IsRFC3339Time.
+	if v := d.Get("created_after").(string); v != "" {
+		filter.createdAfter, _ = time.Parse(time.RFC3339, v)
+	}
+	if v := d.Get("created_before").(string); v != "" {
+		filter.createdBefore, _ = time.Parse(time.RFC3339, v)
+	}
+
+	activities, err := c.Activity().List(ctx, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("activities")
+
+	tfActivities := []map[string]interface{}{}
+	for _, activity := range activities {
+		if filter.match(activity) {
+			tfActivities = append(tfActivities, helpers.FlattenActivity(activity))
+		}
+	}
+
+	if err := d.Set("activities", tfActivities); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+)
+
+func TestActivitiesFilterMatch(t *testing.T) {
+	const vmID = "55555555-2222-3333-4444-555555555555"
+	created := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	activity := &client.Activity{
+		Type:           "ComputeActivity",
+		CreationDate:   created,
+		ConcernedItems: []client.ActivityConcernedItem{{ID: vmID, Type: "virtual_machine"}},
+		State:          map[string]client.ActivityState{"failed": {Reason: "boom"}},
+	}
+
+	cases := map[string]struct {
+		filter activitiesFilter
+		want   bool
+	}{
+		"no filter":                   {activitiesFilter{}, true},
+		"matching type":               {activitiesFilter{activityType: "ComputeActivity"}, true},
+		"other type":                  {activitiesFilter{activityType: "BackupActivity"}, false},
+		"matching state":              {activitiesFilter{state: "failed"}, true},
+		"other state":                 {activitiesFilter{state: "completed"}, false},
+		"concerned item, any case":    {activitiesFilter{concernedItemID: strings.ToUpper(vmID)}, true},
+		"other concerned item":        {activitiesFilter{concernedItemID: "66666666-2222-3333-4444-555555555555"}, false},
+		"created_after is inclusive":  {activitiesFilter{createdAfter: created}, true},
+		"created_after excludes":      {activitiesFilter{createdAfter: created.Add(time.Second)}, false},
+		"created_before is exclusive": {activitiesFilter{createdBefore: created}, false},
+		"created_before includes":     {activitiesFilter{createdBefore: created.Add(time.Second)}, true},
+		"every filter set": {activitiesFilter{
+			concernedItemID: vmID,
+			activityType:    "ComputeActivity",
+			state:           "failed",
+			createdAfter:    created.Add(-time.Hour),
+			createdBefore:   created.Add(time.Hour),
+		}, true},
+	}
+	for name, tc := range cases {
+		if got := tc.filter.match(activity); got != tc.want {
+			t.Errorf("%s: match = %v, want %v", name, got, tc.want)
+		}
+	}
+
+	if (activitiesFilter{}).match(nil) {
+		t.Error("a nil activity must never match")
+	}
+}
+
+// TestActivitiesFilterMatchesTheCurrentState: the State map of an activity
+// also holds the states it went through, which must not match.
+func TestActivitiesFilterMatchesTheCurrentState(t *testing.T) {
+	finished := &client.Activity{State: map[string]client.ActivityState{
+		"waiting":   {StartDate: "2025-03-10T12:00:00Z", StopDate: "2025-03-10T12:00:05Z"},
+		"running":   {StartDate: "2025-03-10T12:00:05Z", StopDate: "2025-03-10T12:01:00Z"},
+		"completed": {StartDate: "2025-03-10T12:01:00Z", StopDate: "2025-03-10T12:01:00Z"},
+	}}
+	running := &client.Activity{State: map[string]client.ActivityState{
+		"waiting": {StartDate: "2025-03-10T12:00:00Z", StopDate: "2025-03-10T12:00:05Z"},
+		"running": {StartDate: "2025-03-10T12:00:05Z"},
+	}}
+
+	for _, tc := range []struct {
+		activity *client.Activity
+		state    string
+		want     bool
+	}{
+		{finished, "completed", true},
+		{finished, "running", false},
+		{finished, "waiting", false},
+		{running, "running", true},
+		{running, "waiting", false},
+		{running, "completed", false},
+	} {
+		if got := (activitiesFilter{state: tc.state}).match(tc.activity); got != tc.want {
+			t.Errorf("state %q on an activity in %q: match = %v, want %v", tc.state, currentActivityState(tc.activity), got, tc.want)
+		}
+	}
+}
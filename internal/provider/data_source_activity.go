@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/provider/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// activityComputedAttributes returns the Computed attributes of an activity,
+// shared by the single and list datasources (the list nests them, plus a
+// Computed `id`, inside each element).
+func activityComputedAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"tenant_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the tenant the activity belongs to.",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The description of the activity.",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The type of the activity.",
+		},
+		"tags": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The tags of the activity.",
+		},
+		"creation_date": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The creation date of the activity (RFC3339).",
+		},
+		"concerned_items": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The items concerned by the activity.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The ID of the concerned item.",
+					},
+					"type": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The type of the concerned item (e.g. `virtual_machine`).",
+					},
+				},
+			},
+		},
+		"state": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The states of the activity, sorted by name.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the state (e.g. `running`, `completed`, `failed`).",
+					},
+					"start_date": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The date the state started.",
+					},
+					"stop_date": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The date the state stopped.",
+					},
+					"reason": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The reason given by the platform, typically why a failed activity failed.",
+					},
+					"result": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The result of the state, typically the ID of the created item.",
+					},
+					"progression": {
+						Type:        schema.TypeFloat,
+						Computed:    true,
+						Description: "The progression of the state.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceActivity() *schema.Resource {
+	s := activityComputedAttributes()
+	s["id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.IsUUID,
+		Description:  "The ID of the activity to retrieve.",
+	}
+
+	return &schema.Resource{
+		Description: "Used to retrieve a specific activity, i.e. the platform's view of an asynchronous operation (its concerned items and the reason, result and progression of each of its states).",
+
+		ReadContext: activityRead,
+
+		Schema: s,
+	}
+}
+
+func activityRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := getClient(meta)
+
+	id := d.Get("id").(string)
+	activity, err := c.Activity().Read(ctx, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if activity == nil {
+		return diag.FromErr(fmt.Errorf("failed to find activity with id %q", id))
+	}
+
+	d.SetId(activity.ID)
+	for k, v := range helpers.FlattenActivity(activity) {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,302 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/sync/errgroup"
+)
+
+// taggedObject is an object listed by a resources_by_tag source. tags is nil
+// when the listing does not carry the tags: they are then read through the tag
+// API, resourcesByTagConcurrency objects at a time.
+type taggedObject struct {
+	id   string
+	name string
+	tags []*client.Tag
+}
+
+// resourcesByTagSource lists the objects of one type. The type is the name of
+// the resource (or data source) that takes the id, so that a result feeds
+// straight into it.
+type resourcesByTagSource struct {
+	typ  string
+	list func(ctx context.Context, c *client.Client) ([]taggedObject, error)
+}
+
+// resourcesByTagConcurrency caps the tag reads in flight, so that searching a
+// large tenant does not flood the tag API.
+const resourcesByTagConcurrency = 8
+
+var resourcesByTagSources = []resourcesByTagSource{
+	{"cloudtemple_compute_virtual_machine", func(ctx context.Context, c *client.Client) ([]taggedObject, error) {
+		vms, err := c.Compute().VirtualMachine().ListStrict(ctx, &client.VirtualMachineFilter{})
+		objects := make([]taggedObject, 0, len(vms))
+		for _, vm := range vms {
+			objects = append(objects, taggedObject{id: vm.ID, name: vm.Name})
+		}
+		return objects, err
+	}},
+	{"cloudtemple_compute_virtual_disk", func(ctx context.Context, c *client.Client) ([]taggedObject, error) {
+		disks, err := c.Compute().VirtualDisk().ListStrict(ctx, &client.VirtualDiskFilter{})
+		objects := make([]taggedObject, 0, len(disks))
+		for _, disk := range disks {
+			objects = append(objects, taggedObject{id: disk.ID, name: disk.Name})
+		}
+		return objects, err
+	}},
+	{"cloudtemple_compute_network", func(ctx context.Context, c *client.Client) ([]taggedObject, error) {
+		networks, err := c.Compute().Network().ListStrict(ctx, &client.NetworkFilter{})
+		objects := make([]taggedObject, 0, len(networks))
+		for _, network := range networks {
+			objects = append(objects, taggedObject{id: network.ID, name: network.Name})
+		}
+		return objects, err
+	}},
+	{"cloudtemple_compute_iaas_opensource_virtual_machine", func(ctx context.Context, c *client.Client) ([]taggedObject, error) {
+		vms, err := c.Compute().OpenIaaS().VirtualMachine().ListStrict(ctx, &client.OpenIaaSVirtualMachineFilter{})
+		objects := make([]taggedObject, 0, len(vms))
+		for _, vm := range vms {
+			objects = append(objects, taggedObject{id: vm.ID, name: vm.Name})
+		}
+		return objects, err
+	}},
+	{"cloudtemple_compute_iaas_opensource_virtual_disk", func(ctx context.Context, c *client.Client) ([]taggedObject, error) {
+		disks, err := c.Compute().OpenIaaS().VirtualDisk().ListStrict(ctx, &client.OpenIaaSVirtualDiskFilter{})
+		objects := make([]taggedObject, 0, len(disks))
+		for _, disk := range disks {
+			objects = append(objects, taggedObject{id: disk.ID, name: disk.Name})
+		}
+		return objects, err
+	}},
+	{"cloudtemple_compute_iaas_opensource_network", func(ctx context.Context, c *client.Client) ([]taggedObject, error) {
+		networks, err := c.Compute().OpenIaaS().Network().ListStrict(ctx, &client.OpenIaaSNetworkFilter{})
+		objects := make([]taggedObject, 0, len(networks))
+		for _, network := range networks {
+			objects = append(objects, taggedObject{id: network.ID, name: network.Name})
+		}
+		return objects, err
+	}},
+	{"cloudtemple_vpc_private_network", func(ctx context.Context, c *client.Client) ([]taggedObject, error) {
+		networks, err := c.VPC().PrivateNetwork().ListStrict(ctx, &client.PrivateNetworkFilter{})
+		objects := make([]taggedObject, 0, len(networks))
+		for _, network := range networks {
+			var name string
+			if network.Name != nil {
+				name = *network.Name
+			}
+			objects = append(objects, taggedObject{id: network.ID, name: name})
+		}
+		return objects, err
+	}},
+	// The bucket listing carries the tags: no per-bucket tag read.
+	{"cloudtemple_object_storage_bucket", func(ctx context.Context, c *client.Client) ([]taggedObject, error) {
+		buckets, err := c.ObjectStorage().Bucket().ListStrict(ctx)
+		objects := make([]taggedObject, 0, len(buckets))
+		for _, bucket := range buckets {
+			tags := make([]*client.Tag, 0, len(bucket.Tags))
+			for _, tag := range bucket.Tags {
+				tags = append(tags, &client.Tag{Key: tag.Key, Value: tag.Value})
+			}
+			objects = append(objects, taggedObject{id: bucket.ID, name: bucket.Name, tags: tags})
+		}
+		return objects, err
+	}},
+}
+
+func resourcesByTagTypes() []string {
+	types := make([]string, 0, len(resourcesByTagSources))
+	for _, source := range resourcesByTagSources {
+		types = append(types, source.typ)
+	}
+	return types
+}
+
+// flattenResourceByTag converts a matching object into an element of the
+// resources attribute.
+func flattenResourceByTag(object taggedObject, typ, value string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":    object.id,
+		"type":  typ,
+		"name":  object.name,
+		"value": value,
+	}
+}
+
+func dataSourceResourcesByTag() *schema.Resource {
+	return &schema.Resource{
+		Description: "Used to find the virtual machines, disks, networks and buckets carrying a tag, e.g. to reach the resources of another stack without hard-coding their IDs. The tags of every listed object but the buckets are read one object at a time, a few in parallel: restrict `types` to what you look for on a large tenant. The search fails rather than return an incomplete result when a listing is partial or the tags of an object cannot be read.",
+
+		ReadContext: resourcesByTagRead,
+
+		Schema: map[string]*schema.Schema{
+			// In
+			"key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The key of the tag to look for.",
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The value of the tag to look for. Any value matches when omitted.",
+			},
+			"types": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: fmt.Sprintf("The types of objects to search. Defaults to every type: `%s`.", strings.Join(resourcesByTagTypes(), "`, `")),
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(resourcesByTagTypes(), false),
+				},
+			},
+
+			// Out
+			"resources": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The objects carrying the tag, sorted by type then ID.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the object.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the object: the name of the resource or data source that takes this ID (e.g. `cloudtemple_compute_virtual_machine`).",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the object.",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The value of the tag on the object.",
+						},
+					},
+				},
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The IDs of the objects carrying the tag, in the order of `resources`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourcesByTagRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := getClient(meta)
+
+	key := d.Get("key").(string)
+	value, filterValue := d.GetOk("value")
+
+	wanted := map[string]bool{}
+	for _, typ := range d.Get("types").(*schema.Set).List() {
+		wanted[typ.(string)] = true
+	}
+
+	var diags diag.Diagnostics
+	var resources []map[string]interface{}
+	for _, source := range resourcesByTagSources {
+		if len(wanted) > 0 && !wanted[source.typ] {
+			continue
+		}
+
+		objects, err := source.list(ctx, c)
+		if isStatusCode(err, http.StatusForbidden) {
+			// The tenant does not have the product, or the credentials lack
+			// its read role: say so rather than silently missing matches.
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("The %s objects were not searched", source.typ),
+				Detail:   fmt.Sprintf("Listing them was forbidden: %s", err),
+			})
+			continue
+		}
+		if err != nil {
+			return append(diags, diag.Errorf("failed to list the %s objects: %s", source.typ, err)...)
+		}
+
+		if err := readResourcesByTagTags(ctx, c, objects); err != nil {
+			return append(diags, diag.Errorf("failed to read the tags of the %s objects: %s", source.typ, err)...)
+		}
+
+		for _, object := range objects {
+			for _, tag := range object.tags {
+				if tag == nil || tag.Key != key || (filterValue && tag.Value != value.(string)) {
+					continue
+				}
+				resources = append(resources, flattenResourceByTag(object, source.typ, tag.Value))
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		if resources[i]["type"] != resources[j]["type"] {
+			return resources[i]["type"].(string) < resources[j]["type"].(string)
+		}
+		return resources[i]["id"].(string) < resources[j]["id"].(string)
+	})
+
+	flattened := make([]interface{}, 0, len(resources))
+	ids := make([]interface{}, 0, len(resources))
+	for _, resource := range resources {
+		flattened = append(flattened, resource)
+		ids = append(ids, resource["id"])
+	}
+
+	id := "resources_by_tag/" + key
+	if filterValue {
+		id += "=" + value.(string)
+	}
+
+	sw := newStateWriter(d)
+	sw.set("id", id)
+	sw.set("resources", flattened)
+	sw.set("ids", ids)
+	return append(diags, sw.diags...)
+}
+
+// readResourcesByTagTags reads the tags of the objects whose listing does not
+// carry them, resourcesByTagConcurrency at a time. Any failed read, a 404
+// included, fails the whole search: a missing object would be a silently
+// dropped match.
+func readResourcesByTagTags(ctx context.Context, c *client.Client, objects []taggedObject) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(resourcesByTagConcurrency)
+	for i := range objects {
+		if objects[i].tags != nil {
+			continue
+		}
+		g.Go(func() error {
+			tags, err := c.Tag().Resource().Read(ctx, objects[i].id)
+			if err != nil {
+				return fmt.Errorf("%s: %w", objects[i].id, err)
+			}
+			if tags == nil {
+				tags = []*client.Tag{}
+			}
+			objects[i].tags = tags
+			return nil
+		})
+	}
+	return g.Wait()
+}
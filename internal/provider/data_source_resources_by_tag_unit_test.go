@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestResourcesByTagRead drives the data source against a stub API: VMware
+// virtual machines whose tags are read one at a time, buckets whose listing
+// carries the tags, and an Open IaaS listing forbidden to the tenant, which is
+// reported as a warning instead of failing the whole search.
+func TestResourcesByTagRead(t *testing.T) {
+	var tagReads atomic.Int32
+	c := newAssignTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/compute/v1/vcenters/virtual_machines"):
+			_, _ = w.Write([]byte(`[{"id":"vm-2","name":"api"},{"id":"vm-1","name":"web"},{"id":"vm-3","name":"db"}]`))
+		case strings.HasSuffix(r.URL.Path, "/storage/object/v1/buckets"):
+			_, _ = w.Write([]byte(`[{"id":"b-1","name":"invoices","tags":[{"key":"app","value":"billing"}]},{"id":"b-2","name":"logs","tags":[{"key":"app","value":"audit"}]}]`))
+		case strings.HasSuffix(r.URL.Path, "/compute/v1/open_iaas/virtual_machines"):
+			w.WriteHeader(http.StatusForbidden)
+		case strings.Contains(r.URL.Path, "/tag/v1/tags/resources/"):
+			tagReads.Add(1)
+			switch r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:] {
+			case "vm-1", "vm-2":
+				_, _ = w.Write([]byte(`[{"key":"owner","value":"ops"},{"key":"app","value":"billing"}]`))
+			default:
+				_, _ = w.Write([]byte(`[]`))
+			}
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceResourcesByTag().Schema, map[string]interface{}{
+		"key":   "app",
+		"value": "billing",
+		"types": []interface{}{"cloudtemple_compute_virtual_machine", "cloudtemple_compute_iaas_opensource_virtual_machine", "cloudtemple_object_storage_bucket"},
+	})
+	diags := resourcesByTagRead(context.Background(), d, c)
+	if diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Summary, "cloudtemple_compute_iaas_opensource_virtual_machine") {
+		t.Fatalf("a forbidden listing must be reported as a warning, got %v", diags)
+	}
+	if n := tagReads.Load(); n != 3 {
+		t.Errorf("tag reads = %d, want one per virtual machine and none for the buckets", n)
+	}
+
+	want := []string{
+		"cloudtemple_compute_virtual_machine/vm-1/web",
+		"cloudtemple_compute_virtual_machine/vm-2/api",
+		"cloudtemple_object_storage_bucket/b-1/invoices",
+	}
+	if n := d.Get("resources.#").(int); n != len(want) {
+		t.Fatalf("resources = %v, want %v", d.Get("resources"), want)
+	}
+	for i, w := range want {
+		r := d.Get("resources").([]interface{})[i].(map[string]interface{})
+		if got := r["type"].(string) + "/" + r["id"].(string) + "/" + r["name"].(string); got != w || r["value"] != "billing" {
+			t.Errorf("resources[%d] = %v, want %s", i, r, w)
+		}
+		if d.Get("ids").([]interface{})[i] != r["id"] {
+			t.Errorf("ids[%d] = %v, want %v", i, d.Get("ids").([]interface{})[i], r["id"])
+		}
+	}
+}
+
+// TestResourcesByTagReadFailsOnAnIncompleteSearch pins that the search never
+// returns a result missing matches: a partial listing and a failed tag read,
+// a 404 included, are errors.
+func TestResourcesByTagReadFailsOnAnIncompleteSearch(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		listStatus    int
+		tagReadStatus int
+	}{
+		{"partial listing", http.StatusPartialContent, http.StatusOK},
+		{"tag read not found", http.StatusOK, http.StatusNotFound},
+		{"tag read failure", http.StatusOK, http.StatusInternalServerError},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newAssignTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/compute/v1/vcenters/virtual_machines"):
+					w.WriteHeader(tc.listStatus)
+					_, _ = w.Write([]byte(`[{"id":"vm-1","name":"web"},{"id":"vm-2","name":"api"}]`))
+				case strings.HasSuffix(r.URL.Path, "/tag/v1/tags/resources/vm-2"):
+					w.WriteHeader(tc.tagReadStatus)
+					_, _ = w.Write([]byte(`[]`))
+				case strings.Contains(r.URL.Path, "/tag/v1/tags/resources/"):
+					_, _ = w.Write([]byte(`[{"key":"app","value":"billing"}]`))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+			})
+
+			d := schema.TestResourceDataRaw(t, dataSourceResourcesByTag().Schema, map[string]interface{}{
+				"key":   "app",
+				"types": []interface{}{"cloudtemple_compute_virtual_machine"},
+			})
+			if diags := resourcesByTagRead(context.Background(), d, c); !diags.HasError() {
+				t.Fatalf("an incomplete search must fail, got %v with resources %v", diags, d.Get("resources"))
+			}
+		})
+	}
+}
+
+// TestResourcesByTagReadCapsTheTagReadsInFlight checks that the tag reads run
+// in parallel, but never more than resourcesByTagConcurrency at a time.
+func TestResourcesByTagReadCapsTheTagReadsInFlight(t *testing.T) {
+	const vms = 3 * resourcesByTagConcurrency
+	var inFlight, peak, reads atomic.Int32
+	c := newAssignTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/compute/v1/vcenters/virtual_machines"):
+			listing := make([]string, 0, vms)
+			for i := range vms {
+				listing = append(listing, fmt.Sprintf(`{"id":"vm-%02d","name":"vm"}`, i))
+			}
+			_, _ = w.Write([]byte("[" + strings.Join(listing, ",") + "]"))
+		case strings.Contains(r.URL.Path, "/tag/v1/tags/resources/"):
+			n := inFlight.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			inFlight.Add(-1)
+			reads.Add(1)
+			_, _ = w.Write([]byte(`[{"key":"app","value":"billing"}]`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceResourcesByTag().Schema, map[string]interface{}{
+		"key":   "app",
+		"types": []interface{}{"cloudtemple_compute_virtual_machine"},
+	})
+	if diags := resourcesByTagRead(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if n := reads.Load(); n != vms {
+		t.Errorf("tag reads = %d, want %d", n, vms)
+	}
+	if p := peak.Load(); p > resourcesByTagConcurrency {
+		t.Errorf("%d tag reads in flight, want at most %d", p, resourcesByTagConcurrency)
+	}
+	ids := d.Get("ids").([]interface{})
+	if len(ids) != vms || ids[0] != "vm-00" || ids[vms-1] != fmt.Sprintf("vm-%02d", vms-1) {
+		t.Errorf("ids = %v, want the %d virtual machines sorted by ID", ids, vms)
+	}
+}
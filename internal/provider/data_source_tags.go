@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/provider/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceTags() *schema.Resource {
+	return &schema.Resource{
+		Description: "Used to retrieve the tags attached to any platform object.",
+
+		ReadContext: tagsRead,
+
+		Schema: map[string]*schema.Schema{
+			// In
+			"resource_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+				Description:  "The ID of the tagged object.",
+			},
+
+			// Out
+			"tags": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The tags attached to the object, by key.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func tagsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := getClient(meta)
+
+	resourceID := d.Get("resource_id").(string)
+	tags, err := c.Tag().Resource().Read(ctx, resourceID)
+	if err != nil {
+		return diag.Errorf("failed to read the tags of %s: %s", resourceID, err)
+	}
+
+	sw := newStateWriter(d)
+	sw.set("id", resourceID)
+	sw.set("tags", helpers.FlattenTags(tags))
+	return sw.diags
+}
@@ -203,6 +203,10 @@ type dsCoverage struct {
 // added datasource. rootKey and any injected key (e.g. "id") mirror the real
 // Read exactly.
 var datasourceCoverage = map[string]dsCoverage{
+	// --- Activity ----------------------------------------------------------
+	"cloudtemple_activity":   {"", flat(helpers.FlattenActivity)},
+	"cloudtemple_activities": {"activities", flat(helpers.FlattenActivity)},
+
 	// --- VMware compute (vCenter) -----------------------------------------
 	"cloudtemple_compute_content_libraries":       {"content_libraries", flat(helpers.FlattenContentLibrary)},
 	"cloudtemple_compute_content_library":         {"", flat(helpers.FlattenContentLibrary)},
@@ -312,6 +316,14 @@ var datasourceCoverage = map[string]dsCoverage{
 	"cloudtemple_object_storage_role":             {"", flat(helpers.FlattenObjectStorageRole)},
 	"cloudtemple_object_storage_roles":            {"roles", flat(helpers.FlattenObjectStorageRole)},
 
+	// --- Tags -------------------------------------------------------------
+	"cloudtemple_resources_by_tag": {"resources", func() map[string]interface{} {
+		return flattenResourceByTag(taggedObject{id: "x", name: "x"}, "x", "x")
+	}},
+	"cloudtemple_tags": {"", func() map[string]interface{} {
+		return map[string]interface{}{"tags": helpers.FlattenTags([]*client.Tag{filled[client.Tag]()})}
+	}},
+
 	// --- IAM --------------------------------------------------------------
 	"cloudtemple_iam_company": {"", flat(helpers.FlattenCompany)},
 	// Feature is self-referential and the schema declares a fixed nesting depth
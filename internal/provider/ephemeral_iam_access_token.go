@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type iamAccessTokenEphemeralResource struct {
+	client *client.Client
+}
+
+type iamAccessTokenModel struct {
+	Token     types.String `tfsdk:"token"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &iamAccessTokenEphemeralResource{}
+
+func newIAMAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &iamAccessTokenEphemeralResource{}
+}
+
+func (r *iamAccessTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_access_token"
+}
+
+func (r *iamAccessTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Exchanges the credentials of the provider for a short-lived bearer token, e.g. to configure another provider (`http`, `helm`, …) calling the Cloud Temple APIs. The token is never written to the plan or the state: a new one is issued on each run. Unlike `cloudtemple_iam_personal_access_token`, nothing is created on the platform.",
+
+		Attributes: map[string]schema.Attribute{
+			"token": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The bearer token, to send in an `Authorization: Bearer <token>` header.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The expiration date of the token, in RFC 3339 format.",
+			},
+		},
+	}
+}
+
+func (r *iamAccessTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// The provider is not configured yet during the validation of the
+	// configuration.
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *client.Client, got %T.", req.ProviderData))
+		return
+	}
+	r.client = c
+}
+
+func (r *iamAccessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "The provider must be configured before opening cloudtemple_iam_access_token.")
+		return
+	}
+
+	token, err := r.client.NewAccessToken(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get an access token", err.Error())
+		return
+	}
+
+	model := iamAccessTokenModel{
+		Token:     types.StringValue(token.Raw),
+		ExpiresAt: types.StringNull(),
+	}
+	if claims, ok := token.Claims.(jwt.MapClaims); ok {
+		if exp, ok := claims["exp"].(float64); ok {
+			model.ExpiresAt = types.StringValue(time.Unix(int64(exp), 0).UTC().Format(time.RFC3339))
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+}
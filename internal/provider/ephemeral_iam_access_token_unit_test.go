@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// providerConfig builds the provider configuration of a protocol request: the
+// values given, null attributes and empty blocks for the rest.
+func providerConfig(t *testing.T, s *tfprotov5.Schema, values map[string]tftypes.Value) *tfprotov5.DynamicValue {
+	t.Helper()
+	typ := s.ValueType().(tftypes.Object)
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range typ.AttributeTypes {
+		switch {
+		case values[name].Type() != nil:
+			attributes[name] = values[name]
+		case attributeType.Is(tftypes.List{}) || attributeType.Is(tftypes.Set{}):
+			attributes[name] = tftypes.NewValue(attributeType, []tftypes.Value{})
+		default:
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+	config, err := tfprotov5.NewDynamicValue(typ, tftypes.NewValue(typ, attributes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &config
+}
+
+func requireNoErrorDiagnostics(t *testing.T, diags []*tfprotov5.Diagnostic) {
+	t.Helper()
+	for _, d := range diags {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("%s: %s", d.Summary, d.Detail)
+		}
+	}
+}
+
+// TestProviderServerSchema: the mux rejects providers whose schemas differ, so
+// this fails as soon as the framework provider schema drifts from the SDKv2 one.
+func TestProviderServerSchema(t *testing.T) {
+	ctx := context.Background()
+	server, err := NewProviderServer(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := server().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireNoErrorDiagnostics(t, resp.Diagnostics)
+
+	if _, ok := resp.EphemeralResourceSchemas["cloudtemple_iam_access_token"]; !ok {
+		t.Error("cloudtemple_iam_access_token is not served")
+	}
+	if want := len(New("test")().ResourcesMap); len(resp.ResourceSchemas) != want {
+		t.Errorf("%d resources served, want the %d of the SDKv2 provider", len(resp.ResourceSchemas), want)
+	}
+}
+
+// TestIAMAccessTokenOpen drives the muxed provider through the protocol: the
+// ephemeral resource reuses the client configured by the SDKv2 provider and
+// returns a fresh token, not the one the provider authenticated with.
+func TestIAMAccessTokenOpen(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+	var logins int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/iam/v2/auth/personal_access_token") {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		n := atomic.AddInt32(&logins, 1)
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"exp": float64(expiresAt.Unix()),
+			"n":   n,
+		}).SignedString([]byte("test-secret"))
+		if err != nil {
+			t.Error(err)
+		}
+		fmt.Fprint(w, token)
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := context.Background()
+	factory, err := NewProviderServer(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := factory()
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configured, err := server.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{
+		Config: providerConfig(t, schemas.Provider, map[string]tftypes.Value{
+			"address":    tftypes.NewValue(tftypes.String, srv.URL),
+			"api_suffix": tftypes.NewValue(tftypes.Bool, false),
+			"client_id":  tftypes.NewValue(tftypes.String, "id"),
+			"secret_id":  tftypes.NewValue(tftypes.String, "secret"),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireNoErrorDiagnostics(t, configured.Diagnostics)
+
+	ephemeralSchema := schemas.EphemeralResourceSchemas["cloudtemple_iam_access_token"]
+	config := providerConfig(t, ephemeralSchema, nil)
+	opened, err := server.OpenEphemeralResource(ctx, &tfprotov5.OpenEphemeralResourceRequest{
+		TypeName: "cloudtemple_iam_access_token",
+		Config:   config,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireNoErrorDiagnostics(t, opened.Diagnostics)
+
+	result, err := opened.Result.Unmarshal(ephemeralSchema.ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var attributes map[string]tftypes.Value
+	if err := result.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	var token, expires string
+	if err := attributes["token"].As(&token); err != nil {
+		t.Fatal(err)
+	}
+	if err := attributes["expires_at"].As(&expires); err != nil {
+		t.Fatal(err)
+	}
+
+	if logins != 2 {
+		t.Fatalf("%d logins, want one to configure the provider and one for the token", logins)
+	}
+	claims := jwt.MapClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(token, claims); err != nil || claims["n"] != float64(2) {
+		t.Errorf("the token must be the second one issued, got claims %v (%v)", claims, err)
+	}
+	if expires != expiresAt.Format(time.RFC3339) {
+		t.Errorf("expires_at = %q, want %q", expires, expiresAt.Format(time.RFC3339))
+	}
+}
@@ -0,0 +1,252 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// NewProviderServer serves the SDKv2 provider muxed with a plugin framework
+// provider, which declares what SDKv2 cannot: the ephemeral resources and the
+// provider functions, and the list resources. The resources and data sources
+// all stay in the SDKv2 provider.
+func NewProviderServer(ctx context.Context, version string) (func() tfprotov5.ProviderServer, error) {
+	sdk := New(version)()
+
+	fw, err := newFrameworkProvider(ctx, version, sdk)
+	if err != nil {
+		return nil, err
+	}
+
+	// The mux configures the servers one at a time, in this order: the SDKv2
+	// provider must come first, the framework provider reuses its client.
+	mux, err := tf5muxserver.NewMuxServer(ctx, sdk.GRPCProvider, providerserver.NewProtocol5(fw))
+	if err != nil {
+		return nil, err
+	}
+	return mux.ProviderServer, nil
+}
+
+// frameworkProvider is the plugin framework half of the provider. The mux
+// requires both halves to declare the same provider schema: it is derived from
+// the SDKv2 one rather than written twice.
+type frameworkProvider struct {
+	version string
+	sdk     *schema.Provider
+	schema  fwschema.Schema
+
+	// The schemas of the SDKv2 resources, which the list resources return.
+	resourceSchemas map[string]*tfprotov5.Schema
+	identitySchemas map[string]*tfprotov5.ResourceIdentitySchema
+}
+
+var (
+	_ fwprovider.ProviderWithEphemeralResources = &frameworkProvider{}
+	_ fwprovider.ProviderWithFunctions          = &frameworkProvider{}
+	_ fwprovider.ProviderWithListResources      = &frameworkProvider{}
+)
+
+func newFrameworkProvider(ctx context.Context, version string, sdk *schema.Provider) (*frameworkProvider, error) {
+	server := schema.NewGRPCProviderServer(sdk)
+	resp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Provider == nil || resp.Provider.Block == nil {
+		return nil, fmt.Errorf("the SDKv2 provider returned no provider schema")
+	}
+
+	attributes, blocks, err := frameworkProviderSchema(resp.Provider.Block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the provider schema: %w", err)
+	}
+
+	identities, err := server.GetResourceIdentitySchemas(ctx, &tfprotov5.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &frameworkProvider{
+		version: version,
+		sdk:     sdk,
+		schema: fwschema.Schema{
+			Description: resp.Provider.Block.Description,
+			Attributes:  attributes,
+			Blocks:      blocks,
+		},
+		resourceSchemas: resp.ResourceSchemas,
+		identitySchemas: identities.IdentitySchemas,
+	}, nil
+}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = "cloudtemple"
+	resp.Version = p.version
+}
+
+func (p *frameworkProvider) Schema(ctx context.Context, req fwprovider.SchemaRequest, resp *fwprovider.SchemaResponse) {
+	resp.Schema = p.schema
+}
+
+// Configure hands the client of the SDKv2 provider, configured just before, to
+// the ephemeral and list resources: the configuration is read, and the API
+// authenticated, only once.
+func (p *frameworkProvider) Configure(ctx context.Context, req fwprovider.ConfigureRequest, resp *fwprovider.ConfigureResponse) {
+	resp.EphemeralResourceData = p.sdk.Meta()
+	resp.ListResourceData = p.sdk.Meta()
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newIAMAccessTokenEphemeralResource,
+	}
+}
+
+// ListResources serves a list resource for each source, on top of the SDKv2
+// resource of the same name.
+func (p *frameworkProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	listResources := make([]func() list.ListResource, 0, len(listResourceSources))
+	for _, source := range listResourceSources {
+		listResources = append(listResources, func() list.ListResource {
+			return &sdkListResource{
+				source:   source,
+				sdk:      p.sdk,
+				schema:   p.resourceSchemas[source.typeName],
+				identity: p.identitySchemas[source.typeName],
+			}
+		})
+	}
+	return listResources
+}
+
+// Functions reuse the parsing helpers of the resources, so that HCL and the
+// provider cannot disagree on an ID.
+func (p *frameworkProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		newVMScopedIDFunction,
+		newParseVMScopedIDFunction,
+		newMemoryBytesFunction,
+		newNormalizeUUIDFunction,
+	}
+}
+
+// frameworkProviderSchema converts the provider block served by SDKv2 into the
+// attributes and blocks of a framework provider schema.
+func frameworkProviderSchema(block *tfprotov5.SchemaBlock) (map[string]fwschema.Attribute, map[string]fwschema.Block, error) {
+	attributes := make(map[string]fwschema.Attribute, len(block.Attributes))
+	for _, a := range block.Attributes {
+		attribute, err := frameworkProviderAttribute(a)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", a.Name, err)
+		}
+		attributes[a.Name] = attribute
+	}
+
+	blocks := make(map[string]fwschema.Block, len(block.BlockTypes))
+	for _, b := range block.BlockTypes {
+		nestedAttributes, nestedBlocks, err := frameworkProviderSchema(b.Block)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s.%w", b.TypeName, err)
+		}
+		object := fwschema.NestedBlockObject{Attributes: nestedAttributes, Blocks: nestedBlocks}
+		description, markdown := descriptions(b.Block.Description, b.Block.DescriptionKind)
+		deprecation := deprecationMessage(b.Block.Deprecated)
+		switch b.Nesting {
+		case tfprotov5.SchemaNestedBlockNestingModeList:
+			blocks[b.TypeName] = fwschema.ListNestedBlock{NestedObject: object, Description: description, MarkdownDescription: markdown, DeprecationMessage: deprecation}
+		case tfprotov5.SchemaNestedBlockNestingModeSet:
+			blocks[b.TypeName] = fwschema.SetNestedBlock{NestedObject: object, Description: description, MarkdownDescription: markdown, DeprecationMessage: deprecation}
+		case tfprotov5.SchemaNestedBlockNestingModeSingle:
+			blocks[b.TypeName] = fwschema.SingleNestedBlock{Attributes: nestedAttributes, Blocks: nestedBlocks, Description: description, MarkdownDescription: markdown, DeprecationMessage: deprecation}
+		default:
+			return nil, nil, fmt.Errorf("%s: unsupported block nesting %s", b.TypeName, b.Nesting)
+		}
+	}
+
+	return attributes, blocks, nil
+}
+
+func frameworkProviderAttribute(a *tfprotov5.SchemaAttribute) (fwschema.Attribute, error) {
+	deprecation := deprecationMessage(a.Deprecated)
+	description, markdown := descriptions(a.Description, a.DescriptionKind)
+
+	switch {
+	case a.Type.Is(tftypes.String):
+		return fwschema.StringAttribute{Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive, Description: description, MarkdownDescription: markdown, DeprecationMessage: deprecation}, nil
+	case a.Type.Is(tftypes.Bool):
+		return fwschema.BoolAttribute{Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive, Description: description, MarkdownDescription: markdown, DeprecationMessage: deprecation}, nil
+	case a.Type.Is(tftypes.Number):
+		return fwschema.NumberAttribute{Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive, Description: description, MarkdownDescription: markdown, DeprecationMessage: deprecation}, nil
+	case a.Type.Is(tftypes.List{}):
+		elem, err := frameworkType(a.Type.(tftypes.List).ElementType)
+		return fwschema.ListAttribute{ElementType: elem, Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive, Description: description, MarkdownDescription: markdown, DeprecationMessage: deprecation}, err
+	case a.Type.Is(tftypes.Set{}):
+		elem, err := frameworkType(a.Type.(tftypes.Set).ElementType)
+		return fwschema.SetAttribute{ElementType: elem, Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive, Description: description, MarkdownDescription: markdown, DeprecationMessage: deprecation}, err
+	case a.Type.Is(tftypes.Map{}):
+		elem, err := frameworkType(a.Type.(tftypes.Map).ElementType)
+		return fwschema.MapAttribute{ElementType: elem, Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive, Description: description, MarkdownDescription: markdown, DeprecationMessage: deprecation}, err
+	}
+	return nil, fmt.Errorf("unsupported attribute type %s", a.Type)
+}
+
+func frameworkType(t tftypes.Type) (attr.Type, error) {
+	switch {
+	case t.Is(tftypes.String):
+		return types.StringType, nil
+	case t.Is(tftypes.Bool):
+		return types.BoolType, nil
+	case t.Is(tftypes.Number):
+		return types.NumberType, nil
+	case t.Is(tftypes.List{}):
+		elem, err := frameworkType(t.(tftypes.List).ElementType)
+		return types.ListType{ElemType: elem}, err
+	case t.Is(tftypes.Set{}):
+		elem, err := frameworkType(t.(tftypes.Set).ElementType)
+		return types.SetType{ElemType: elem}, err
+	case t.Is(tftypes.Map{}):
+		elem, err := frameworkType(t.(tftypes.Map).ElementType)
+		return types.MapType{ElemType: elem}, err
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// descriptions sets a description in the field of its kind, as the framework
+// serves the kind of the field that is set.
+func descriptions(description string, kind tfprotov5.StringKind) (plain, markdown string) {
+	if kind == tfprotov5.StringKindMarkdown {
+		return "", description
+	}
+	return description, ""
+}
+
+// deprecationMessage stands in for the message SDKv2 does not serve: the
+// framework only marks an attribute deprecated when it has one.
+func deprecationMessage(deprecated bool) string {
+	if deprecated {
+		return "Deprecated"
+	}
+	return ""
+}
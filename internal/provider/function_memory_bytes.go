@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// memoryUnits are the binary units accepted by memory_bytes, in bytes.
+var memoryUnits = map[string]int64{
+	"B":   1,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+	"TiB": 1 << 40,
+}
+
+func memoryUnitNames() []string {
+	names := make([]string, 0, len(memoryUnits))
+	for name := range memoryUnits {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return memoryUnits[names[i]] < memoryUnits[names[j]] })
+	return names
+}
+
+// memoryBytes converts a quantity of memory into bytes, the unit of the memory
+// attributes of the virtual machines.
+func memoryBytes(size int64, unit string) (int64, error) {
+	multiplier, ok := memoryUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q, expected one of %s", unit, strings.Join(memoryUnitNames(), ", "))
+	}
+	if size < 0 {
+		return 0, fmt.Errorf("the size must not be negative, got %d", size)
+	}
+	if size > math.MaxInt64/multiplier {
+		return 0, fmt.Errorf("%d %s does not fit in 64 bits", size, unit)
+	}
+	return size * multiplier, nil
+}
+
+type memoryBytesFunction struct{}
+
+var _ function.Function = memoryBytesFunction{}
+
+func newMemoryBytesFunction() function.Function {
+	return memoryBytesFunction{}
+}
+
+func (f memoryBytesFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "memory_bytes"
+}
+
+func (f memoryBytesFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Convert a quantity of memory into bytes",
+		MarkdownDescription: "Converts a quantity of memory into bytes, the unit of the `memory` attribute of the virtual machines, e.g. `memory_bytes(4, \"GiB\")` returns `4294967296`.",
+		Parameters: []function.Parameter{
+			function.Int64Parameter{
+				Name:                "size",
+				MarkdownDescription: "The quantity of memory, in `unit`.",
+			},
+			function.StringParameter{
+				Name:                "unit",
+				MarkdownDescription: fmt.Sprintf("The unit of `size`: one of `%s`.", strings.Join(memoryUnitNames(), "`, `")),
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f memoryBytesFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var size int64
+	var unit string
+	resp.Error = req.Arguments.Get(ctx, &size, &unit)
+	if resp.Error != nil {
+		return
+	}
+
+	bytes, err := memoryBytes(size, unit)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, bytes)
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+type normalizeUUIDFunction struct{}
+
+var _ function.Function = normalizeUUIDFunction{}
+
+func newNormalizeUUIDFunction() function.Function {
+	return normalizeUUIDFunction{}
+}
+
+func (f normalizeUUIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_uuid"
+}
+
+func (f normalizeUUIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Normalize a UUID to the platform's lower-case form",
+		MarkdownDescription: "Returns a UUID in lower case, the form the platform returns. The provider compares UUIDs case-insensitively: normalize an ID written in upper case before comparing it to an attribute in HCL. Fails if the value is not a UUID.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "uuid",
+				MarkdownDescription: "The UUID to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f normalizeUUIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var uuid string
+	resp.Error = req.Arguments.Get(ctx, &uuid)
+	if resp.Error != nil {
+		return
+	}
+
+	if !isUUID(uuid) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("%q is not a valid UUID", uuid))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, strings.ToLower(uuid))
+}
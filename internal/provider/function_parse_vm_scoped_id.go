@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var vmScopedIDAttributeTypes = map[string]attr.Type{
+	"virtual_machine_id": types.StringType,
+	"id":                 types.StringType,
+}
+
+type parseVMScopedIDFunction struct{}
+
+var _ function.Function = parseVMScopedIDFunction{}
+
+func newParseVMScopedIDFunction() function.Function {
+	return parseVMScopedIDFunction{}
+}
+
+func (f parseVMScopedIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_vm_scoped_id"
+}
+
+func (f parseVMScopedIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Split the import ID of a virtual machine child resource",
+		MarkdownDescription: "Splits a composite `<virtual_machine_id>/<id>` import ID into an object with the `virtual_machine_id` and `id` attributes. Fails on any ID the import would reject.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "id",
+				MarkdownDescription: "The composite ID to split.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: vmScopedIDAttributeTypes,
+		},
+	}
+}
+
+func (f parseVMScopedIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	resp.Error = req.Arguments.Get(ctx, &id)
+	if resp.Error != nil {
+		return
+	}
+
+	vmID, childID, err := parseVMScopedID(id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result, diags := types.ObjectValue(vmScopedIDAttributeTypes, map[string]attr.Value{
+		"virtual_machine_id": types.StringValue(vmID),
+		"id":                 types.StringValue(childID),
+	})
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
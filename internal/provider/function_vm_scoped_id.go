@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+type vmScopedIDFunction struct{}
+
+var _ function.Function = vmScopedIDFunction{}
+
+func newVMScopedIDFunction() function.Function {
+	return vmScopedIDFunction{}
+}
+
+func (f vmScopedIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "vm_scoped_id"
+}
+
+func (f vmScopedIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build the import ID of a virtual machine child resource",
+		MarkdownDescription: "Builds the composite `<virtual_machine_id>/<id>` ID taken by the import of the resources attached to a virtual machine (snapshots, network adapters, …). Fails if either part is not a UUID, as the import would.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "virtual_machine_id",
+				MarkdownDescription: "The ID of the virtual machine.",
+			},
+			function.StringParameter{
+				Name:                "id",
+				MarkdownDescription: "The ID of the child resource.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f vmScopedIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var vmID, childID string
+	resp.Error = req.Arguments.Get(ctx, &vmID, &childID)
+	if resp.Error != nil {
+		return
+	}
+
+	// Parse the result back so that the function accepts exactly what the
+	// import accepts.
+	id := formatVMScopedID(vmID, childID)
+	if _, _, err := parseVMScopedID(id); err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, id)
+}
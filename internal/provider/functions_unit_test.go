@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	testVMID    = "6a3c1f6e-2b9d-4c5e-8f70-1a2b3c4d5e6f"
+	testChildID = "0f1e2d3c-4b5a-4968-8776-a5b4c3d2e1f0"
+)
+
+// callFunction calls a provider function through the muxed server, as
+// Terraform does.
+func callFunction(t *testing.T, name string, returnType tftypes.Type, args ...tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+	t.Helper()
+	ctx := context.Background()
+	factory, err := NewProviderServer(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	arguments := make([]*tfprotov5.DynamicValue, 0, len(args))
+	for _, arg := range args {
+		value, err := tfprotov5.NewDynamicValue(arg.Type(), arg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		arguments = append(arguments, &value)
+	}
+
+	resp, err := factory().CallFunction(ctx, &tfprotov5.CallFunctionRequest{Name: name, Arguments: arguments})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error != nil {
+		return tftypes.Value{}, resp.Error
+	}
+	result, err := resp.Result.Unmarshal(returnType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result, nil
+}
+
+func TestProviderServerFunctions(t *testing.T) {
+	ctx := context.Background()
+	factory, err := NewProviderServer(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := factory().GetFunctions(ctx, &tfprotov5.GetFunctionsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireNoErrorDiagnostics(t, resp.Diagnostics)
+
+	for _, name := range []string{"vm_scoped_id", "parse_vm_scoped_id", "memory_bytes", "normalize_uuid"} {
+		if _, ok := resp.Functions[name]; !ok {
+			t.Errorf("function %s is not served", name)
+		}
+	}
+}
+
+func TestVMScopedIDFunction(t *testing.T) {
+	result, funcErr := callFunction(t, "vm_scoped_id", tftypes.String,
+		tftypes.NewValue(tftypes.String, testVMID),
+		tftypes.NewValue(tftypes.String, testChildID),
+	)
+	if funcErr != nil {
+		t.Fatal(funcErr.Text)
+	}
+	var id string
+	if err := result.As(&id); err != nil {
+		t.Fatal(err)
+	}
+	if id != testVMID+"/"+testChildID {
+		t.Errorf("vm_scoped_id = %q", id)
+	}
+
+	// The import would reject this ID, so must the function.
+	if _, funcErr := callFunction(t, "vm_scoped_id", tftypes.String,
+		tftypes.NewValue(tftypes.String, "my-vm"),
+		tftypes.NewValue(tftypes.String, testChildID),
+	); funcErr == nil {
+		t.Error("vm_scoped_id accepted a virtual machine ID that is not a UUID")
+	}
+}
+
+func TestParseVMScopedIDFunction(t *testing.T) {
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"virtual_machine_id": tftypes.String,
+		"id":                 tftypes.String,
+	}}
+
+	result, funcErr := callFunction(t, "parse_vm_scoped_id", objectType,
+		tftypes.NewValue(tftypes.String, testVMID+"/"+testChildID),
+	)
+	if funcErr != nil {
+		t.Fatal(funcErr.Text)
+	}
+	var attributes map[string]tftypes.Value
+	if err := result.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	var vmID, childID string
+	if err := attributes["virtual_machine_id"].As(&vmID); err != nil {
+		t.Fatal(err)
+	}
+	if err := attributes["id"].As(&childID); err != nil {
+		t.Fatal(err)
+	}
+	if vmID != testVMID || childID != testChildID {
+		t.Errorf("parse_vm_scoped_id = %q, %q", vmID, childID)
+	}
+
+	for _, id := range []string{testVMID, testVMID + "/" + testChildID + "/x", "/" + testChildID} {
+		_, funcErr := callFunction(t, "parse_vm_scoped_id", objectType, tftypes.NewValue(tftypes.String, id))
+		if funcErr == nil {
+			t.Errorf("parse_vm_scoped_id(%q) did not fail", id)
+		} else if funcErr.FunctionArgument == nil || *funcErr.FunctionArgument != 0 {
+			t.Errorf("parse_vm_scoped_id(%q) error does not point at the argument", id)
+		}
+	}
+}
+
+func TestMemoryBytes(t *testing.T) {
+	tests := []struct {
+		size    int64
+		unit    string
+		want    int64
+		wantErr bool
+	}{
+		{size: 512, unit: "B", want: 512},
+		{size: 2, unit: "KiB", want: 2048},
+		{size: 512, unit: "MiB", want: 512 << 20},
+		{size: 4, unit: "GiB", want: 4294967296},
+		{size: 1, unit: "TiB", want: 1 << 40},
+		{size: 4, unit: "GB", wantErr: true},
+		{size: 4, unit: "gib", wantErr: true},
+		{size: -1, unit: "GiB", wantErr: true},
+		{size: 1 << 24, unit: "TiB", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := memoryBytes(tt.size, tt.unit)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("memoryBytes(%d, %q) = %d, %v", tt.size, tt.unit, got, err)
+		}
+	}
+
+	result, funcErr := callFunction(t, "memory_bytes", tftypes.Number,
+		tftypes.NewValue(tftypes.Number, 4),
+		tftypes.NewValue(tftypes.String, "GiB"),
+	)
+	if funcErr != nil {
+		t.Fatal(funcErr.Text)
+	}
+	if !result.Equal(tftypes.NewValue(tftypes.Number, 4294967296)) {
+		t.Errorf("memory_bytes(4, \"GiB\") = %s", result)
+	}
+}
+
+func TestNormalizeUUIDFunction(t *testing.T) {
+	result, funcErr := callFunction(t, "normalize_uuid", tftypes.String,
+		tftypes.NewValue(tftypes.String, "6A3C1F6E-2B9D-4C5E-8F70-1A2B3C4D5E6F"),
+	)
+	if funcErr != nil {
+		t.Fatal(funcErr.Text)
+	}
+	var uuid string
+	if err := result.As(&uuid); err != nil {
+		t.Fatal(err)
+	}
+	if uuid != testVMID {
+		t.Errorf("normalize_uuid = %q, want %q", uuid, testVMID)
+	}
+
+	if _, funcErr := callFunction(t, "normalize_uuid", tftypes.String, tftypes.NewValue(tftypes.String, "not-a-uuid")); funcErr == nil {
+		t.Error("normalize_uuid accepted a value that is not a UUID")
+	}
+}
@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -38,3 +39,14 @@ func GetStringList(d *schema.ResourceData, key string) []string {
 	}
 	return stringList
 }
+
+// GetWriteOnlyString returns the value of a write-only string attribute, or ""
+// when it is not set. Write-only values never reach d.Get: they are only in the
+// configuration, which the provider receives while planning and applying.
+func GetWriteOnlyString(d *schema.ResourceData, path cty.Path) string {
+	v, diags := d.GetRawConfigAt(path)
+	if diags.HasError() || v.IsNull() || !v.IsKnown() || !v.Type().Equals(cty.String) {
+		return ""
+	}
+	return v.AsString()
+}
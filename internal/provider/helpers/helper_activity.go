@@ -0,0 +1,55 @@
+package helpers
+
+import (
+	"sort"
+	"time"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+)
+
+// FlattenActivity convertit une Activity en une map compatible avec le schéma
+// Terraform. Les états sont triés par nom pour que la liste soit stable d'un
+// refresh à l'autre (la map de l'API n'a pas d'ordre).
+func FlattenActivity(activity *client.Activity) map[string]interface{} {
+	concernedItems := make([]interface{}, 0, len(activity.ConcernedItems))
+	for _, item := range activity.ConcernedItems {
+		concernedItems = append(concernedItems, map[string]interface{}{
+			"id":   item.ID,
+			"type": item.Type,
+		})
+	}
+
+	names := make([]string, 0, len(activity.State))
+	for name := range activity.State {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	states := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		state := activity.State[name]
+		states = append(states, map[string]interface{}{
+			"name":        name,
+			"start_date":  state.StartDate,
+			"stop_date":   state.StopDate,
+			"reason":      state.Reason,
+			"result":      state.Result,
+			"progression": state.Progression,
+		})
+	}
+
+	creationDate := ""
+	if !activity.CreationDate.IsZero() {
+		creationDate = activity.CreationDate.Format(time.RFC3339)
+	}
+
+	return map[string]interface{}{
+		"id":              activity.ID,
+		"tenant_id":       activity.TenantId,
+		"description":     activity.Description,
+		"type":            activity.Type,
+		"tags":            activity.Tags,
+		"creation_date":   creationDate,
+		"concerned_items": concernedItems,
+		"state":           states,
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -143,14 +144,24 @@ func BuildGuestOSCustomizationRequest(ctx context.Context, d *schema.ResourceDat
 	}
 
 	if len(d.Get("customize.0.windows_config").([]interface{})) > 0 {
+		windowsConfig := cty.GetAttrPath("customize").IndexInt(0).GetAttr("windows_config").IndexInt(0)
+		password := d.Get("customize.0.windows_config.0.password").(string)
+		if password == "" {
+			password = GetWriteOnlyString(d, windowsConfig.GetAttr("password_wo"))
+		}
+		domainAdminPassword := d.Get("customize.0.windows_config.0.domain.0.admin_password").(string)
+		if domainAdminPassword == "" {
+			domainAdminPassword = GetWriteOnlyString(d, windowsConfig.GetAttr("domain").IndexInt(0).GetAttr("admin_password_wo"))
+		}
+
 		customizationRequest.WindowsConfig = &client.CustomGuestWindowsConfig{
 			AutoLogon:           d.Get("customize.0.windows_config.0.auto_logon").(bool),
 			AutoLogonCount:      d.Get("customize.0.windows_config.0.auto_logon_count").(int),
 			TimeZone:            d.Get("customize.0.windows_config.0.timezone").(int),
-			Password:            d.Get("customize.0.windows_config.0.password").(string),
+			Password:            password,
 			JoinDomain:          d.Get("customize.0.windows_config.0.domain.0.name").(string),
 			DomainAdmin:         d.Get("customize.0.windows_config.0.domain.0.admin_username").(string),
-			DomainAdminPassword: d.Get("customize.0.windows_config.0.domain.0.admin_password").(string),
+			DomainAdminPassword: domainAdminPassword,
 		}
 	}
 
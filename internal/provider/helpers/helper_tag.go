@@ -0,0 +1,18 @@
+package helpers
+
+import (
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+)
+
+// FlattenTags convertit les tags d'un objet en une map clé/valeur compatible
+// avec un attribut Terraform de type TypeMap.
+func FlattenTags(tags []*client.Tag) map[string]interface{} {
+	tagsMap := make(map[string]interface{}, len(tags))
+	for _, tag := range tags {
+		if tag == nil {
+			continue
+		}
+		tagsMap[tag.Key] = tag.Value
+	}
+	return tagsMap
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	sdkdiag "github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// ImportState imports the object of the resource typeName by its import ID and
+// reads it, as `terraform import` does, and returns the resulting state: the
+// one the next plan compares the configuration with. The value is null when the
+// object does not exist. p must be configured, its meta being the
+// *client.Client to read with (see schema.Provider.SetMeta).
+func ImportState(ctx context.Context, p *schema.Provider, typeName, id string) (cty.Value, error) {
+	return diagnosticsError(importResource(ctx, p, typeName, id, nil))
+}
+
+// ImportStateByIdentity is ImportState for an import by resource identity
+// (`identity = { … }` in an import block), the only one some resources, such
+// as cloudtemple_object_storage_bucket, can be read back from.
+func ImportStateByIdentity(ctx context.Context, p *schema.Provider, typeName string, identity map[string]string) (cty.Value, error) {
+	return diagnosticsError(importResource(ctx, p, typeName, "", identity))
+}
+
+// diagnosticsError joins the errors of diags, dropping the warnings.
+func diagnosticsError(value cty.Value, diags sdkdiag.Diagnostics) (cty.Value, error) {
+	if !diags.HasError() {
+		return value, nil
+	}
+	var errs []string
+	for _, d := range diags {
+		if d.Severity == sdkdiag.Error {
+			errs = append(errs, strings.TrimSuffix(d.Summary+": "+d.Detail, ": "))
+		}
+	}
+	return cty.NilVal, errors.New(strings.Join(errs, "; "))
+}
+
+// VMScopedImportID returns the composite import ID of a VM-scoped child
+// resource (see parseVMScopedID).
+func VMScopedImportID(vmID, childID string) string {
+	return formatVMScopedID(vmID, childID)
+}
+
+// importResource imports the object by its import ID, or by its identity when
+// identity is set, then reads it. The value is null when the object is gone.
+func importResource(ctx context.Context, p *schema.Provider, typeName, id string, identity map[string]string) (cty.Value, sdkdiag.Diagnostics) {
+	res, ok := p.ResourcesMap[typeName]
+	if !ok {
+		return cty.NilVal, sdkdiag.Errorf("unknown resource type %s", typeName)
+	}
+	ty := res.CoreConfigSchema().ImpliedType()
+
+	info := &terraform.InstanceInfo{Type: typeName}
+	var states []*terraform.InstanceState
+	var err error
+	if identity != nil {
+		states, err = p.ImportStateWithIdentity(ctx, info, id, identity)
+	} else {
+		states, err = p.ImportState(ctx, info, id)
+	}
+	if err != nil {
+		return cty.NilVal, sdkdiag.FromErr(err)
+	}
+	if len(states) == 0 {
+		return cty.NullVal(ty), nil
+	}
+
+	state, diags := res.RefreshWithoutUpgrade(ctx, states[0], p.Meta())
+	if diags.HasError() {
+		return cty.NilVal, diags
+	}
+	if state == nil || state.ID == "" {
+		return cty.NullVal(ty), diags
+	}
+
+	value, err := schema.StateValueFromInstanceState(state, ty)
+	if err != nil {
+		return cty.NilVal, append(diags, sdkdiag.Diagnostic{
+			Severity: sdkdiag.Error,
+			Summary:  fmt.Sprintf("failed to decode the state of %s %s", typeName, state.ID),
+			Detail:   err.Error(),
+		})
+	}
+	return value, diags
+}
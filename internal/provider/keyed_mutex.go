@@ -1,6 +1,9 @@
 package provider
 
-import "sync"
+import (
+	"slices"
+	"sync"
+)
 
 // keyedMutex serializes work per string key WITHIN THIS PROCESS. Terraform
 // applies sibling resources concurrently (default parallelism 10), so several
@@ -37,5 +40,34 @@ func (k *keyedMutex) lock(key string) func() {
 	return lock.Unlock
 }
 
+// lockAll acquires the locks of several keys, e.g. the source and the target
+// virtual machines of a disk move. The keys are locked in sorted order, so two
+// callers locking the same keys never deadlock, and a repeated key once.
+func (k *keyedMutex) lockAll(keys ...string) func() {
+	keys = slices.Clone(keys)
+	slices.Sort(keys)
+	keys = slices.Compact(keys)
+
+	unlocks := make([]func(), 0, len(keys))
+	for _, key := range keys {
+		unlocks = append(unlocks, k.lock(key))
+	}
+	return func() {
+		for i := len(unlocks) - 1; i >= 0; i-- {
+			unlocks[i]()
+		}
+	}
+}
+
 // publicCloudVMInstanceMutex serializes VM-scoped writes keyed by the VM id.
 var publicCloudVMInstanceMutex = newKeyedMutex()
+
+// vmwareVirtualMachineMutex serializes VMware VM-scoped writes (snapshot
+// create / revert / delete) keyed by the VM id.
+var vmwareVirtualMachineMutex = newKeyedMutex()
+
+// openIaasVirtualMachineMutex serializes OpenIaaS VM-scoped writes (VM update /
+// power / delete, virtual disk create / update / move / delete, network adapter
+// create / update / delete, snapshot create / revert / delete) keyed by the VM
+// id.
+var openIaasVirtualMachineMutex = newKeyedMutex()
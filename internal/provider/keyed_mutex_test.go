@@ -57,3 +57,43 @@ func TestKeyedMutexDifferentKeysDoNotBlock(t *testing.T) {
 		t.Fatal("locks on different keys must not block each other")
 	}
 }
+
+// TestKeyedMutexLockAll pins the disk move lock: both virtual machines are
+// held, callers naming them in opposite orders do not deadlock, and a
+// repeated key (a disk not moving) is locked once.
+func TestKeyedMutexLockAll(t *testing.T) {
+	km := newKeyedMutex()
+	km.lockAll("vm-1", "vm-1")()
+
+	unlock := km.lockAll("vm-2", "vm-1")
+	for _, key := range []string{"vm-1", "vm-2"} {
+		acquired := make(chan struct{})
+		go func() {
+			km.lock(key)()
+			close(acquired)
+		}()
+		select {
+		case <-acquired:
+			t.Fatalf("%s must stay locked while lockAll holds it", key)
+		case <-time.After(50 * time.Millisecond):
+		}
+		defer func() { <-acquired }()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for range 100 {
+			km.lockAll("vm-1", "vm-2")()
+		}
+		close(done)
+	}()
+	unlock()
+	for range 100 {
+		km.lockAll("vm-2", "vm-1")()
+	}
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("lockAll callers naming the keys in opposite orders must not deadlock")
+	}
+}
@@ -0,0 +1,386 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	sdkdiag "github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// listedObject is an object found by a list resource: the value of the
+// identity of its resource, its name, and its tags when the listing carries
+// them (nil otherwise: they are then read through the tag API, and only when
+// filtering by tag).
+type listedObject struct {
+	identity string
+	name     string
+	tags     []*client.Tag
+}
+
+// listResourceSource lists the objects of a resource for `terraform query`.
+// The listing is always a strict one: a partial answer is an error, not a
+// shorter result.
+type listResourceSource struct {
+	typeName    string
+	description string
+	// filters are the filters specific to the source, passed to list.
+	filters map[string]listschema.StringAttribute
+	list    func(ctx context.Context, c *client.Client, filters map[string]string) ([]listedObject, error)
+}
+
+// The filters every list resource supports, applied to the listed objects.
+var commonListFilters = map[string]listschema.StringAttribute{
+	"name_regex": {
+		Optional:            true,
+		MarkdownDescription: "A regular expression the name of the objects must match, e.g. `^web-`.",
+	},
+	"tag_key": {
+		Optional:            true,
+		MarkdownDescription: "Only list the objects carrying a tag with this key.",
+	},
+	"tag_value": {
+		Optional:            true,
+		MarkdownDescription: "Only list the objects whose `tag_key` tag has this value. Requires `tag_key`.",
+	},
+}
+
+var listResourceSources = []listResourceSource{
+	{
+		typeName:    "cloudtemple_compute_virtual_machine",
+		description: "Lists the virtual machines of the VMware infrastructure, to import them with `terraform query`.",
+		filters: map[string]listschema.StringAttribute{
+			"machine_manager_id": {Optional: true, MarkdownDescription: "Only list the virtual machines of this machine manager (vCenter)."},
+			"datacenter_id":      {Optional: true, MarkdownDescription: "Only list the virtual machines of this datacenter."},
+			"host_cluster_id":    {Optional: true, MarkdownDescription: "Only list the virtual machines of this host cluster."},
+		},
+		list: func(ctx context.Context, c *client.Client, filters map[string]string) ([]listedObject, error) {
+			filter := &client.VirtualMachineFilter{MachineManagerID: filters["machine_manager_id"]}
+			if id := filters["datacenter_id"]; id != "" {
+				filter.Datacenters = []string{id}
+			}
+			if id := filters["host_cluster_id"]; id != "" {
+				filter.HostClusters = []string{id}
+			}
+			vms, err := c.Compute().VirtualMachine().ListStrict(ctx, filter)
+			if err != nil {
+				return nil, err
+			}
+			objects := make([]listedObject, 0, len(vms))
+			for _, vm := range vms {
+				objects = append(objects, listedObject{identity: vm.ID, name: vm.Name})
+			}
+			return objects, nil
+		},
+	},
+	{
+		typeName:    "cloudtemple_compute_iaas_opensource_virtual_machine",
+		description: "Lists the virtual machines of the Open IaaS infrastructure, to import them with `terraform query`.",
+		filters: map[string]listschema.StringAttribute{
+			"machine_manager_id": {Optional: true, MarkdownDescription: "Only list the virtual machines of this machine manager."},
+			"pool_id":            {Optional: true, MarkdownDescription: "Only list the virtual machines of this pool."},
+			"host_id":            {Optional: true, MarkdownDescription: "Only list the virtual machines running on this host."},
+		},
+		list: func(ctx context.Context, c *client.Client, filters map[string]string) ([]listedObject, error) {
+			vms, err := c.Compute().OpenIaaS().VirtualMachine().ListStrict(ctx, &client.OpenIaaSVirtualMachineFilter{
+				MachineManagerID: filters["machine_manager_id"],
+			})
+			if err != nil {
+				return nil, err
+			}
+			objects := make([]listedObject, 0, len(vms))
+			for _, vm := range vms {
+				if id := filters["pool_id"]; id != "" && !sameUUID(vm.Pool.ID, id) {
+					continue
+				}
+				if id := filters["host_id"]; id != "" && !sameUUID(vm.Host.ID, id) {
+					continue
+				}
+				objects = append(objects, listedObject{identity: vm.ID, name: vm.Name})
+			}
+			return objects, nil
+		},
+	},
+	{
+		typeName:    "cloudtemple_public_cloud_vm_instance",
+		description: "Lists the public cloud virtual machine instances, to import them with `terraform query`.",
+		filters: map[string]listschema.StringAttribute{
+			"availability_zone_id": {Optional: true, MarkdownDescription: "Only list the instances of this availability zone."},
+		},
+		list: func(ctx context.Context, c *client.Client, filters map[string]string) ([]listedObject, error) {
+			instances, err := c.PublicCloudVM().Instance().ListStrict(ctx, &client.PublicCloudVMInstanceFilter{
+				AvailabilityZoneID: filters["availability_zone_id"],
+			})
+			if err != nil {
+				return nil, err
+			}
+			objects := make([]listedObject, 0, len(instances))
+			for _, instance := range instances {
+				objects = append(objects, listedObject{identity: instance.ID, name: instance.Name})
+			}
+			return objects, nil
+		},
+	},
+	// The bucket and storage account listings carry the tags.
+	{
+		typeName:    "cloudtemple_object_storage_bucket",
+		description: "Lists the object storage buckets, to import them with `terraform query`.",
+		list: func(ctx context.Context, c *client.Client, filters map[string]string) ([]listedObject, error) {
+			buckets, err := c.ObjectStorage().Bucket().ListStrict(ctx)
+			if err != nil {
+				return nil, err
+			}
+			objects := make([]listedObject, 0, len(buckets))
+			for _, bucket := range buckets {
+				tags := make([]*client.Tag, 0, len(bucket.Tags))
+				for _, tag := range bucket.Tags {
+					tags = append(tags, &client.Tag{Key: tag.Key, Value: tag.Value})
+				}
+				objects = append(objects, listedObject{identity: bucket.Name, name: bucket.Name, tags: tags})
+			}
+			return objects, nil
+		},
+	},
+	{
+		typeName:    "cloudtemple_object_storage_storage_account",
+		description: "Lists the object storage storage accounts, to import them with `terraform query`.",
+		list: func(ctx context.Context, c *client.Client, filters map[string]string) ([]listedObject, error) {
+			accounts, err := c.ObjectStorage().StorageAccount().ListStrict(ctx)
+			if err != nil {
+				return nil, err
+			}
+			objects := make([]listedObject, 0, len(accounts))
+			for _, account := range accounts {
+				tags := make([]*client.Tag, 0, len(account.Tags))
+				for _, tag := range account.Tags {
+					tags = append(tags, &client.Tag{Key: tag.Key, Value: tag.Value})
+				}
+				objects = append(objects, listedObject{identity: account.Name, name: account.Name, tags: tags})
+			}
+			return objects, nil
+		},
+	},
+}
+
+// sdkListResource serves a list resource for a resource of the SDKv2
+// provider. The resource returned with each result is built the way an import
+// by identity builds it: through the importer then the read of the resource,
+// so that the generated configuration matches what the provider would plan.
+type sdkListResource struct {
+	source   listResourceSource
+	sdk      *schema.Provider
+	schema   *tfprotov5.Schema
+	identity *tfprotov5.ResourceIdentitySchema
+	client   *client.Client
+}
+
+var (
+	_ list.ListResourceWithConfigure    = &sdkListResource{}
+	_ list.ListResourceWithRawV5Schemas = &sdkListResource{}
+)
+
+func (r *sdkListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = r.source.typeName
+}
+
+func (r *sdkListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	attributes := map[string]listschema.Attribute{}
+	for name, attribute := range commonListFilters {
+		attributes[name] = attribute
+	}
+	for name, attribute := range r.source.filters {
+		attributes[name] = attribute
+	}
+
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: r.source.description,
+		Attributes:          attributes,
+	}
+}
+
+func (r *sdkListResource) RawV5Schemas(ctx context.Context, req list.RawV5SchemaRequest, resp *list.RawV5SchemaResponse) {
+	resp.ProtoV5Schema = r.schema
+	resp.ProtoV5IdentitySchema = r.identity
+}
+
+func (r *sdkListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// The provider is not configured yet during the validation of the
+	// configuration.
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *client.Client, got %T.", req.ProviderData))
+		return
+	}
+	r.client = c
+}
+
+func (r *sdkListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	if r.client == nil {
+		stream.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{
+			diag.NewErrorDiagnostic("Provider not configured", fmt.Sprintf("The provider must be configured before listing %s.", r.source.typeName)),
+		})
+		return
+	}
+
+	filters, diags := r.filters(ctx, req)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if pattern := filters["name_regex"]; pattern != "" {
+		var err error
+		if nameRegex, err = regexp.Compile(pattern); err != nil {
+			diags.AddAttributeError(path.Root("name_regex"), "Invalid name_regex", err.Error())
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+	}
+	if filters["tag_value"] != "" && filters["tag_key"] == "" {
+		diags.AddAttributeError(path.Root("tag_value"), "Missing tag_key", "tag_value only filters the value of the tag_key tag.")
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	objects, err := r.source.list(ctx, r.client, filters)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to list the %s objects", r.source.typeName), err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].name < objects[j].name })
+
+	if nameRegex != nil {
+		objects = slices.DeleteFunc(objects, func(object listedObject) bool { return !nameRegex.MatchString(object.name) })
+	}
+	if key := filters["tag_key"]; key != "" {
+		if err := r.readTags(ctx, objects); err != nil {
+			diags.AddError(fmt.Sprintf("Failed to read the tags of the %s objects", r.source.typeName), err.Error())
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+		objects = slices.DeleteFunc(objects, func(object listedObject) bool { return !hasTag(object, key, filters["tag_value"]) })
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var pushed int64
+		for _, object := range objects {
+			if req.Limit > 0 && pushed >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = object.name
+			result.Diagnostics.Append(result.Identity.SetAttribute(ctx, path.Root(r.identityAttribute()), object.identity)...)
+			if req.IncludeResource && !result.Diagnostics.HasError() {
+				r.readResource(ctx, object, &result)
+			}
+
+			pushed++
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// filters reads the filters of the configuration, empty when not set.
+func (r *sdkListResource) filters(ctx context.Context, req list.ListRequest) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	filters := map[string]string{}
+	for _, attributes := range []map[string]listschema.StringAttribute{commonListFilters, r.source.filters} {
+		for name := range attributes {
+			var value types.String
+			diags.Append(req.Config.GetAttribute(ctx, path.Root(name), &value)...)
+			filters[name] = value.ValueString()
+		}
+	}
+	return filters, diags
+}
+
+func (r *sdkListResource) identityAttribute() string {
+	return r.identity.IdentityAttributes[0].Name
+}
+
+// readTags reads the tags of the objects whose listing does not carry them,
+// with the bounded parallel reads of cloudtemple_resources_by_tag. As there,
+// any failed read, a 404 included, is an error: a missing object would be a
+// silently dropped match.
+func (r *sdkListResource) readTags(ctx context.Context, objects []listedObject) error {
+	tagged := make([]taggedObject, len(objects))
+	for i, object := range objects {
+		tagged[i] = taggedObject{id: object.identity, name: object.name, tags: object.tags}
+	}
+	if err := readResourcesByTagTags(ctx, r.client, tagged); err != nil {
+		return err
+	}
+	for i := range objects {
+		objects[i].tags = tagged[i].tags
+	}
+	return nil
+}
+
+// hasTag tells whether the object carries the tag, as cloudtemple_resources_by_tag
+// does.
+func hasTag(object listedObject, key, value string) bool {
+	for _, tag := range object.tags {
+		if tag != nil && tag.Key == key && (value == "" || tag.Value == value) {
+			return true
+		}
+	}
+	return false
+}
+
+// readResource imports the object by its identity and reads it, as `terraform
+// import` would, and sets the resource of the result.
+func (r *sdkListResource) readResource(ctx context.Context, object listedObject, result *list.ListResult) {
+	summary := fmt.Sprintf("Failed to read %s %s", r.source.typeName, object.identity)
+
+	value, readDiags := importResource(ctx, r.sdk, r.source.typeName, "", map[string]string{
+		r.identityAttribute(): object.identity,
+	})
+	for _, d := range readDiags {
+		if d.Severity == sdkdiag.Error {
+			result.Diagnostics.AddError(summary, d.Summary+": "+d.Detail)
+		} else {
+			result.Diagnostics.AddWarning(d.Summary, d.Detail)
+		}
+	}
+	if result.Diagnostics.HasError() {
+		return
+	}
+	if value.IsNull() {
+		// Deleted between the listing and the read.
+		result.Diagnostics.AddWarning(summary, "The object no longer exists.")
+		return
+	}
+
+	packed, err := msgpack.Marshal(value, value.Type())
+	if err != nil {
+		result.Diagnostics.AddError(summary, err.Error())
+		return
+	}
+	raw, err := (&tfprotov5.DynamicValue{MsgPack: packed}).Unmarshal(r.schema.ValueType())
+	if err != nil {
+		result.Diagnostics.AddError(summary, err.Error())
+		return
+	}
+	result.Resource.Raw = raw
+}
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestListResourcesServed: each list resource is served on top of the SDKv2
+// resource of the same name, which must declare an identity.
+func TestListResourcesServed(t *testing.T) {
+	ctx := context.Background()
+	factory, err := NewProviderServer(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := factory()
+
+	resp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireNoErrorDiagnostics(t, resp.Diagnostics)
+
+	identities, err := server.GetResourceIdentitySchemas(ctx, &tfprotov5.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireNoErrorDiagnostics(t, identities.Diagnostics)
+
+	for _, source := range listResourceSources {
+		if _, ok := resp.ListResourceSchemas[source.typeName]; !ok {
+			t.Errorf("list resource %s is not served", source.typeName)
+		}
+		if _, ok := identities.IdentitySchemas[source.typeName]; !ok {
+			t.Errorf("resource %s declares no identity", source.typeName)
+		}
+	}
+}
+
+// TestStorageAccountListResource lists the storage accounts through the
+// protocol, as `terraform query` does: the filters apply, and the resource of
+// each result is the one the import would read.
+func TestStorageAccountListResource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/iam/v2/auth/personal_access_token"):
+			token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+				"exp": float64(time.Now().Add(time.Hour).Unix()),
+			}).SignedString([]byte("test-secret"))
+			if err != nil {
+				t.Error(err)
+			}
+			fmt.Fprint(w, token)
+		case r.URL.Path == "/storage/object/v1/storage_accounts":
+			fmt.Fprint(w, `[
+				{"id": "1", "name": "app-prod", "tags": [{"key": "env", "value": "prod"}]},
+				{"id": "2", "name": "app-dev", "tags": [{"key": "env", "value": "dev"}]},
+				{"id": "3", "name": "backup-prod", "tags": [{"key": "env", "value": "prod"}]}
+			]`)
+		case r.URL.Path == "/storage/object/v1/storage_accounts/app-prod":
+			fmt.Fprint(w, `{"id": "1", "name": "app-prod", "accessKeyId": "AKIA", "tags": [{"key": "env", "value": "prod"}]}`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := context.Background()
+	factory, err := NewProviderServer(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := factory()
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	configured, err := server.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{
+		Config: providerConfig(t, schemas.Provider, map[string]tftypes.Value{
+			"address":    tftypes.NewValue(tftypes.String, srv.URL),
+			"api_suffix": tftypes.NewValue(tftypes.Bool, false),
+			"client_id":  tftypes.NewValue(tftypes.String, "id"),
+			"secret_id":  tftypes.NewValue(tftypes.String, "secret"),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireNoErrorDiagnostics(t, configured.Diagnostics)
+
+	const typeName = "cloudtemple_object_storage_storage_account"
+	stream, err := server.(tfprotov5.ProviderServerWithListResource).ListResource(ctx, &tfprotov5.ListResourceRequest{
+		TypeName: typeName,
+		Config: providerConfig(t, schemas.ListResourceSchemas[typeName], map[string]tftypes.Value{
+			"name_regex": tftypes.NewValue(tftypes.String, "^app-"),
+			"tag_key":    tftypes.NewValue(tftypes.String, "env"),
+			"tag_value":  tftypes.NewValue(tftypes.String, "prod"),
+		}),
+		IncludeResource: true,
+		Limit:           100,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var results []tfprotov5.ListResourceResult
+	for result := range stream.Results {
+		requireNoErrorDiagnostics(t, result.Diagnostics)
+		results = append(results, result)
+	}
+	if len(results) != 1 || results[0].DisplayName != "app-prod" {
+		t.Fatalf("got %d results (%v), want only app-prod", len(results), results)
+	}
+
+	identitySchemas, err := server.GetResourceIdentitySchemas(ctx, &tfprotov5.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity, err := results[0].Identity.IdentityData.Unmarshal(identitySchemas.IdentitySchemas[typeName].ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var identityAttributes map[string]tftypes.Value
+	if err := identity.As(&identityAttributes); err != nil {
+		t.Fatal(err)
+	}
+	if !identityAttributes["name"].Equal(tftypes.NewValue(tftypes.String, "app-prod")) {
+		t.Errorf("identity = %s, want the name", identity)
+	}
+
+	resourceSchema := schemas.ResourceSchemas[typeName]
+	resource, err := results[0].Resource.Unmarshal(resourceSchema.ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var attributes map[string]tftypes.Value
+	if err := resource.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{"id": "app-prod", "name": "app-prod", "access_key_id": "AKIA"} {
+		if !attributes[name].Equal(tftypes.NewValue(tftypes.String, want)) {
+			t.Errorf("%s = %s, want %q", name, attributes[name], want)
+		}
+	}
+}
+
+// TestListResourceReadTagsFailsOnAnUnreadTag checks that the tag filter of the
+// list resources, like cloudtemple_resources_by_tag, never drops an object
+// whose tags it could not read.
+func TestListResourceReadTagsFailsOnAnUnreadTag(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		status int
+	}{
+		{"not found", http.StatusNotFound},
+		{"failure", http.StatusInternalServerError},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newAssignTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/tag/v1/tags/resources/vm-2"):
+					w.WriteHeader(tc.status)
+					_, _ = w.Write([]byte(`[]`))
+				case strings.Contains(r.URL.Path, "/tag/v1/tags/resources/"):
+					_, _ = w.Write([]byte(`[{"key":"env","value":"prod"}]`))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+			})
+
+			r := &sdkListResource{client: c}
+			objects := []listedObject{{identity: "vm-1", name: "web"}, {identity: "vm-2", name: "api"}}
+			if err := r.readTags(context.Background(), objects); err == nil {
+				t.Fatalf("an unread tag must fail the listing, got the tags %v and %v", objects[0].tags, objects[1].tags)
+			}
+		})
+	}
+
+	t.Run("read", func(t *testing.T) {
+		var reads atomic.Int32
+		c := newAssignTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			reads.Add(1)
+			_, _ = w.Write([]byte(`[{"key":"env","value":"prod"}]`))
+		})
+
+		r := &sdkListResource{client: c}
+		objects := []listedObject{
+			{identity: "vm-1", name: "web"},
+			{identity: "vm-2", name: "api", tags: []*client.Tag{{Key: "env", Value: "dev"}}},
+		}
+		if err := r.readTags(context.Background(), objects); err != nil {
+			t.Fatal(err)
+		}
+		if n := reads.Load(); n != 1 {
+			t.Errorf("%d tag reads, want only the object the listing left without tags", n)
+		}
+		if !hasTag(objects[0], "env", "prod") || hasTag(objects[1], "env", "prod") {
+			t.Errorf("tags = %v and %v", objects[0].tags, objects[1].tags)
+		}
+	})
+}
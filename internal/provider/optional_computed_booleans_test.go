@@ -24,7 +24,7 @@ var optionalComputedBooleanGuards = map[string]string{
 	"cloudtemple_compute_iaas_opensource_virtual_machine.os_disk.connected":                  "UNGATED-LEGACY: reconciled against live state in handleUpdateOSDevices; connect/disconnect paths use live VBD evidence",
 	"cloudtemple_compute_iaas_opensource_virtual_machine.os_network_adapter.attached":        "deprecated attribute, never written from the VM path",
 	"cloudtemple_compute_iaas_opensource_virtual_machine.os_network_adapter.tx_checksumming": "raw-config gated through osAdapterTxConfigured (map[string]*bool)",
-	"cloudtemple_compute_iaas_opensource_network_adapter.tx_checksumming":                    "raw-config gated in openIaasNetworkAdapterUpdate (txConfigured)",
+	"cloudtemple_compute_iaas_opensource_network_adapter.tx_checksumming":                    "raw-config gated in updateOpenIaasNetworkAdapter (txConfigured)",
 	"cloudtemple_compute_virtual_machine.boot_options.enter_bios_setup":                      "raw-config gated in buildVMwareBootOptionsFromRaw (Lot D)",
 	"cloudtemple_compute_virtual_machine.boot_options.boot_retry_enabled":                    "raw-config gated in buildVMwareBootOptionsFromRaw (Lot D)",
 	"cloudtemple_compute_virtual_machine.boot_options.efi_secure_boot_enabled":               "raw-config gated in buildVMwareBootOptionsFromRaw (Lot D)",
@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Resumable creates. An async create POSTs, then blocks on its activity. When the
+// apply is interrupted or its timeout fires during that wait, the object keeps
+// being created platform-side, but nothing of it reached the state and the next
+// apply POSTed a second one. The create now records the pending activity in the
+// resource id instead (pendingCreatePrefix + activityID), and the next refresh,
+// update or delete resumes waiting on that activity and adopts the created id.
+//
+// An interrupted create returns a WARNING, not an error: SDKv2 taints a
+// resource whose create errored, and a tainted resource is planned for
+// replacement. The resources with an identity are the exception, as the
+// placeholder is no identity (see setIdentityAfterCreate). A timeout is an
+// error all the same: the apply did not deliver what was asked within the time
+// the configuration allows, and must not report success. The placeholder is
+// kept, and the error says to untaint the resource to resume the wait rather
+// than replace it.
+//
+// The resources whose create goes on once the activity completes (guest OS
+// customization, backup policies, tags, power state...) run those steps on the
+// object they adopt, see resumeAndFinishPendingCreate.
+const pendingCreatePrefix = "pending-activity:"
+
+func pendingCreateID(activityID string) string {
+	return pendingCreatePrefix + activityID
+}
+
+// pendingCreateActivityID returns the activity recorded by an interrupted create,
+// if id is such a placeholder.
+func pendingCreateActivityID(id string) (string, bool) {
+	return strings.CutPrefix(id, pendingCreatePrefix)
+}
+
+// waitInterrupted reports whether a failed activity wait was cut short by the
+// apply (interruption or timeout) rather than failed by the platform: the
+// activity may still complete and must not be forgotten.
+func waitInterrupted(ctx context.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+	return ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// waitTimedOut reports whether an interrupted wait was cut short by a timeout
+// (the operation's or a waiter's *client.WaitTimeoutError) rather than by the
+// interruption of the apply.
+func waitTimedOut(ctx context.Context, err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded)
+}
+
+// pendingCreateError carries the activity of a create whose wait failed, for the
+// create funcs that resolve the id themselves (the caller cannot see the
+// activity otherwise).
+type pendingCreateError struct {
+	activityID string
+	err        error
+}
+
+func (e *pendingCreateError) Error() string { return e.err.Error() }
+func (e *pendingCreateError) Unwrap() error { return e.err }
+
+// recordPendingCreate keeps an interrupted create in the state as a pending
+// activity placeholder. It warns that the next run resumes it, or errors when the
+// wait timed out.
+func recordPendingCreate(ctx context.Context, d *schema.ResourceData, label, activityID string, err error) diag.Diagnostics {
+	d.SetId(pendingCreateID(activityID))
+	if waitTimedOut(ctx, err) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Timed out waiting for the %s creation (activity %q)", label, activityID),
+			Detail: fmt.Sprintf(
+				"%s. The activity is recorded in the state, but Terraform taints a resource whose creation failed: untaint it (terraform untaint) so that the next plan or apply resumes waiting on the activity and adopts the created %s, instead of replacing it.",
+				err, label,
+			),
+		}}
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("The %s creation is still in progress (activity %q)", label, activityID),
+		Detail: fmt.Sprintf(
+			"Waiting for the create activity was interrupted: %s. The activity is recorded in the state: the next plan or apply resumes waiting on it and adopts the created %s instead of creating a second one.",
+			err, label,
+		),
+	}}
+}
+
+// pendingCreateResolver waits for a create activity and returns the created id.
+// It must fail rather than return an empty id.
+type pendingCreateResolver func(ctx context.Context, activityID string) (string, error)
+
+// resumePendingCreate resolves a pending create recorded by recordPendingCreate.
+// It returns stop=false when the caller can proceed with d.Id(): the id was never
+// pending, or the created id was just adopted. Otherwise (stop=true) the caller
+// returns the diagnostics as is:
+//   - the wait was interrupted again -> keep the placeholder, warn (error on a
+//     timeout);
+//   - the activity failed -> nothing was created, drop the resource (SetId("")) so
+//     the next apply creates it again, warn;
+//   - any other error (read failure, no id) -> keep the placeholder, error. The
+//     activity is not proven failed, so dropping it could orphan the object.
+func resumePendingCreate(ctx context.Context, d *schema.ResourceData, label string, resolve pendingCreateResolver) (diags diag.Diagnostics, stop bool) {
+	activityID, pending := pendingCreateActivityID(d.Id())
+	if !pending {
+		return nil, false
+	}
+
+	id, err := resolve(ctx, activityID)
+	switch {
+	case err == nil && id != "":
+		d.SetId(id)
+		return nil, false
+	case err == nil:
+		return diag.Errorf("the %s create activity %q completed without reporting an id; refusing to guess it. Import the %s if it was created, then remove this resource from the state.", label, activityID, label), true
+	case waitInterrupted(ctx, err) && waitTimedOut(ctx, err):
+		return diag.Errorf("timed out waiting for the %s creation (activity %q): %s. The pending activity is kept in the state; the next plan or apply resumes waiting on it.", label, activityID, err), true
+	case waitInterrupted(ctx, err):
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("The %s creation is still in progress (activity %q)", label, activityID),
+			Detail:   fmt.Sprintf("Waiting for the create activity was interrupted again: %s. The next plan or apply resumes it.", err),
+		}}, true
+	case client.IsFailedActivity(err):
+		d.SetId("")
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("The pending %s creation failed (activity %q)", label, activityID),
+			Detail:   fmt.Sprintf("%s\n\nThe resource was removed from the state; the next apply creates it again.", err),
+		}}, true
+	default:
+		return diag.Errorf("failed to resume the pending %s creation (activity %q): %s. The pending activity is kept in the state; resolve the error, then refresh.", label, activityID, err), true
+	}
+}
+
+// resumePendingCreateForWrite is resumePendingCreate for the update and delete
+// paths (reached without a refresh, e.g. -refresh=false): a creation still in
+// progress cannot be written to, so it is an error rather than a warning. A
+// failed creation leaves nothing to write, and its warning is returned as is.
+func resumePendingCreateForWrite(ctx context.Context, d *schema.ResourceData, label string, resolve pendingCreateResolver) (diag.Diagnostics, bool) {
+	diags, stop := resumePendingCreate(ctx, d, label, resolve)
+	if !stop || d.Id() == "" || diags.HasError() {
+		return diags, stop
+	}
+	return append(diags, diag.Errorf("the %s cannot be modified while its creation is still in progress; retry once the create activity completes", label)...), true
+}
+
+// pendingCreateResume is resumePendingCreate or resumePendingCreateForWrite.
+type pendingCreateResume func(ctx context.Context, d *schema.ResourceData, label string, resolve pendingCreateResolver) (diag.Diagnostics, bool)
+
+// pendingCreateFinisher runs, on the object adopted from an interrupted create,
+// the create steps that follow the activity and that the create skipped. It
+// must not rely on d.HasChange: a refresh has no changes.
+type pendingCreateFinisher func(ctx context.Context) diag.Diagnostics
+
+// resumeAndFinishPendingCreate is resume for the resources whose create goes on
+// once its activity completes: when the created id was just adopted, finish runs
+// the create steps the interrupted create skipped. If they fail, the placeholder
+// is put back, so that the next run adopts the object again and retries them
+// rather than leave them undone without a diff to show for it.
+func resumeAndFinishPendingCreate(ctx context.Context, d *schema.ResourceData, label string, resume pendingCreateResume, resolve pendingCreateResolver, finish pendingCreateFinisher) (diag.Diagnostics, bool) {
+	activityID, pending := pendingCreateActivityID(d.Id())
+	diags, stop := resume(ctx, d, label, resolve)
+	if stop || !pending {
+		return diags, stop
+	}
+
+	diags = append(diags, finish(ctx)...)
+	if diags.HasError() {
+		id := d.Id()
+		d.SetId(pendingCreateID(activityID))
+		return append(diags, diag.Errorf("the %s %s created by the interrupted create activity %q could not be finished; the pending activity is kept in the state and the next plan or apply retries the create steps it skipped", label, id, activityID)...), true
+	}
+	return diags, false
+}
@@ -0,0 +1,284 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func newPendingCreateRD(t *testing.T, id string) *schema.ResourceData {
+	t.Helper()
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		"name": {Type: schema.TypeString, Optional: true},
+	}, map[string]interface{}{})
+	d.SetId(id)
+	return d
+}
+
+func hasWarningOnly(diags diag.Diagnostics) bool {
+	return len(diags) > 0 && !diags.HasError()
+}
+
+func TestResumePendingCreate(t *testing.T) {
+	t.Run("a real id is left untouched and nothing is waited on", func(t *testing.T) {
+		d := newPendingCreateRD(t, "vm-1")
+		diags, stop := resumePendingCreate(context.Background(), d, "virtual machine", func(ctx context.Context, a string) (string, error) {
+			t.Fatal("a real id must not be resolved")
+			return "", nil
+		})
+		if stop || diags != nil || d.Id() != "vm-1" {
+			t.Fatalf("stop=%v diags=%v id=%q", stop, diags, d.Id())
+		}
+	})
+
+	t.Run("a completed activity is adopted", func(t *testing.T) {
+		d := newPendingCreateRD(t, pendingCreateID("act-1"))
+		diags, stop := resumePendingCreate(context.Background(), d, "virtual machine", func(ctx context.Context, a string) (string, error) {
+			if a != "act-1" {
+				t.Fatalf("resolved activity %q, want act-1", a)
+			}
+			return "vm-1", nil
+		})
+		if stop || diags != nil || d.Id() != "vm-1" {
+			t.Fatalf("stop=%v diags=%v id=%q", stop, diags, d.Id())
+		}
+	})
+
+	t.Run("an interrupted resume keeps the activity and only warns", func(t *testing.T) {
+		d := newPendingCreateRD(t, pendingCreateID("act-1"))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		diags, stop := resumePendingCreate(ctx, d, "virtual machine", func(ctx context.Context, a string) (string, error) {
+			return "", ctx.Err()
+		})
+		if !stop || !hasWarningOnly(diags) || d.Id() != pendingCreateID("act-1") {
+			t.Fatalf("stop=%v diags=%v id=%q", stop, diags, d.Id())
+		}
+	})
+
+	t.Run("a timed out resume keeps the activity and errors", func(t *testing.T) {
+		d := newPendingCreateRD(t, pendingCreateID("act-1"))
+		diags, stop := resumePendingCreate(context.Background(), d, "virtual machine", func(ctx context.Context, a string) (string, error) {
+			return "", &client.WaitTimeoutError{Subject: "activity act-1"}
+		})
+		if !stop || !diags.HasError() || d.Id() != pendingCreateID("act-1") {
+			t.Fatalf("stop=%v diags=%v id=%q", stop, diags, d.Id())
+		}
+	})
+
+	for name, resolve := range map[string]pendingCreateResolver{
+		"an unreadable activity": func(ctx context.Context, a string) (string, error) { return "", errors.New("403 forbidden") },
+		"an empty id":            func(ctx context.Context, a string) (string, error) { return "", nil },
+	} {
+		t.Run(name+" fails closed keeping the activity", func(t *testing.T) {
+			d := newPendingCreateRD(t, pendingCreateID("act-1"))
+			diags, stop := resumePendingCreate(context.Background(), d, "virtual machine", resolve)
+			if !stop || !diags.HasError() || d.Id() != pendingCreateID("act-1") {
+				t.Fatalf("stop=%v diags=%v id=%q", stop, diags, d.Id())
+			}
+		})
+	}
+
+	t.Run("the update of a create still in progress is an error", func(t *testing.T) {
+		d := newPendingCreateRD(t, pendingCreateID("act-1"))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		diags, stop := resumePendingCreateForWrite(ctx, d, "virtual machine", func(ctx context.Context, a string) (string, error) {
+			return "", ctx.Err()
+		})
+		if !stop || !diags.HasError() {
+			t.Fatalf("stop=%v diags=%v", stop, diags)
+		}
+	})
+}
+
+// TestRecordPendingCreate pins that an interrupted create only warns, so that
+// the resource is not tainted, while a timed out one is an error; both keep the
+// activity in the state.
+func TestRecordPendingCreate(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, expire := context.WithDeadline(context.Background(), time.Now())
+	defer expire()
+
+	for _, tc := range []struct {
+		name    string
+		ctx     context.Context
+		err     error
+		isError bool
+	}{
+		{"an interrupted apply", cancelled, context.Canceled, false},
+		{"an expired create timeout", expired, context.DeadlineExceeded, true},
+		{"a waiter timeout", context.Background(), &client.WaitTimeoutError{Subject: "activity act-1"}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newPendingCreateRD(t, "")
+			diags := recordPendingCreate(tc.ctx, d, "virtual machine", "act-1", tc.err)
+			if d.Id() != pendingCreateID("act-1") {
+				t.Fatalf("id = %q, want the pending activity", d.Id())
+			}
+			if tc.isError != diags.HasError() || (!tc.isError && !hasWarningOnly(diags)) {
+				t.Fatalf("diags = %v, want an error: %v", diags, tc.isError)
+			}
+		})
+	}
+}
+
+func TestResumeAndFinishPendingCreate(t *testing.T) {
+	adopt := func(ctx context.Context, a string) (string, error) { return "vm-1", nil }
+
+	t.Run("a real id is not finished", func(t *testing.T) {
+		d := newPendingCreateRD(t, "vm-1")
+		diags, stop := resumeAndFinishPendingCreate(context.Background(), d, "virtual machine", resumePendingCreate, adopt, func(ctx context.Context) diag.Diagnostics {
+			t.Fatal("a virtual machine that was never pending must not be finished")
+			return nil
+		})
+		if stop || diags != nil || d.Id() != "vm-1" {
+			t.Fatalf("stop=%v diags=%v id=%q", stop, diags, d.Id())
+		}
+	})
+
+	t.Run("an adopted create is finished on its new id", func(t *testing.T) {
+		d := newPendingCreateRD(t, pendingCreateID("act-1"))
+		finished := ""
+		diags, stop := resumeAndFinishPendingCreate(context.Background(), d, "virtual machine", resumePendingCreate, adopt, func(ctx context.Context) diag.Diagnostics {
+			finished = d.Id()
+			return nil
+		})
+		if stop || diags.HasError() || d.Id() != "vm-1" || finished != "vm-1" {
+			t.Fatalf("stop=%v diags=%v id=%q finished=%q", stop, diags, d.Id(), finished)
+		}
+	})
+
+	t.Run("a failed finish keeps the activity to retry it", func(t *testing.T) {
+		d := newPendingCreateRD(t, pendingCreateID("act-1"))
+		diags, stop := resumeAndFinishPendingCreate(context.Background(), d, "virtual machine", resumePendingCreateForWrite, adopt, func(ctx context.Context) diag.Diagnostics {
+			return diag.Errorf("failed to power on virtual machine")
+		})
+		if !stop || !diags.HasError() || d.Id() != pendingCreateID("act-1") {
+			t.Fatalf("stop=%v diags=%v id=%q", stop, diags, d.Id())
+		}
+	})
+
+	t.Run("a create still in progress is not finished", func(t *testing.T) {
+		d := newPendingCreateRD(t, pendingCreateID("act-1"))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		diags, stop := resumeAndFinishPendingCreate(ctx, d, "virtual machine", resumePendingCreate, func(ctx context.Context, a string) (string, error) {
+			return "", ctx.Err()
+		}, func(ctx context.Context) diag.Diagnostics {
+			t.Fatal("a create still in progress must not be finished")
+			return nil
+		})
+		if !stop || !hasWarningOnly(diags) || d.Id() != pendingCreateID("act-1") {
+			t.Fatalf("stop=%v diags=%v id=%q", stop, diags, d.Id())
+		}
+	})
+}
+
+// TestOpenIaasVirtualMachineFinishCreate drives the takeover of an interrupted
+// Open IaaS create against a stub API: once the created virtual machine is
+// adopted, the backup policies, the tags and the power state the create skipped
+// are applied, in that order.
+func TestOpenIaasVirtualMachineFinishCreate(t *testing.T) {
+	var calls []string
+	c := newAssignTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/activity/v1/activities/act-1"):
+			_, _ = w.Write([]byte(`{"id":"act-1","state":{"completed":{"result":"vm-1"}}}`))
+		case r.Method == http.MethodGet && strings.Contains(r.URL.Path, "/activity/v1/activities/"):
+			_, _ = w.Write([]byte(`{"id":"act-2","state":{"completed":{}}}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/backup/v1/open_iaas/policies/assign"):
+			calls = append(calls, "assign")
+			w.Header().Set("Location", "act-2")
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/tag/v1/tags/resources/vm-1"):
+			_, _ = w.Write([]byte(`[]`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/tag/v1/tags"):
+			calls = append(calls, "tag")
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPatch && strings.HasSuffix(r.URL.Path, "/compute/v1/open_iaas/virtual_machines/vm-1/power"):
+			calls = append(calls, "power")
+			w.Header().Set("Location", "act-3")
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceOpenIaasVirtualMachine().Schema, map[string]interface{}{
+		"name":                     "web",
+		"power_state":              "on",
+		"host_id":                  "host-1",
+		"wait_for_drivers_timeout": 0,
+		"backup_sla_policies":      []interface{}{"pol-1"},
+		"tags":                     map[string]interface{}{"app": "billing"},
+	})
+	d.SetId(pendingCreateID("act-1"))
+
+	diags, stop := resumeAndFinishPendingCreate(context.Background(), d, "virtual machine", resumePendingCreate, openIaasVirtualMachineCreateResolver(c), openIaasVirtualMachineFinishCreate(c, d))
+	if stop || diags.HasError() || d.Id() != "vm-1" {
+		t.Fatalf("stop=%v diags=%v id=%q", stop, diags, d.Id())
+	}
+	if got := strings.Join(calls, ","); got != "assign,tag,power" {
+		t.Errorf("calls = %s, want assign,tag,power", got)
+	}
+}
+
+// TestOpenIaasVirtualMachineCreateResolver drives the resume through the real
+// client waiter: a completed create activity yields its single state result, a
+// failed one drops the pending resource so the next apply creates it again.
+func TestOpenIaasVirtualMachineCreateResolver(t *testing.T) {
+	activityServer := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet || !strings.Contains(r.URL.Path, "/activity/v1/activities/act-1") {
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_, _ = w.Write([]byte(body))
+		}
+	}
+
+	t.Run("completed", func(t *testing.T) {
+		c := newAssignTestClient(t, activityServer(`{"id":"act-1","state":{"completed":{"result":"vm-1"}}}`))
+		d := newPendingCreateRD(t, pendingCreateID("act-1"))
+		diags, stop := resumePendingCreate(context.Background(), d, "virtual machine", openIaasVirtualMachineCreateResolver(c))
+		if stop || diags.HasError() || d.Id() != "vm-1" {
+			t.Fatalf("stop=%v diags=%v id=%q", stop, diags, d.Id())
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		c := newAssignTestClient(t, activityServer(`{"id":"act-1","state":{"failed":{"reason":"no space left"}}}`))
+		d := newPendingCreateRD(t, pendingCreateID("act-1"))
+		diags, stop := resumePendingCreate(context.Background(), d, "virtual machine", openIaasVirtualMachineCreateResolver(c))
+		if !stop || !hasWarningOnly(diags) || d.Id() != "" {
+			t.Fatalf("stop=%v diags=%v id=%q", stop, diags, d.Id())
+		}
+	})
+}
+
+func TestCreateVPCStaticIPRecordsAnInterruptedCreate(t *testing.T) {
+	d := newStaticIPCreateData(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	diags := createVPCStaticIPWith(ctx, d, vpcStaticIPCreateFuncs{
+		create: func(ctx context.Context, privateNetworkID string, req *client.CreateStaticIPRequest) (string, error) {
+			cancel()
+			return "", &pendingCreateError{activityID: "act-1", err: ctx.Err()}
+		},
+		read: func(ctx context.Context, id string) (*client.StaticIP, error) {
+			t.Fatal("a pending create must not be read")
+			return nil, nil
+		},
+	})
+	if !hasWarningOnly(diags) || d.Id() != pendingCreateID("act-1") {
+		t.Fatalf("diags=%v id=%q", diags, d.Id())
+	}
+}
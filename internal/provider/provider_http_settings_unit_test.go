@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestExpandHTTPSettingsPrecedence: an argument wins over the environment,
+// which wins over the default.
+func TestExpandHTTPSettingsPrecedence(t *testing.T) {
+	isolateProviderEnv(t)
+	t.Setenv(client.HTTPTimeoutEnvName, "120")
+	t.Setenv(client.ReadRetryMaxEnvName, "5")
+	t.Setenv(client.ReadRetryBackoffMaxEnvName, "20s")
+	t.Setenv(client.RateLimitEnvName, "5")
+	t.Setenv(client.MaxInFlightEnvName, "4")
+	t.Setenv(client.ListPageSizeEnvName, "50")
+	t.Setenv(client.ListMaxItemsEnvName, "1000")
+
+	config, err := client.DefaultConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := schema.TestResourceDataRaw(t, New("test")().Schema, map[string]any{
+		"http_timeout":            "5m",
+		"fast_read_timeout":       "0s",
+		"read_retry_backoff_base": "250ms",
+		"rate_limit":              20.5,
+		"list_max_items":          5000,
+		"reference_cache_ttl":     "5m",
+	})
+	expandHTTPSettings(d, config)
+
+	for name, got := range map[string][2]any{
+		"http_timeout (argument)":            {config.HTTPTimeout, 5 * time.Minute},
+		"fast_read_timeout (argument)":       {config.FastReadTimeout, time.Duration(0)},
+		"read_retry_backoff_base (argument)": {config.ReadRetryBackoffBase, 250 * time.Millisecond},
+		"read_retry_backoff_max (env)":       {config.ReadRetryBackoffMax, 20 * time.Second},
+		"read_retry_max (env)":               {config.ReadRetryMax, 5},
+		"rate_limit (argument)":              {config.RateLimit, 20.5},
+		"max_in_flight (env)":                {config.MaxInFlight, 4},
+		"rate_burst (default)":               {config.RateBurst, 0},
+		"list_page_size (env)":               {config.ListPageSize, 50},
+		"list_max_items (argument)":          {config.ListMaxItems, 5000},
+		"reference_cache_ttl (argument)":     {config.ReferenceCacheTTL, 5 * time.Minute},
+	} {
+		if got[0] != got[1] {
+			t.Errorf("%s = %v, want %v", name, got[0], got[1])
+		}
+	}
+}
+
+func TestValidateDuration(t *testing.T) {
+	validate := validateDuration(time.Millisecond)
+	for v, valid := range map[string]bool{
+		"500ms": true,
+		"1m30s": true,
+		"1ms":   true,
+		"30":    false,
+		"0s":    false,
+		"-1s":   false,
+		"soon":  false,
+	} {
+		if _, errs := validate(v, "read_retry_backoff_base"); (len(errs) == 0) != valid {
+			t.Errorf("%q: errors %v, want valid=%t", v, errs, valid)
+		}
+	}
+}
+
+func TestProviderConfigureRejectsBackoffMaxBelowBase(t *testing.T) {
+	isolateProviderEnv(t)
+
+	diags := New("test")().Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]any{
+		"client_id":               "id",
+		"secret_id":               "secret",
+		"read_retry_backoff_base": "2s",
+		"read_retry_backoff_max":  "1s",
+	}))
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "shorter than its base") {
+		t.Fatalf("want a backoff error, got %v", diags)
+	}
+}
+
+// TestProviderConfigureTLS: the login goes through the transport configured
+// with the CA of the provider configuration.
+func TestProviderConfigureTLS(t *testing.T) {
+	isolateProviderEnv(t)
+
+	var logins []string
+	srv := httptest.NewTLSServer(loginHandler(t, &logins))
+	t.Cleanup(srv.Close)
+
+	configure := func(config map[string]any) diag.Diagnostics {
+		config["address"] = srv.URL
+		config["api_suffix"] = false
+		config["client_id"] = "id"
+		config["secret_id"] = "secret"
+		return New("test")().Configure(context.Background(), terraform.NewResourceConfigRaw(config))
+	}
+
+	if diags := configure(map[string]any{}); !diags.HasError() {
+		t.Fatal("the certificate of the test server must not be trusted without ca_cert_pem")
+	}
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+	if diags := configure(map[string]any{"ca_cert_pem": caPEM}); diags.HasError() {
+		t.Fatalf("%s: %s", diags[0].Summary, diags[0].Detail)
+	}
+	if len(logins) != 1 {
+		t.Errorf("logins = %v, want one", logins)
+	}
+
+	if diags := configure(map[string]any{"ca_cert_pem": "not a certificate"}); !diags.HasError() {
+		t.Error("an invalid ca_cert_pem must fail the configuration")
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
 	"github.com/hashicorp/terraform-plugin-log/tflogtest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 )
@@ -117,3 +119,36 @@ func TestLoggingHttpTransportRedactsSecretBodyValues(t *testing.T) {
 		})
 	}
 }
+
+// TestTraceHTTPLogsToTheSubsystem: a trace is logged to the cloudtemple_http
+// subsystem, with the fields a support ticket needs.
+func TestTraceHTTPLogsToTheSubsystem(t *testing.T) {
+	trace := &client.HTTPTrace{
+		Method:       http.MethodPost,
+		Path:         "/api/compute/v1/virtual_machines",
+		Status:       http.StatusOK,
+		Latency:      42 * time.Millisecond,
+		Location:     "/api/activity/v1/activities/42",
+		RequestBody:  `{"name":"vm-1"}`,
+		ResponseBody: "",
+	}
+
+	t.Setenv(httpSubsystemLevelEnv, "DEBUG")
+	var on bytes.Buffer
+	traceHTTP(tflogtest.RootLogger(context.Background(), &on), trace)
+	out := on.String()
+	for _, want := range []string{
+		`"@module":"provider.cloudtemple_http"`,
+		`"method":"POST"`,
+		`"latency_ms":42`,
+		`"location":"/api/activity/v1/activities/42"`,
+		`"request_body":"{\"name\":\"vm-1\"}"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("the trace lacks %s:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "response_body") {
+		t.Errorf("an empty body must not be logged:\n%s", out)
+	}
+}
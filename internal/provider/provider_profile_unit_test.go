@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// isolateProviderEnv clears the variables the provider configuration reads, so
+// the developer's own credentials never leak into a test.
+func isolateProviderEnv(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	for _, name := range []string{
+		client.SharedCredentialsFileEnvName, client.ProfileEnvName,
+		client.HTTPAddrEnvName, client.HTTPSchemeEnvName, client.HTTPClientIDEnvName, client.HTTPClientSecretEnvName,
+		client.HTTPTimeoutEnvName, client.FastReadTimeoutEnvName,
+		client.ReadRetryMaxEnvName, client.ReadRetryBackoffBaseEnvName, client.ReadRetryBackoffMaxEnvName,
+		client.RateLimitEnvName, client.RateBurstEnvName, client.MaxInFlightEnvName,
+		client.ListPageSizeEnvName, client.ListMaxItemsEnvName, client.ReferenceCacheTTLEnvName,
+	} {
+		t.Setenv(name, "")
+	}
+}
+
+// loginHandler answers the personal access token login, recording the
+// "id:secret" of each login in logins.
+func loginHandler(t *testing.T, logins *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/iam/v2/auth/personal_access_token") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body struct{ ID, Secret string }
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		*logins = append(*logins, body.ID+":"+body.Secret)
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"exp": float64(time.Now().Add(time.Hour).Unix()),
+		}).SignedString([]byte("test-secret"))
+		fmt.Fprint(w, token)
+	}
+}
+
+// TestProviderConfigureFromProfile: the address and the credentials come from
+// the profile named by the configuration, and the arguments win over it.
+func TestProviderConfigureFromProfile(t *testing.T) {
+	isolateProviderEnv(t)
+
+	var logins []string
+	srv := httptest.NewServer(loginHandler(t, &logins))
+	t.Cleanup(srv.Close)
+
+	path := filepath.Join(t.TempDir(), "credentials")
+	content := fmt.Sprintf("[default]\nclient_id = wrong\nsecret_id = wrong\n\n[vmware]\naddress = %s\nclient_id = vmware-id\nsecret_id = vmware-secret\n", srv.URL)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	configure := func(config map[string]any) error {
+		p := New("test")()
+		config["shared_credentials_file"] = path
+		config["api_suffix"] = false
+		diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(config))
+		if diags.HasError() {
+			return fmt.Errorf("%s: %s", diags[0].Summary, diags[0].Detail)
+		}
+		return nil
+	}
+
+	if err := configure(map[string]any{"profile": "vmware"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := configure(map[string]any{"profile": "vmware", "secret_id": "hcl-secret"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"vmware-id:vmware-secret", "vmware-id:hcl-secret"}; fmt.Sprint(logins) != fmt.Sprint(want) {
+		t.Errorf("logins = %v, want %v", logins, want)
+	}
+
+	if err := configure(map[string]any{"profile": "openiaas"}); err == nil || !strings.Contains(err.Error(), "openiaas") {
+		t.Errorf("an unknown profile must fail the configuration, got %v", err)
+	}
+}
+
+func TestProviderConfigureRequiresCredentials(t *testing.T) {
+	isolateProviderEnv(t)
+
+	diags := New("test")().Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]any{}))
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "client_id and secret_id must be set") {
+		t.Fatalf("want a missing credentials error, got %v", diags)
+	}
+}
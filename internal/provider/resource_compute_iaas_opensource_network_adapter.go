@@ -23,6 +23,11 @@ func resourceOpenIaasNetworkAdapter() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultComputeTimeout),
+			Update: schema.DefaultTimeout(defaultComputeTimeout),
+			Delete: schema.DefaultTimeout(defaultComputeTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			// In
@@ -133,6 +138,8 @@ func resourceOpenIaasNetworkAdapter() *schema.Resource {
 func openIaasNetworkAdapterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := getClient(meta)
 	vmID := d.Get("virtual_machine_id").(string)
+	unlock := openIaasVirtualMachineMutex.lock(vmID)
+	defer unlock()
 
 	// Reject ip_address on a non-VPC network BEFORE creating anything.
 	if diags := ensureVPCForIPAddress(ctx, d, openIaasNetworkVPCBacked(c)); diags != nil {
@@ -210,7 +217,7 @@ func openIaasNetworkAdapterCreate(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	return openIaasNetworkAdapterUpdate(ctx, d, meta)
+	return updateOpenIaasNetworkAdapter(ctx, d, meta)
 }
 
 func openIaasNetworkAdapterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -295,6 +302,14 @@ func openIaasNetworkAdapterRead(ctx context.Context, d *schema.ResourceData, met
 }
 
 func openIaasNetworkAdapterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	unlock := openIaasVirtualMachineMutex.lock(d.Get("virtual_machine_id").(string))
+	defer unlock()
+	return updateOpenIaasNetworkAdapter(ctx, d, meta)
+}
+
+// updateOpenIaasNetworkAdapter applies the changes of the adapter, with the
+// lock of its virtual machine held by the caller.
+func updateOpenIaasNetworkAdapter(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := getClient(meta)
 
 	// Reject ip_address on a non-VPC network BEFORE moving the adapter.
@@ -454,6 +469,8 @@ func openIaasNetworkAdapterUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func openIaasNetworkAdapterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	unlock := openIaasVirtualMachineMutex.lock(d.Get("virtual_machine_id").(string))
+	defer unlock()
 	c := getClient(meta)
 
 	activityId, err := c.Compute().OpenIaaS().NetworkAdapter().Delete(ctx, d.Id())
@@ -17,6 +17,10 @@ func resourceOpenIaasReplicationPolicy() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultComputeTimeout),
+			Delete: schema.DefaultTimeout(defaultComputeTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			// In
@@ -0,0 +1,297 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	openIaasSnapshotCreateTimeout = 30 * time.Minute
+	openIaasSnapshotRevertTimeout = 30 * time.Minute
+	openIaasSnapshotDeleteTimeout = 30 * time.Minute
+)
+
+func resourceOpenIaasSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a snapshot of an Open IaaS virtual machine. Create, revert and delete are asynchronous. The snapshot itself is immutable (every argument except `revert_trigger` forces a new snapshot); changing `revert_trigger` reverts the virtual machine to the snapshot.",
+
+		CreateContext: openIaasSnapshotCreate,
+		ReadContext:   openIaasSnapshotRead,
+		UpdateContext: openIaasSnapshotUpdate,
+		DeleteContext: openIaasSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(openIaasSnapshotCreateTimeout),
+			Update: schema.DefaultTimeout(openIaasSnapshotRevertTimeout),
+			Delete: schema.DefaultTimeout(openIaasSnapshotDeleteTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			// In
+			"virtual_machine_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+				Description:  "The ID of the virtual machine to snapshot. Immutable.",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The name of the snapshot. Immutable (renaming recreates the snapshot).",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The description of the snapshot. Immutable.",
+			},
+			"save_memory": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+				// The API does not report whether a snapshot holds the memory, so
+				// an imported snapshot has no value in the state: never replace it
+				// for that reason alone.
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return old == "" && d.Id() != ""
+				},
+				Description: "Whether to also capture the memory of the running virtual machine, so that a revert resumes it in its running state. When `false` (the default) the snapshot is disk-only and a revert leaves the virtual machine halted. Immutable.",
+			},
+			"revert_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An arbitrary value (e.g. a timestamp or a counter). Any change to it after creation reverts the virtual machine to this snapshot; the value set at creation does not trigger a revert. A failed revert keeps the previous value in the state so the next apply retries it.",
+			},
+
+			// Out
+			"create_time": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The timestamp when the snapshot was created (in Unix time format).",
+			},
+		},
+	}
+}
+
+// openIaasSnapshotReadMode selects how a snapshot that cannot be read is
+// treated (see readOpenIaasSnapshotInto).
+type openIaasSnapshotReadMode int
+
+const (
+	openIaasSnapshotReadForRefresh openIaasSnapshotReadMode = iota
+	openIaasSnapshotReadAfterWrite
+)
+
+// openIaasSnapshotCRUDFuncs abstracts the client surface for unit testing without HTTP.
+type openIaasSnapshotCRUDFuncs struct {
+	create       func(ctx context.Context, req *client.CreateOpenIaaSSnapshotRequest) (string, error)
+	revert       func(ctx context.Context, id string) (string, error)
+	del          func(ctx context.Context, id string) (string, error)
+	read         func(ctx context.Context, id string) (*client.OpenIaaSSnapshot, error)
+	listStrict   func(ctx context.Context, filter *client.OpenIaaSSnapshotFilter) ([]*client.OpenIaaSSnapshot, error)
+	waitActivity func(ctx context.Context, activityID string) (*client.Activity, error)
+}
+
+func openIaasSnapshotClientFuncs(c *client.Client) openIaasSnapshotCRUDFuncs {
+	snap := c.Compute().OpenIaaS().Snapshot()
+	return openIaasSnapshotCRUDFuncs{
+		create:     snap.Create,
+		revert:     snap.Revert,
+		del:        snap.Delete,
+		read:       snap.Read,
+		listStrict: snap.ListStrict,
+		waitActivity: func(ctx context.Context, activityID string) (*client.Activity, error) {
+			return c.Activity().WaitForCompletion(ctx, activityID, getWaiterOptions(ctx))
+		},
+	}
+}
+
+func openIaasSnapshotCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	unlock := openIaasVirtualMachineMutex.lock(d.Get("virtual_machine_id").(string))
+	defer unlock()
+	return createOpenIaasSnapshotWith(ctx, d, openIaasSnapshotClientFuncs(getClient(meta)))
+}
+
+func openIaasSnapshotRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	return readOpenIaasSnapshotInto(ctx, d, openIaasSnapshotClientFuncs(getClient(meta)), openIaasSnapshotReadForRefresh)
+}
+
+func openIaasSnapshotUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	unlock := openIaasVirtualMachineMutex.lock(d.Get("virtual_machine_id").(string))
+	defer unlock()
+	return updateOpenIaasSnapshotWith(ctx, d, openIaasSnapshotClientFuncs(getClient(meta)))
+}
+
+func openIaasSnapshotDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	unlock := openIaasVirtualMachineMutex.lock(d.Get("virtual_machine_id").(string))
+	defer unlock()
+	return deleteOpenIaasSnapshotWith(ctx, d, openIaasSnapshotClientFuncs(getClient(meta)))
+}
+
+// createOpenIaasSnapshotWith: the snapshot id is taken from the completed
+// activity — the "snapshot" concerned item, else the terminal state result —
+// validated (non-empty, UUID, != vmID) before SetId.
+func createOpenIaasSnapshotWith(ctx context.Context, d *schema.ResourceData, funcs openIaasSnapshotCRUDFuncs) diag.Diagnostics {
+	vmID := d.Get("virtual_machine_id").(string)
+	name := d.Get("name").(string)
+
+	activityID, err := funcs.create(ctx, &client.CreateOpenIaaSSnapshotRequest{
+		VirtualMachineID: vmID,
+		Name:             name,
+		Description:      d.Get("description").(string),
+		SaveMemory:       d.Get("save_memory").(bool),
+	})
+	if err != nil {
+		return diag.Errorf("failed to create snapshot %q of virtual machine %s: %s", name, vmID, err)
+	}
+	activity, err := funcs.waitActivity(ctx, activityID)
+	if err != nil {
+		return diag.Errorf(
+			"snapshot %q create on virtual machine %s: activity %q did not complete: %s. If a snapshot was created it is ORPHANED outside the state — audit the virtual machine's snapshots and import it (terraform import <snapshotID>) or delete it before re-applying.",
+			name, vmID, activityID, err,
+		)
+	}
+
+	candidate := activityConcernedItemID(activity, "snapshot")
+	if candidate == "" {
+		candidate = singleActivityResult(activity)
+	}
+	if candidate == "" || !isUUID(candidate) || sameUUID(candidate, vmID) {
+		return diag.Errorf(
+			"snapshot %q create on virtual machine %s (activity %q) did not report a usable snapshot id (got %q); refusing to guess. Audit the virtual machine's snapshots and import the new one if it was created.",
+			name, vmID, activityID, candidate,
+		)
+	}
+	d.SetId(candidate)
+
+	return readOpenIaasSnapshotInto(ctx, d, funcs, openIaasSnapshotReadAfterWrite)
+}
+
+// confirmOpenIaasSnapshotDeleted resolves a nil per-id snapshot read into a
+// verdict under strict listing evidence, scoped to the VM then tenant-wide, like
+// confirmOpenIaaSVirtualDiskDeleted. A listing failure yields a non-nil diags and
+// the fail-closed deviceStillInScope verdict.
+func confirmOpenIaasSnapshotDeleted(ctx context.Context, funcs openIaasSnapshotCRUDFuncs, id, vmID string) (missingDeviceVerdict, diag.Diagnostics) {
+	scoped, err := funcs.listStrict(ctx, &client.OpenIaaSSnapshotFilter{VirtualMachineID: vmID})
+	if err != nil {
+		return deviceStillInScope, diag.Errorf("snapshot %s could not be read and its deletion could not be confirmed: %s", id, err)
+	}
+	tenant, err := funcs.listStrict(ctx, &client.OpenIaaSSnapshotFilter{})
+	if err != nil {
+		return deviceStillInScope, diag.Errorf("snapshot %s could not be read and its deletion could not be confirmed: %s", id, err)
+	}
+	scopedIDs := map[string]bool{}
+	for _, s := range scoped {
+		if s != nil && sameUUID(s.ID, id) {
+			scopedIDs[id] = true
+		}
+	}
+	tenantIDs := map[string]bool{}
+	for _, s := range tenant {
+		if s != nil && sameUUID(s.ID, id) {
+			tenantIDs[id] = true
+		}
+	}
+	return classifyMissingDevice(id, scopedIDs, tenantIDs), nil
+}
+
+// readOpenIaasSnapshotInto reads the snapshot by id. A nil read (a definitive
+// 404 since #384) drops the resource only on refresh and only once both strict
+// listings confirm the absence; right after a write it is eventual consistency
+// and the resource is kept.
+func readOpenIaasSnapshotInto(ctx context.Context, d *schema.ResourceData, funcs openIaasSnapshotCRUDFuncs, mode openIaasSnapshotReadMode) diag.Diagnostics {
+	vmID := d.Get("virtual_machine_id").(string)
+	snapshotID := d.Id()
+
+	snapshot, err := funcs.read(ctx, snapshotID)
+	if err != nil {
+		return diag.Errorf("failed to read snapshot %s: %s", snapshotID, err)
+	}
+	if snapshot == nil {
+		if mode == openIaasSnapshotReadAfterWrite {
+			return diag.Errorf("snapshot %s of virtual machine %s was just written but cannot be read yet (eventual consistency); the resource is kept in the state with its id.", snapshotID, vmID)
+		}
+		verdict, confirmDiags := confirmOpenIaasSnapshotDeleted(ctx, funcs, snapshotID, vmID)
+		if confirmDiags != nil {
+			return confirmDiags
+		}
+		switch verdict {
+		case deviceStillInScope:
+			return diag.Errorf("snapshot %s could not be read but is still listed on virtual machine %s: refusing to drop it from the state (possible access restriction)", snapshotID, vmID)
+		case deviceExistsOutOfScope:
+			return diag.Errorf("snapshot %s could not be read and is not listed on virtual machine %s but still exists platform-side: refusing to treat this as a deletion — fix virtual_machine_id or re-import", snapshotID, vmID)
+		}
+		// Deletion confirmed by independent strict reads.
+		d.SetId("")
+		return nil
+	}
+
+	sw := newStateWriter(d)
+	sw.set("virtual_machine_id", snapshot.VirtualMachineID)
+	sw.set("name", snapshot.Name)
+	sw.set("description", snapshot.Description)
+	sw.set("create_time", snapshot.CreateTime)
+	return sw.diags
+}
+
+// updateOpenIaasSnapshotWith reverts the virtual machine when revert_trigger
+// changes. A failed revert is reported with d.Partial(true) so the previous
+// trigger value stays in the state and the next apply retries the revert.
+func updateOpenIaasSnapshotWith(ctx context.Context, d *schema.ResourceData, funcs openIaasSnapshotCRUDFuncs) diag.Diagnostics {
+	if !d.HasChange("revert_trigger") {
+		return readOpenIaasSnapshotInto(ctx, d, funcs, openIaasSnapshotReadAfterWrite)
+	}
+	vmID := d.Get("virtual_machine_id").(string)
+	snapshotID := d.Id()
+
+	activityID, err := funcs.revert(ctx, snapshotID)
+	if err != nil {
+		d.Partial(true)
+		return diag.Errorf("failed to revert virtual machine %s to snapshot %s: %s", vmID, snapshotID, err)
+	}
+	if _, err := funcs.waitActivity(ctx, activityID); err != nil {
+		d.Partial(true)
+		return diag.Errorf("revert of virtual machine %s to snapshot %s: activity %q did not complete: %s", vmID, snapshotID, activityID, err)
+	}
+
+	return readOpenIaasSnapshotInto(ctx, d, funcs, openIaasSnapshotReadAfterWrite)
+}
+
+// deleteOpenIaasSnapshotWith: a 404 on delete is accepted only once the strict
+// listings confirm the snapshot is gone; any other error or a failed activity
+// keeps the resource in the state.
+func deleteOpenIaasSnapshotWith(ctx context.Context, d *schema.ResourceData, funcs openIaasSnapshotCRUDFuncs) diag.Diagnostics {
+	vmID := d.Get("virtual_machine_id").(string)
+	snapshotID := d.Id()
+
+	activityID, err := funcs.del(ctx, snapshotID)
+	if err != nil {
+		if !isStatusCode(err, http.StatusNotFound) {
+			return diag.Errorf("failed to delete snapshot %s of virtual machine %s: %s", snapshotID, vmID, err)
+		}
+		verdict, confirmDiags := confirmOpenIaasSnapshotDeleted(ctx, funcs, snapshotID, vmID)
+		if confirmDiags != nil {
+			return confirmDiags
+		}
+		if verdict != deviceDeletionConfirmed {
+			return diag.Errorf("snapshot %s was reported not found on delete but is still listed; refusing to drop it (possible access restriction).", snapshotID)
+		}
+		return nil
+	}
+	if _, err := funcs.waitActivity(ctx, activityID); err != nil {
+		return diag.Errorf("snapshot %s delete on virtual machine %s: activity %q did not complete: %s", snapshotID, vmID, activityID, err)
+	}
+	return nil
+}
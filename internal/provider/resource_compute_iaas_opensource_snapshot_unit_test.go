@@ -0,0 +1,288 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	openIaasSnapTestVMID   = "aaaaaaaa-1111-2222-3333-444444444444"
+	openIaasSnapTestSnapID = "bbbbbbbb-1111-2222-3333-444444444444"
+)
+
+func newOpenIaasSnapRD(t *testing.T, raw map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+	cfg := map[string]interface{}{
+		"virtual_machine_id": openIaasSnapTestVMID,
+		"name":               "pre-upgrade",
+	}
+	for k, v := range raw {
+		cfg[k] = v
+	}
+	return schema.TestResourceDataRaw(t, resourceOpenIaasSnapshot().Schema, cfg)
+}
+
+func openIaasSnapFound(ctx context.Context, id string) (*client.OpenIaaSSnapshot, error) {
+	return &client.OpenIaaSSnapshot{ID: id, VirtualMachineID: openIaasSnapTestVMID, Name: "pre-upgrade", Description: "before the upgrade", CreateTime: 1700000000}, nil
+}
+
+func openIaasSnapMissing(ctx context.Context, id string) (*client.OpenIaaSSnapshot, error) {
+	return nil, nil
+}
+
+// openIaasSnapListings answers the VM-scoped and the tenant-wide strict listings.
+func openIaasSnapListings(scoped, tenant []string) func(ctx context.Context, f *client.OpenIaaSSnapshotFilter) ([]*client.OpenIaaSSnapshot, error) {
+	return func(ctx context.Context, f *client.OpenIaaSSnapshotFilter) ([]*client.OpenIaaSSnapshot, error) {
+		ids := tenant
+		if f.VirtualMachineID != "" {
+			ids = scoped
+		}
+		out := make([]*client.OpenIaaSSnapshot, 0, len(ids))
+		for _, id := range ids {
+			out = append(out, &client.OpenIaaSSnapshot{ID: id})
+		}
+		return out, nil
+	}
+}
+
+func TestCreateOpenIaasSnapshotWith(t *testing.T) {
+	t.Run("memory flag and description are sent, id from the snapshot concerned item", func(t *testing.T) {
+		d := newOpenIaasSnapRD(t, map[string]interface{}{"save_memory": true, "description": "before the upgrade"})
+		var sent *client.CreateOpenIaaSSnapshotRequest
+		funcs := openIaasSnapshotCRUDFuncs{
+			create: func(ctx context.Context, req *client.CreateOpenIaaSSnapshotRequest) (string, error) {
+				sent = req
+				return "act", nil
+			},
+			waitActivity: func(ctx context.Context, a string) (*client.Activity, error) {
+				return &client.Activity{
+					ConcernedItems: []client.ActivityConcernedItem{{ID: openIaasSnapTestVMID, Type: "virtual_machine"}, {ID: openIaasSnapTestSnapID, Type: "snapshot"}},
+				}, nil
+			},
+			read: openIaasSnapFound,
+		}
+		if diags := createOpenIaasSnapshotWith(context.Background(), d, funcs); diags.HasError() {
+			t.Fatalf("unexpected diags: %v", diags)
+		}
+		if sent == nil || sent.VirtualMachineID != openIaasSnapTestVMID || !sent.SaveMemory || sent.Description != "before the upgrade" {
+			t.Fatalf("unexpected create request: %+v", sent)
+		}
+		if d.Id() != openIaasSnapTestSnapID {
+			t.Fatalf("id = %q, want %q", d.Id(), openIaasSnapTestSnapID)
+		}
+		if d.Get("create_time").(int) != 1700000000 {
+			t.Fatalf("state not set: create_time=%v", d.Get("create_time"))
+		}
+	})
+
+	t.Run("disk-only is the default", func(t *testing.T) {
+		d := newOpenIaasSnapRD(t, nil)
+		var sent *client.CreateOpenIaaSSnapshotRequest
+		funcs := openIaasSnapshotCRUDFuncs{
+			create: func(ctx context.Context, req *client.CreateOpenIaaSSnapshotRequest) (string, error) {
+				sent = req
+				return "act", nil
+			},
+			waitActivity: func(ctx context.Context, a string) (*client.Activity, error) {
+				return &client.Activity{State: map[string]client.ActivityState{"completed": {Result: openIaasSnapTestSnapID}}}, nil
+			},
+			read: openIaasSnapFound,
+		}
+		if diags := createOpenIaasSnapshotWith(context.Background(), d, funcs); diags.HasError() {
+			t.Fatalf("unexpected diags: %v", diags)
+		}
+		if sent == nil || sent.SaveMemory {
+			t.Fatalf("expected a disk-only snapshot request, got %+v", sent)
+		}
+	})
+
+	t.Run("no usable id fails closed", func(t *testing.T) {
+		for _, result := range []string{"", openIaasSnapTestVMID, "not-a-uuid"} {
+			d := newOpenIaasSnapRD(t, nil)
+			r := result
+			funcs := openIaasSnapshotCRUDFuncs{
+				create: func(ctx context.Context, req *client.CreateOpenIaaSSnapshotRequest) (string, error) {
+					return "act", nil
+				},
+				waitActivity: func(ctx context.Context, a string) (*client.Activity, error) {
+					return &client.Activity{State: map[string]client.ActivityState{"completed": {Result: r}}}, nil
+				},
+			}
+			if diags := createOpenIaasSnapshotWith(context.Background(), d, funcs); !diags.HasError() {
+				t.Fatalf("result %q must fail closed", r)
+			}
+			if d.Id() != "" {
+				t.Fatalf("result %q must not set an id", r)
+			}
+		}
+	})
+
+	t.Run("a failed activity names the orphan risk", func(t *testing.T) {
+		d := newOpenIaasSnapRD(t, nil)
+		funcs := openIaasSnapshotCRUDFuncs{
+			create: func(ctx context.Context, req *client.CreateOpenIaaSSnapshotRequest) (string, error) {
+				return "act-1", nil
+			},
+			waitActivity: func(ctx context.Context, a string) (*client.Activity, error) { return nil, errors.New("failed") },
+		}
+		diags := createOpenIaasSnapshotWith(context.Background(), d, funcs)
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, "ORPHANED") || !strings.Contains(diags[0].Summary, "act-1") {
+			t.Fatalf("expected an orphan diagnostic naming the activity, got %v", diags)
+		}
+	})
+}
+
+func TestReadOpenIaasSnapshotInto(t *testing.T) {
+	t.Run("a readable snapshot is refreshed", func(t *testing.T) {
+		d := newOpenIaasSnapRD(t, nil)
+		d.SetId(openIaasSnapTestSnapID)
+		funcs := openIaasSnapshotCRUDFuncs{read: openIaasSnapFound}
+		if diags := readOpenIaasSnapshotInto(context.Background(), d, funcs, openIaasSnapshotReadForRefresh); diags.HasError() {
+			t.Fatalf("unexpected diags: %v", diags)
+		}
+		if d.Get("description").(string) != "before the upgrade" {
+			t.Fatalf("description = %q", d.Get("description"))
+		}
+	})
+
+	t.Run("a confirmed deletion drops the resource on refresh", func(t *testing.T) {
+		d := newOpenIaasSnapRD(t, nil)
+		d.SetId(openIaasSnapTestSnapID)
+		funcs := openIaasSnapshotCRUDFuncs{read: openIaasSnapMissing, listStrict: openIaasSnapListings(nil, nil)}
+		if diags := readOpenIaasSnapshotInto(context.Background(), d, funcs, openIaasSnapshotReadForRefresh); diags.HasError() {
+			t.Fatalf("unexpected diags: %v", diags)
+		}
+		if d.Id() != "" {
+			t.Fatal("a confirmed deletion must drop the resource")
+		}
+	})
+
+	t.Run("an unconfirmed absence keeps the resource", func(t *testing.T) {
+		cases := map[string]openIaasSnapshotCRUDFuncs{
+			"still listed on the VM":  {read: openIaasSnapMissing, listStrict: openIaasSnapListings([]string{strings.ToUpper(openIaasSnapTestSnapID)}, nil)},
+			"still listed tenantwide": {read: openIaasSnapMissing, listStrict: openIaasSnapListings(nil, []string{openIaasSnapTestSnapID})},
+			"listing error": {read: openIaasSnapMissing, listStrict: func(ctx context.Context, f *client.OpenIaaSSnapshotFilter) ([]*client.OpenIaaSSnapshot, error) {
+				return nil, client.StatusError{Code: 403}
+			}},
+			"read error": {read: func(ctx context.Context, id string) (*client.OpenIaaSSnapshot, error) {
+				return nil, client.StatusError{Code: 500}
+			}},
+		}
+		for name, funcs := range cases {
+			d := newOpenIaasSnapRD(t, nil)
+			d.SetId(openIaasSnapTestSnapID)
+			if diags := readOpenIaasSnapshotInto(context.Background(), d, funcs, openIaasSnapshotReadForRefresh); !diags.HasError() {
+				t.Fatalf("%s: must fail closed", name)
+			}
+			if d.Id() != openIaasSnapTestSnapID {
+				t.Fatalf("%s: the resource must not be dropped", name)
+			}
+		}
+	})
+
+	t.Run("a missing snapshot right after a write is kept", func(t *testing.T) {
+		d := newOpenIaasSnapRD(t, nil)
+		d.SetId(openIaasSnapTestSnapID)
+		funcs := openIaasSnapshotCRUDFuncs{read: openIaasSnapMissing, listStrict: openIaasSnapListings(nil, nil)}
+		if diags := readOpenIaasSnapshotInto(context.Background(), d, funcs, openIaasSnapshotReadAfterWrite); !diags.HasError() {
+			t.Fatal("an unreadable snapshot after a write must fail closed")
+		}
+		if d.Id() != openIaasSnapTestSnapID {
+			t.Fatal("the resource must not be dropped after a write")
+		}
+	})
+}
+
+func TestUpdateOpenIaasSnapshotWith(t *testing.T) {
+	t.Run("a trigger change reverts to the snapshot", func(t *testing.T) {
+		d := newOpenIaasSnapRD(t, map[string]interface{}{"revert_trigger": "2026-10-01"})
+		d.SetId(openIaasSnapTestSnapID)
+		var reverted string
+		funcs := openIaasSnapshotCRUDFuncs{
+			revert: func(ctx context.Context, id string) (string, error) {
+				reverted = id
+				return "act", nil
+			},
+			waitActivity: func(ctx context.Context, a string) (*client.Activity, error) { return &client.Activity{}, nil },
+			read:         openIaasSnapFound,
+		}
+		if diags := updateOpenIaasSnapshotWith(context.Background(), d, funcs); diags.HasError() {
+			t.Fatalf("unexpected diags: %v", diags)
+		}
+		if reverted != openIaasSnapTestSnapID {
+			t.Fatalf("reverted %q, want %q", reverted, openIaasSnapTestSnapID)
+		}
+	})
+
+	t.Run("no trigger change never reverts", func(t *testing.T) {
+		d := newOpenIaasSnapRD(t, nil)
+		d.SetId(openIaasSnapTestSnapID)
+		funcs := openIaasSnapshotCRUDFuncs{
+			revert: func(ctx context.Context, id string) (string, error) {
+				t.Fatal("revert must not be called without a trigger change")
+				return "", nil
+			},
+			read: openIaasSnapFound,
+		}
+		if diags := updateOpenIaasSnapshotWith(context.Background(), d, funcs); diags.HasError() {
+			t.Fatalf("unexpected diags: %v", diags)
+		}
+	})
+
+	t.Run("a failed revert is reported", func(t *testing.T) {
+		d := newOpenIaasSnapRD(t, map[string]interface{}{"revert_trigger": "2"})
+		d.SetId(openIaasSnapTestSnapID)
+		funcs := openIaasSnapshotCRUDFuncs{
+			revert:       func(ctx context.Context, id string) (string, error) { return "act-r", nil },
+			waitActivity: func(ctx context.Context, a string) (*client.Activity, error) { return nil, errors.New("failed") },
+		}
+		diags := updateOpenIaasSnapshotWith(context.Background(), d, funcs)
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, "act-r") {
+			t.Fatalf("expected a revert failure naming the activity, got %v", diags)
+		}
+	})
+}
+
+func TestDeleteOpenIaasSnapshotWith(t *testing.T) {
+	notFound := func(ctx context.Context, id string) (string, error) {
+		return "", client.StatusError{Code: 404}
+	}
+
+	t.Run("404 accepted when the strict listings confirm the absence", func(t *testing.T) {
+		d := newOpenIaasSnapRD(t, nil)
+		d.SetId(openIaasSnapTestSnapID)
+		funcs := openIaasSnapshotCRUDFuncs{del: notFound, listStrict: openIaasSnapListings(nil, nil)}
+		if diags := deleteOpenIaasSnapshotWith(context.Background(), d, funcs); diags.HasError() {
+			t.Fatalf("unexpected diags: %v", diags)
+		}
+	})
+
+	t.Run("404 refused while the snapshot is still listed", func(t *testing.T) {
+		d := newOpenIaasSnapRD(t, nil)
+		d.SetId(openIaasSnapTestSnapID)
+		funcs := openIaasSnapshotCRUDFuncs{del: notFound, listStrict: openIaasSnapListings(nil, []string{openIaasSnapTestSnapID})}
+		if diags := deleteOpenIaasSnapshotWith(context.Background(), d, funcs); !diags.HasError() {
+			t.Fatal("a still-listed snapshot must not be treated as deleted")
+		}
+	})
+
+	t.Run("403 fails without an absence check", func(t *testing.T) {
+		d := newOpenIaasSnapRD(t, nil)
+		d.SetId(openIaasSnapTestSnapID)
+		funcs := openIaasSnapshotCRUDFuncs{
+			del: func(ctx context.Context, id string) (string, error) { return "", client.StatusError{Code: 403} },
+			listStrict: func(ctx context.Context, f *client.OpenIaaSSnapshotFilter) ([]*client.OpenIaaSSnapshot, error) {
+				t.Fatal("a 403 must not be probed as an absence")
+				return nil, nil
+			},
+		}
+		if diags := deleteOpenIaasSnapshotWith(context.Background(), d, funcs); !diags.HasError() {
+			t.Fatal("a 403 must fail")
+		}
+	})
+}
@@ -20,6 +20,11 @@ func resourceOpenIaasVirtualDisk() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultComputeTimeout),
+			Update: schema.DefaultTimeout(defaultComputeTimeout),
+			Delete: schema.DefaultTimeout(defaultComputeTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			// In
@@ -143,6 +148,8 @@ func resourceOpenIaasVirtualDisk() *schema.Resource {
 }
 
 func openIaasVirtualDiskCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	unlock := openIaasVirtualMachineMutex.lock(d.Get("virtual_machine_id").(string))
+	defer unlock()
 	c := getClient(meta)
 
 	activityId, err := c.Compute().OpenIaaS().VirtualDisk().Create(ctx, &client.OpenIaaSVirtualDiskCreateRequest{
@@ -351,6 +358,10 @@ func openIaasVirtualDiskRead(ctx context.Context, d *schema.ResourceData, meta i
 }
 
 func openIaasVirtualDiskUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// A move detaches the disk from the source virtual machine: lock both.
+	oldVM, newVM := d.GetChange("virtual_machine_id")
+	unlock := openIaasVirtualMachineMutex.lockAll(oldVM.(string), newVM.(string))
+	defer unlock()
 	c := getClient(meta)
 
 	disk, err := c.Compute().OpenIaaS().VirtualDisk().Read(ctx, d.Id())
@@ -505,6 +516,8 @@ func openIaasVirtualDiskUpdate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func openIaasVirtualDiskDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	unlock := openIaasVirtualMachineMutex.lock(d.Get("virtual_machine_id").(string))
+	defer unlock()
 	c := getClient(meta)
 
 	// Disconnect disk before delete
@@ -26,7 +26,12 @@ func resourceOpenIaasVirtualMachine() *schema.Resource {
 		DeleteContext: openIaasVirtualMachineDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: schema.ImportStatePassthroughWithIdentity("id"),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultComputeTimeout),
+			Update: schema.DefaultTimeout(defaultComputeTimeout),
+			Delete: schema.DefaultTimeout(defaultComputeTimeout),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -180,6 +185,7 @@ Order of the elements in the list is the boot order.`,
 					Type: schema.TypeString,
 				},
 			},
+			"tags_all": tagsAllSchema(),
 			"cloud_init": {
 				Type:     schema.TypeMap,
 				Optional: true,
@@ -205,6 +211,8 @@ Order of the elements in the list is the boot order.`,
 					"^network_config$"},
 					"|")), `The following key is not allowed for cloud-init`),
 			},
+			"cloud_init_wo":         cloudInitWriteOnlySchema(noCloudInitKeys, true),
+			"cloud_init_wo_version": cloudInitWriteOnlyVersionSchema(true),
 			"os_disk": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -422,6 +430,7 @@ Order of the elements in the list is the boot order.`,
 			},
 		},
 		CustomizeDiff: customdiff.All(
+			customizeDiffTagsAll,
 			customdiff.ValidateChange("os_disk", func(ctx context.Context, old, new, meta any) error {
 				o := len(old.([]interface{}))
 				n := len(new.([]interface{}))
@@ -479,8 +488,7 @@ func openIaasVirtualMachineCreate(ctx context.Context, d *schema.ResourceData, m
 
 	// Cloud-Init to configure the virtual machine. A nil result omits the
 	// "cloudInit" field entirely — the API rejects an empty cloudInit object.
-	cloudInitRaw, _ := d.Get("cloud_init").(map[string]interface{})
-	cloudInit, err := buildOpenIaasCloudInit(cloudInitRaw)
+	cloudInit, err := buildOpenIaasCloudInit(cloudInitConfig(d, noCloudInitKeys))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -523,6 +531,9 @@ func openIaasVirtualMachineCreate(ctx context.Context, d *schema.ResourceData, m
 		}
 		activity, err := c.Activity().WaitForCompletion(ctx, activityId, getWaiterOptions(ctx))
 		setIdFromActivityState(d, activity)
+		if waitInterrupted(ctx, err) && d.Id() == "" {
+			return recordPendingCreate(ctx, d, "virtual machine", activityId, err)
+		}
 		if err != nil {
 			return diag.Errorf("failed to create virtual machine, %s", err)
 		}
@@ -566,6 +577,9 @@ func openIaasVirtualMachineCreate(ctx context.Context, d *schema.ResourceData, m
 		}
 		activity, err := c.Activity().WaitForCompletion(ctx, activityId, getWaiterOptions(ctx))
 		setIdFromActivityState(d, activity)
+		if waitInterrupted(ctx, err) && d.Id() == "" {
+			return recordPendingCreate(ctx, d, "virtual machine", activityId, err)
+		}
 		if err != nil {
 			return diag.Errorf("failed to create virtual machine from marketplace item, %s", err)
 		}
@@ -618,6 +632,84 @@ func openIaasVirtualMachineCreate(ctx context.Context, d *schema.ResourceData, m
 	return openIaasVirtualMachineUpdate(ctx, d, meta)
 }
 
+// openIaasVirtualMachineCreateResolver resumes a create interrupted while waiting
+// on its activity (see resumePendingCreate). Both create paths (template and
+// marketplace item) report the new VM id as the activity's single state result.
+func openIaasVirtualMachineCreateResolver(c *client.Client) pendingCreateResolver {
+	return func(ctx context.Context, activityID string) (string, error) {
+		return c.Activity().WaitForCreatedID(ctx, activityID, "virtual machine create", getWaiterOptions(ctx))
+	}
+}
+
+// powerOpenIaaSVirtualMachine powers the virtual machine on or off, and waits for
+// the PV drivers of a powered-on one.
+func powerOpenIaaSVirtualMachine(ctx context.Context, c *client.Client, d *schema.ResourceData, powerState string, hostConfigured bool) error {
+	// Resolve the power-on host (#356): only on power-on (HostId is ignored
+	// on power-off), honoring a configured host_id and otherwise the LIVE
+	// current host — never the possibly-stale Terraform state value.
+	hostID := ""
+	if powerState == "on" {
+		resolved, err := resolveOpenIaaSPowerOnHostID(hostConfigured, d.Get("host_id").(string),
+			func() (string, error) { return openIaaSLiveHostID(ctx, c, d.Id()) })
+		if err != nil {
+			return fmt.Errorf("failed to resolve the power-on host for virtual machine %s: %w", d.Id(), err)
+		}
+		hostID = resolved
+	}
+	activityId, err := c.Compute().OpenIaaS().VirtualMachine().Power(ctx, d.Id(), &client.UpdateOpenIaasVirtualMachinePowerRequest{
+		HostId:                  hostID,
+		PowerState:              powerState,
+		Force:                   false,
+		BypassMacAddressesCheck: false,
+		BypassBlockedOperation:  false,
+		ForceShutdownDelay:      0,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to power %s virtual machine: %s", powerState, err)
+	}
+	if _, err := c.Activity().WaitForCompletion(ctx, activityId, getWaiterOptions(ctx)); err != nil {
+		return fmt.Errorf("failed to power %s virtual machine, %s", powerState, err)
+	}
+	// Avoid trying to wait for the drivers on a halted virtual machine
+	if powerState != "off" {
+		// Wait for the PV drivers to be detected, otherwise operations like creating new network adapters will fail.
+		timeout := time.Duration(d.Get("wait_for_drivers_timeout").(int)) * time.Second
+		if timeout > 0 {
+			if _, err := c.Compute().OpenIaaS().VirtualMachine().WaitForDrivers(ctx, d.Id(), timeout, getWaiterOptions(ctx)); err != nil {
+				return fmt.Errorf("failed to get PV drivers on virtual machine, %s", err)
+			}
+		}
+	}
+	return nil
+}
+
+// openIaasVirtualMachineFinishCreate runs, on a virtual machine adopted from an
+// interrupted create, the create steps that follow the activity: the backup SLA
+// policies, the tags and the power state.
+func openIaasVirtualMachineFinishCreate(c *client.Client, d *schema.ResourceData) pendingCreateFinisher {
+	return func(ctx context.Context) diag.Diagnostics {
+		unlock := openIaasVirtualMachineMutex.lock(d.Id())
+		defer unlock()
+
+		slaPolicies := []string{}
+		for _, policy := range d.Get("backup_sla_policies").(*schema.Set).List() {
+			slaPolicies = append(slaPolicies, policy.(string))
+		}
+		if err := assignBackupSLAPoliciesIfAny(ctx, c, d.Id(), slaPolicies); err != nil {
+			return diag.Errorf("failed to assign policies to virtual machine, %s", err)
+		}
+		if diags := writeTags(ctx, c, d, d.Id(), "iaas_opensource_virtual_machine", "iaas_opensource"); diags != nil {
+			return diags
+		}
+		if d.Get("power_state").(string) == "on" {
+			if err := powerOpenIaaSVirtualMachine(ctx, c, d, "on", d.Get("host_id").(string) != ""); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		return nil
+	}
+}
+
 // assignBackupSLAPoliciesIfAny assigns the given backup SLA policies to the
 // virtual machine and waits for the assign activity to complete. It is a NO-OP
 // when policies is empty: assigning an empty list to a freshly created VM has
@@ -644,6 +736,10 @@ func openIaasVirtualMachineRead(ctx context.Context, d *schema.ResourceData, met
 	c := getClient(meta)
 	var diags diag.Diagnostics
 
+	if diags, stop := resumeAndFinishPendingCreate(ctx, d, "virtual machine", resumePendingCreate, openIaasVirtualMachineCreateResolver(c), openIaasVirtualMachineFinishCreate(c, d)); stop {
+		return diags
+	}
+
 	// Get the virtual machine by its ID
 	vm, err := c.Compute().OpenIaaS().VirtualMachine().Read(ctx, d.Id())
 	if err != nil {
@@ -801,11 +897,9 @@ func openIaasVirtualMachineRead(ctx context.Context, d *schema.ResourceData, met
 		return diag.Errorf("failed to get tags: %s", err)
 	}
 
-	tagsMap := make(map[string]interface{})
-	for _, tag := range tags {
-		tagsMap[tag.Key] = tag.Value
-	}
+	tagsMap, tagsAll := flattenTags(meta, d, tags)
 	d.Set("tags", tagsMap)
+	d.Set("tags_all", tagsAll)
 
 	// Get the SLA policies
 	slaPolicies, err := c.Backup().OpenIaaS().Policy().List(ctx, &client.BackupOpenIaasPolicyFilter{
@@ -845,6 +939,11 @@ func openIaasVirtualMachineRead(ctx context.Context, d *schema.ResourceData, met
 
 func openIaasVirtualMachineUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	c := getClient(meta)
+	if diags, stop := resumeAndFinishPendingCreate(ctx, d, "virtual machine", resumePendingCreateForWrite, openIaasVirtualMachineCreateResolver(c), openIaasVirtualMachineFinishCreate(c, d)); stop {
+		return diags
+	}
+	unlock := openIaasVirtualMachineMutex.lock(d.Id())
+	defer unlock()
 
 	// Capture the host-placement intent once, before any mutation or read
 	// (#355): GetChange/GetRawConfig reflect the plan, not the live mutations
@@ -1080,43 +1179,7 @@ func openIaasVirtualMachineUpdate(ctx context.Context, d *schema.ResourceData, m
 		if powerState != "on" && d.IsNewResource() {
 			return nil
 		}
-		// Resolve the power-on host (#356): only on power-on (HostId is ignored
-		// on power-off), honoring a configured host_id and otherwise the LIVE
-		// current host — never the possibly-stale Terraform state value.
-		hostID := ""
-		if powerState == "on" {
-			resolved, err := resolveOpenIaaSPowerOnHostID(placementInputs.hostConfigured, d.Get("host_id").(string),
-				func() (string, error) { return openIaaSLiveHostID(ctx, c, d.Id()) })
-			if err != nil {
-				return fmt.Errorf("failed to resolve the power-on host for virtual machine %s: %w", d.Id(), err)
-			}
-			hostID = resolved
-		}
-		activityId, err := c.Compute().OpenIaaS().VirtualMachine().Power(ctx, d.Id(), &client.UpdateOpenIaasVirtualMachinePowerRequest{
-			HostId:                  hostID,
-			PowerState:              powerState,
-			Force:                   false,
-			BypassMacAddressesCheck: false,
-			BypassBlockedOperation:  false,
-			ForceShutdownDelay:      0,
-		})
-		if err != nil {
-			return fmt.Errorf("failed to power %s virtual machine: %s", powerState, err)
-		}
-		if _, err := c.Activity().WaitForCompletion(ctx, activityId, getWaiterOptions(ctx)); err != nil {
-			return fmt.Errorf("failed to power %s virtual machine, %s", powerState, err)
-		}
-		// Avoid trying to wait for the drivers on a halted virtual machine
-		if powerState != "off" {
-			// Wait for the PV drivers to be detected, otherwise operations like creating new network adapters will fail.
-			timeout := time.Duration(d.Get("wait_for_drivers_timeout").(int)) * time.Second
-			if timeout > 0 {
-				if _, err := c.Compute().OpenIaaS().VirtualMachine().WaitForDrivers(ctx, d.Id(), timeout, getWaiterOptions(ctx)); err != nil {
-					return fmt.Errorf("failed to get PV drivers on virtual machine, %s", err)
-				}
-			}
-		}
-		return nil
+		return powerOpenIaaSVirtualMachine(ctx, c, d, powerState, placementInputs.hostConfigured)
 	}
 
 	if err := applyOpenIaaSHostPlacement(ctx, d.Id(), placementInputs, newOpenIaaSHostPlacementFuncs(c, powerBlock)); err != nil {
@@ -1132,6 +1195,11 @@ func openIaasVirtualMachineUpdate(ctx context.Context, d *schema.ResourceData, m
 
 func openIaasVirtualMachineDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	c := getClient(meta)
+	if diags, stop := resumeAndFinishPendingCreate(ctx, d, "virtual machine", resumePendingCreateForWrite, openIaasVirtualMachineCreateResolver(c), openIaasVirtualMachineFinishCreate(c, d)); stop {
+		return diags
+	}
+	unlock := openIaasVirtualMachineMutex.lock(d.Id())
+	defer unlock()
 
 	activityId, err := c.Compute().OpenIaaS().VirtualMachine().Delete(ctx, d.Id())
 	if err != nil {
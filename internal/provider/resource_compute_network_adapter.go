@@ -24,6 +24,11 @@ func resourceNetworkAdapter() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Duration(0)),
+			Update: schema.DefaultTimeout(defaultComputeTimeout),
+			Delete: schema.DefaultTimeout(defaultComputeTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			// In
@@ -86,6 +91,8 @@ func resourceNetworkAdapter() *schema.Resource {
 }
 
 func computeNetworkAdapterCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	ctx, cancel := withConfiguredTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
 	c := getClient(meta)
 
 	// Reject ip_address on a non-VPC network BEFORE creating anything.
@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	computeSnapshotCreateTimeout = 30 * time.Minute
+	computeSnapshotRevertTimeout = 30 * time.Minute
+	computeSnapshotDeleteTimeout = 30 * time.Minute
+)
+
+func resourceComputeSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a snapshot of a virtual machine. Create, revert and delete are asynchronous. The snapshot itself is immutable (name and virtual_machine_id force a new snapshot); changing `revert_trigger` reverts the virtual machine to the snapshot.",
+
+		CreateContext: computeSnapshotCreate,
+		ReadContext:   computeSnapshotRead,
+		UpdateContext: computeSnapshotUpdate,
+		DeleteContext: computeSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importVMScopedResource(),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(computeSnapshotCreateTimeout),
+			Update: schema.DefaultTimeout(computeSnapshotRevertTimeout),
+			Delete: schema.DefaultTimeout(computeSnapshotDeleteTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			// In
+			"virtual_machine_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+				Description:  "The ID of the virtual machine to snapshot. Immutable.",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The name of the snapshot. Immutable (renaming recreates the snapshot).",
+			},
+			"revert_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An arbitrary value (e.g. a timestamp or a counter). Any change to it after creation reverts the virtual machine to this snapshot; the value set at creation does not trigger a revert. A failed revert keeps the previous value in the state so the next apply retries it.",
+			},
+
+			// Out
+			"create_time": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The timestamp when the snapshot was created (in Unix time format).",
+			},
+		},
+	}
+}
+
+// computeSnapshotReadMode selects how a snapshot missing from the listing is
+// treated (see readComputeSnapshotInto).
+type computeSnapshotReadMode int
+
+const (
+	computeSnapshotReadForRefresh computeSnapshotReadMode = iota
+	computeSnapshotReadAfterWrite
+)
+
+// computeSnapshotCRUDFuncs abstracts the client surface for unit testing without HTTP.
+type computeSnapshotCRUDFuncs struct {
+	create       func(ctx context.Context, req *client.CreateSnapshotRequest) (string, error)
+	revert       func(ctx context.Context, id string) (string, error)
+	del          func(ctx context.Context, id string) (string, error)
+	listStrict   func(ctx context.Context, filter *client.SnapshotFilter) ([]*client.Snapshot, error)
+	waitActivity func(ctx context.Context, activityID string) (*client.Activity, error)
+}
+
+func computeSnapshotClientFuncs(c *client.Client) computeSnapshotCRUDFuncs {
+	snap := c.Compute().Snapshot()
+	return computeSnapshotCRUDFuncs{
+		create:     snap.Create,
+		revert:     snap.Revert,
+		del:        snap.Delete,
+		listStrict: snap.ListStrict,
+		waitActivity: func(ctx context.Context, activityID string) (*client.Activity, error) {
+			return c.Activity().WaitForCompletion(ctx, activityID, getWaiterOptions(ctx))
+		},
+	}
+}
+
+func computeSnapshotCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	unlock := vmwareVirtualMachineMutex.lock(d.Get("virtual_machine_id").(string))
+	defer unlock()
+	return createComputeSnapshotWith(ctx, d, computeSnapshotClientFuncs(getClient(meta)))
+}
+
+func computeSnapshotRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	return readComputeSnapshotInto(ctx, d, computeSnapshotClientFuncs(getClient(meta)), computeSnapshotReadForRefresh)
+}
+
+func computeSnapshotUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	unlock := vmwareVirtualMachineMutex.lock(d.Get("virtual_machine_id").(string))
+	defer unlock()
+	return updateComputeSnapshotWith(ctx, d, computeSnapshotClientFuncs(getClient(meta)))
+}
+
+func computeSnapshotDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	unlock := vmwareVirtualMachineMutex.lock(d.Get("virtual_machine_id").(string))
+	defer unlock()
+	return deleteComputeSnapshotWith(ctx, d, computeSnapshotClientFuncs(getClient(meta)))
+}
+
+// createComputeSnapshotWith: the snapshot id is taken from the completed
+// activity — the "snapshot" concerned item, else the terminal state result —
+// validated (non-empty, UUID, != vmID) before SetId.
+func createComputeSnapshotWith(ctx context.Context, d *schema.ResourceData, funcs computeSnapshotCRUDFuncs) diag.Diagnostics {
+	vmID := d.Get("virtual_machine_id").(string)
+	name := d.Get("name").(string)
+
+	activityID, err := funcs.create(ctx, &client.CreateSnapshotRequest{
+		VirtualMachineId: vmID,
+		Name:             name,
+	})
+	if err != nil {
+		return diag.Errorf("failed to create snapshot %q of virtual machine %s: %s", name, vmID, err)
+	}
+	activity, err := funcs.waitActivity(ctx, activityID)
+	if err != nil {
+		return diag.Errorf(
+			"snapshot %q create on virtual machine %s: activity %q did not complete: %s. If a snapshot was created it is ORPHANED outside the state — audit the virtual machine's snapshots and import it (terraform import <vmID>/<snapshotID>) or delete it before re-applying.",
+			name, vmID, activityID, err,
+		)
+	}
+
+	candidate := activityConcernedItemID(activity, "snapshot")
+	if candidate == "" {
+		candidate = singleActivityResult(activity)
+	}
+	if candidate == "" || !isUUID(candidate) || sameUUID(candidate, vmID) {
+		return diag.Errorf(
+			"snapshot %q create on virtual machine %s (activity %q) did not report a usable snapshot id (got %q); refusing to guess. Audit the virtual machine's snapshots and import the new one if it was created.",
+			name, vmID, activityID, candidate,
+		)
+	}
+	d.SetId(candidate)
+
+	return readComputeSnapshotInto(ctx, d, funcs, computeSnapshotReadAfterWrite)
+}
+
+// readComputeSnapshotInto reads the snapshot through the strict VM-scoped
+// listing (the API has no per-id read). A snapshot missing from the listing is
+// never dropped: it goes through confirmVMwareDeviceOrKeep like the other VMware
+// devices, which always keeps the resource and fails closed (#281).
+func readComputeSnapshotInto(ctx context.Context, d *schema.ResourceData, funcs computeSnapshotCRUDFuncs, mode computeSnapshotReadMode) diag.Diagnostics {
+	vmID := d.Get("virtual_machine_id").(string)
+	snapshotID := d.Id()
+
+	snapshots, err := funcs.listStrict(ctx, &client.SnapshotFilter{VirtualMachineID: vmID})
+	if err != nil {
+		return diag.Errorf("failed to list the snapshots of virtual machine %s: %s. The snapshot %s is kept in the state (a forbidden or backend error is not proof of absence).", vmID, err, snapshotID)
+	}
+
+	var snapshot *client.Snapshot
+	ids := make([]string, 0, len(snapshots))
+	for _, s := range snapshots {
+		if s == nil {
+			continue
+		}
+		ids = append(ids, s.ID)
+		if sameUUID(s.ID, snapshotID) {
+			snapshot = s
+		}
+	}
+	if snapshot == nil {
+		if mode == computeSnapshotReadAfterWrite {
+			return diag.Errorf("snapshot %s of virtual machine %s was just written but is not yet listed (eventual consistency); the resource is kept in the state with its id.", snapshotID, vmID)
+		}
+		return confirmVMwareDeviceOrKeep(ctx, snapshotID, "snapshot", "virtual machine", vmID,
+			func(ctx context.Context) ([]string, error) {
+				return ids, nil
+			})
+	}
+
+	sw := newStateWriter(d)
+	sw.set("virtual_machine_id", vmID)
+	sw.set("name", snapshot.Name)
+	sw.set("create_time", snapshot.CreateTime)
+	return sw.diags
+}
+
+// updateComputeSnapshotWith reverts the virtual machine when revert_trigger
+// changes. A failed revert is reported with d.Partial(true) so the previous
+// trigger value stays in the state and the next apply retries the revert.
+func updateComputeSnapshotWith(ctx context.Context, d *schema.ResourceData, funcs computeSnapshotCRUDFuncs) diag.Diagnostics {
+	if !d.HasChange("revert_trigger") {
+		return readComputeSnapshotInto(ctx, d, funcs, computeSnapshotReadAfterWrite)
+	}
+	vmID := d.Get("virtual_machine_id").(string)
+	snapshotID := d.Id()
+
+	activityID, err := funcs.revert(ctx, snapshotID)
+	if err != nil {
+		d.Partial(true)
+		return diag.Errorf("failed to revert virtual machine %s to snapshot %s: %s", vmID, snapshotID, err)
+	}
+	if _, err := funcs.waitActivity(ctx, activityID); err != nil {
+		d.Partial(true)
+		return diag.Errorf("revert of virtual machine %s to snapshot %s: activity %q did not complete: %s", vmID, snapshotID, activityID, err)
+	}
+
+	return readComputeSnapshotInto(ctx, d, funcs, computeSnapshotReadAfterWrite)
+}
+
+// deleteComputeSnapshotWith: a 404 on delete is accepted only once the strict
+// VM-scoped listing confirms the snapshot is gone; any other error or a failed
+// activity keeps the resource in the state.
+func deleteComputeSnapshotWith(ctx context.Context, d *schema.ResourceData, funcs computeSnapshotCRUDFuncs) diag.Diagnostics {
+	vmID := d.Get("virtual_machine_id").(string)
+	snapshotID := d.Id()
+
+	activityID, err := funcs.del(ctx, snapshotID)
+	if err != nil {
+		if !isStatusCode(err, http.StatusNotFound) {
+			return diag.Errorf("failed to delete snapshot %s of virtual machine %s: %s", snapshotID, vmID, err)
+		}
+		snapshots, lerr := funcs.listStrict(ctx, &client.SnapshotFilter{VirtualMachineID: vmID})
+		if lerr != nil {
+			return diag.Errorf("snapshot %s of virtual machine %s was reported not found on delete but its absence could not be confirmed (strict listing failed: %s); the resource is kept in the state.", snapshotID, vmID, lerr)
+		}
+		for _, s := range snapshots {
+			if s != nil && sameUUID(s.ID, snapshotID) {
+				return diag.Errorf("snapshot %s was reported not found on delete but is still listed on virtual machine %s; refusing to drop it (possible access restriction).", snapshotID, vmID)
+			}
+		}
+		return nil
+	}
+	if _, err := funcs.waitActivity(ctx, activityID); err != nil {
+		return diag.Errorf("snapshot %s delete on virtual machine %s: activity %q did not complete: %s", snapshotID, vmID, activityID, err)
+	}
+	return nil
+}
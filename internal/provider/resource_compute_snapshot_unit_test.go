@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	computeSnapTestVMID   = "eeeeeeee-eeee-eeee-eeee-eeeeeeeeeeee"
+	computeSnapTestSnapID = "ffffffff-ffff-ffff-ffff-ffffffffffff"
+)
+
+func newComputeSnapRD(t *testing.T, raw map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+	cfg := map[string]interface{}{
+		"virtual_machine_id": computeSnapTestVMID,
+		"name":               "pre-upgrade",
+	}
+	for k, v := range raw {
+		cfg[k] = v
+	}
+	return schema.TestResourceDataRaw(t, resourceComputeSnapshot().Schema, cfg)
+}
+
+func computeSnapListing(ids ...string) func(ctx context.Context, filter *client.SnapshotFilter) ([]*client.Snapshot, error) {
+	return func(ctx context.Context, filter *client.SnapshotFilter) ([]*client.Snapshot, error) {
+		out := make([]*client.Snapshot, 0, len(ids))
+		for _, id := range ids {
+			out = append(out, &client.Snapshot{ID: id, VirtualMachineId: filter.VirtualMachineID, Name: "pre-upgrade", CreateTime: 1700000000})
+		}
+		return out, nil
+	}
+}
+
+func TestCreateComputeSnapshotWith(t *testing.T) {
+	t.Run("id from the snapshot concerned item", func(t *testing.T) {
+		d := newComputeSnapRD(t, nil)
+		var sent *client.CreateSnapshotRequest
+		funcs := computeSnapshotCRUDFuncs{
+			create: func(ctx context.Context, req *client.CreateSnapshotRequest) (string, error) {
+				sent = req
+				return "act", nil
+			},
+			waitActivity: func(ctx context.Context, a string) (*client.Activity, error) {
+				return &client.Activity{
+					State:          map[string]client.ActivityState{"completed": {Result: computeSnapTestVMID}},
+					ConcernedItems: []client.ActivityConcernedItem{{ID: computeSnapTestVMID, Type: "virtual_machine"}, {ID: computeSnapTestSnapID, Type: "snapshot"}},
+				}, nil
+			},
+			listStrict: computeSnapListing(computeSnapTestSnapID),
+		}
+		if diags := createComputeSnapshotWith(context.Background(), d, funcs); diags.HasError() {
+			t.Fatalf("unexpected diags: %v", diags)
+		}
+		if sent == nil || sent.VirtualMachineId != computeSnapTestVMID || sent.Name != "pre-upgrade" {
+			t.Fatalf("unexpected create request: %+v", sent)
+		}
+		if d.Id() != computeSnapTestSnapID {
+			t.Fatalf("id = %q, want %q", d.Id(), computeSnapTestSnapID)
+		}
+		if d.Get("create_time").(int) != 1700000000 {
+			t.Fatalf("state not set: create_time=%v", d.Get("create_time"))
+		}
+	})
+
+	t.Run("no usable id fails closed", func(t *testing.T) {
+		for _, result := range []string{"", computeSnapTestVMID, "not-a-uuid"} {
+			d := newComputeSnapRD(t, nil)
+			r := result
+			funcs := computeSnapshotCRUDFuncs{
+				create: func(ctx context.Context, req *client.CreateSnapshotRequest) (string, error) { return "act", nil },
+				waitActivity: func(ctx context.Context, a string) (*client.Activity, error) {
+					return &client.Activity{State: map[string]client.ActivityState{"completed": {Result: r}}}, nil
+				},
+			}
+			if diags := createComputeSnapshotWith(context.Background(), d, funcs); !diags.HasError() {
+				t.Fatalf("result %q must fail closed", r)
+			}
+			if d.Id() != "" {
+				t.Fatalf("result %q must not set an id", r)
+			}
+		}
+	})
+
+	t.Run("a failed activity names the orphan risk", func(t *testing.T) {
+		d := newComputeSnapRD(t, nil)
+		funcs := computeSnapshotCRUDFuncs{
+			create:       func(ctx context.Context, req *client.CreateSnapshotRequest) (string, error) { return "act-1", nil },
+			waitActivity: func(ctx context.Context, a string) (*client.Activity, error) { return nil, errors.New("failed") },
+		}
+		diags := createComputeSnapshotWith(context.Background(), d, funcs)
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, "ORPHANED") || !strings.Contains(diags[0].Summary, "act-1") {
+			t.Fatalf("expected an orphan diagnostic naming the activity, got %v", diags)
+		}
+		if d.Id() != "" {
+			t.Fatal("a wait failure must not set an id")
+		}
+	})
+}
+
+func TestReadComputeSnapshotInto(t *testing.T) {
+	t.Run("a listed snapshot is refreshed", func(t *testing.T) {
+		d := newComputeSnapRD(t, nil)
+		d.SetId(computeSnapTestSnapID)
+		funcs := computeSnapshotCRUDFuncs{listStrict: computeSnapListing("11111111-1111-1111-1111-111111111111", strings.ToUpper(computeSnapTestSnapID))}
+		if diags := readComputeSnapshotInto(context.Background(), d, funcs, computeSnapshotReadForRefresh); diags.HasError() {
+			t.Fatalf("unexpected diags: %v", diags)
+		}
+		if d.Get("name").(string) != "pre-upgrade" {
+			t.Fatalf("name = %q", d.Get("name"))
+		}
+	})
+
+	t.Run("a snapshot missing from the listing is kept and fails closed", func(t *testing.T) {
+		for _, mode := range []computeSnapshotReadMode{computeSnapshotReadForRefresh, computeSnapshotReadAfterWrite} {
+			d := newComputeSnapRD(t, nil)
+			d.SetId(computeSnapTestSnapID)
+			funcs := computeSnapshotCRUDFuncs{listStrict: computeSnapListing()}
+			if diags := readComputeSnapshotInto(context.Background(), d, funcs, mode); !diags.HasError() {
+				t.Fatalf("mode %d: an unlisted snapshot must fail closed", mode)
+			}
+			if d.Id() != computeSnapTestSnapID {
+				t.Fatalf("mode %d: the resource must never be dropped", mode)
+			}
+		}
+	})
+
+	t.Run("a listing error keeps the resource", func(t *testing.T) {
+		d := newComputeSnapRD(t, nil)
+		d.SetId(computeSnapTestSnapID)
+		funcs := computeSnapshotCRUDFuncs{listStrict: func(ctx context.Context, f *client.SnapshotFilter) ([]*client.Snapshot, error) {
+			return nil, client.StatusError{Code: 403}
+		}}
+		if diags := readComputeSnapshotInto(context.Background(), d, funcs, computeSnapshotReadForRefresh); !diags.HasError() {
+			t.Fatal("a listing error must fail closed")
+		}
+		if d.Id() != computeSnapTestSnapID {
+			t.Fatal("a listing error must not drop the resource")
+		}
+	})
+}
+
+func TestUpdateComputeSnapshotWith(t *testing.T) {
+	t.Run("a trigger change reverts to the snapshot", func(t *testing.T) {
+		d := newComputeSnapRD(t, map[string]interface{}{"revert_trigger": "2026-10-01"})
+		d.SetId(computeSnapTestSnapID)
+		var reverted string
+		funcs := computeSnapshotCRUDFuncs{
+			revert: func(ctx context.Context, id string) (string, error) {
+				reverted = id
+				return "act", nil
+			},
+			waitActivity: func(ctx context.Context, a string) (*client.Activity, error) { return &client.Activity{}, nil },
+			listStrict:   computeSnapListing(computeSnapTestSnapID),
+		}
+		if diags := updateComputeSnapshotWith(context.Background(), d, funcs); diags.HasError() {
+			t.Fatalf("unexpected diags: %v", diags)
+		}
+		if reverted != computeSnapTestSnapID {
+			t.Fatalf("reverted %q, want %q", reverted, computeSnapTestSnapID)
+		}
+	})
+
+	t.Run("no trigger change never reverts", func(t *testing.T) {
+		d := newComputeSnapRD(t, nil)
+		d.SetId(computeSnapTestSnapID)
+		funcs := computeSnapshotCRUDFuncs{
+			revert: func(ctx context.Context, id string) (string, error) {
+				t.Fatal("revert must not be called without a trigger change")
+				return "", nil
+			},
+			listStrict: computeSnapListing(computeSnapTestSnapID),
+		}
+		if diags := updateComputeSnapshotWith(context.Background(), d, funcs); diags.HasError() {
+			t.Fatalf("unexpected diags: %v", diags)
+		}
+	})
+
+	t.Run("a failed revert is reported", func(t *testing.T) {
+		d := newComputeSnapRD(t, map[string]interface{}{"revert_trigger": "2"})
+		d.SetId(computeSnapTestSnapID)
+		funcs := computeSnapshotCRUDFuncs{
+			revert:       func(ctx context.Context, id string) (string, error) { return "act-r", nil },
+			waitActivity: func(ctx context.Context, a string) (*client.Activity, error) { return nil, errors.New("failed") },
+		}
+		diags := updateComputeSnapshotWith(context.Background(), d, funcs)
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, "act-r") {
+			t.Fatalf("expected a revert failure naming the activity, got %v", diags)
+		}
+	})
+}
+
+func TestDeleteComputeSnapshotWith(t *testing.T) {
+	notFound := func(ctx context.Context, id string) (string, error) {
+		return "", client.StatusError{Code: 404}
+	}
+
+	t.Run("404 accepted when the strict listing confirms the absence", func(t *testing.T) {
+		d := newComputeSnapRD(t, nil)
+		d.SetId(computeSnapTestSnapID)
+		funcs := computeSnapshotCRUDFuncs{del: notFound, listStrict: computeSnapListing()}
+		if diags := deleteComputeSnapshotWith(context.Background(), d, funcs); diags.HasError() {
+			t.Fatalf("unexpected diags: %v", diags)
+		}
+	})
+
+	t.Run("404 refused while the snapshot is still listed", func(t *testing.T) {
+		d := newComputeSnapRD(t, nil)
+		d.SetId(computeSnapTestSnapID)
+		funcs := computeSnapshotCRUDFuncs{del: notFound, listStrict: computeSnapListing(computeSnapTestSnapID)}
+		if diags := deleteComputeSnapshotWith(context.Background(), d, funcs); !diags.HasError() {
+			t.Fatal("a still-listed snapshot must not be treated as deleted")
+		}
+	})
+
+	t.Run("403 fails without an absence check", func(t *testing.T) {
+		d := newComputeSnapRD(t, nil)
+		d.SetId(computeSnapTestSnapID)
+		funcs := computeSnapshotCRUDFuncs{
+			del: func(ctx context.Context, id string) (string, error) { return "", client.StatusError{Code: 403} },
+			listStrict: func(ctx context.Context, f *client.SnapshotFilter) ([]*client.Snapshot, error) {
+				t.Fatal("a 403 must not be probed as an absence")
+				return nil, nil
+			},
+		}
+		if diags := deleteComputeSnapshotWith(context.Background(), d, funcs); !diags.HasError() {
+			t.Fatal("a 403 must fail")
+		}
+	})
+}
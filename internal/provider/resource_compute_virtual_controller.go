@@ -21,6 +21,11 @@ func resourceVirtualController() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultComputeTimeout),
+			Update: schema.DefaultTimeout(defaultComputeTimeout),
+			Delete: schema.DefaultTimeout(defaultComputeTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			//In
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"time"
 
 	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
 	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/provider/helpers"
@@ -21,6 +22,11 @@ func resourceVirtualDisk() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Duration(0)),
+			Update: schema.DefaultTimeout(defaultComputeTimeout),
+			Delete: schema.DefaultTimeout(defaultComputeTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			// In
@@ -142,6 +148,8 @@ func resourceVirtualDisk() *schema.Resource {
 }
 
 func computeVirtualDiskCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	ctx, cancel := withConfiguredTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
 	c := getClient(meta)
 
 	activityId, err := c.Compute().VirtualDisk().Create(ctx, &client.CreateVirtualDiskRequest{
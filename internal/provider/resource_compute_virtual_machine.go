@@ -33,7 +33,23 @@ func resourceVirtualMachine() *schema.Resource {
 		DeleteContext:        computeVirtualMachineDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: schema.ImportStatePassthroughWithIdentity("id"),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Duration(0)),
+			Update: schema.DefaultTimeout(defaultComputeTimeout),
+			Delete: schema.DefaultTimeout(defaultComputeTimeout),
+		},
+
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validation.PreferWriteOnlyAttribute(
+				cty.GetAttrPath("customize").Index(cty.UnknownVal(cty.Number)).GetAttr("windows_config").Index(cty.UnknownVal(cty.Number)).GetAttr("password"),
+				cty.GetAttrPath("customize").Index(cty.UnknownVal(cty.Number)).GetAttr("windows_config").Index(cty.UnknownVal(cty.Number)).GetAttr("password_wo"),
+			),
+			validation.PreferWriteOnlyAttribute(
+				cty.GetAttrPath("customize").Index(cty.UnknownVal(cty.Number)).GetAttr("windows_config").Index(cty.UnknownVal(cty.Number)).GetAttr("domain").Index(cty.UnknownVal(cty.Number)).GetAttr("admin_password"),
+				cty.GetAttrPath("customize").Index(cty.UnknownVal(cty.Number)).GetAttr("windows_config").Index(cty.UnknownVal(cty.Number)).GetAttr("domain").Index(cty.UnknownVal(cty.Number)).GetAttr("admin_password_wo"),
+			),
 		},
 
 		SchemaVersion: 1,
@@ -120,6 +136,8 @@ func resourceVirtualMachine() *schema.Resource {
 					"^seedfrom$"},
 					"|")), `The following key is not allowed for cloud-init`),
 			},
+			"cloud_init_wo":         cloudInitWriteOnlySchema(vmwareCloudInitKeys, false),
+			"cloud_init_wo_version": cloudInitWriteOnlyVersionSchema(false),
 			"customize": {
 				Type:        schema.TypeList,
 				MaxItems:    1,
@@ -225,7 +243,22 @@ func resourceVirtualMachine() *schema.Resource {
 										Optional: true,
 										Description: `The new administrator password for the machine. To specify that the password should be set to blank (that is, no password), set the password value to NULL. Because of encryption, "" is NOT a valid value.
 										If password is set to blank and autoLogon is set, the guest customization will fail.`,
-										Sensitive: true,
+										Sensitive:     true,
+										ConflictsWith: []string{"customize.0.windows_config.0.password_wo"},
+									},
+									"password_wo": {
+										Type:          schema.TypeString,
+										Optional:      true,
+										Sensitive:     true,
+										WriteOnly:     true,
+										ConflictsWith: []string{"customize.0.windows_config.0.password"},
+										Description:   "The write-only alternative to `password` (Terraform 1.11+): it is sent to the guest customization but never written to the plan or the state.",
+									},
+									"password_wo_version": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										RequiredWith: []string{"customize.0.windows_config.0.password_wo"},
+										Description:  "Any value, to change when `password_wo` changes: Terraform cannot see a change of a write-only value, changing this one runs the guest customization again.",
 									},
 									"domain": {
 										Type:          schema.TypeList,
@@ -238,10 +271,9 @@ func resourceVirtualMachine() *schema.Resource {
 												"name": {
 													Type:        schema.TypeString,
 													Optional:    true,
-													Description: "The domain that the virtual machine should join. If this value is supplied, then admin_username and admin_password must also be supplied, and the workgroup name must be empty.",
+													Description: "The domain that the virtual machine should join. If this value is supplied, then admin_username and admin_password (or admin_password_wo) must also be supplied, and the workgroup name must be empty.",
 													RequiredWith: []string{
 														"customize.0.windows_config.0.domain.0.admin_username",
-														"customize.0.windows_config.0.domain.0.admin_password",
 													},
 												},
 												"admin_username": {
@@ -250,7 +282,6 @@ func resourceVirtualMachine() *schema.Resource {
 													Description: "This is the domain user account used for authentication if the virtual machine is joining a domain. The user does not need to be a domain administrator, but the account must have the privileges required to add computers to the domain.",
 													RequiredWith: []string{
 														"customize.0.windows_config.0.domain.0.name",
-														"customize.0.windows_config.0.domain.0.admin_password",
 													},
 												},
 												"admin_password": {
@@ -262,6 +293,31 @@ func resourceVirtualMachine() *schema.Resource {
 														"customize.0.windows_config.0.domain.0.admin_username",
 														"customize.0.windows_config.0.domain.0.name",
 													},
+													ExactlyOneOf: []string{
+														"customize.0.windows_config.0.domain.0.admin_password",
+														"customize.0.windows_config.0.domain.0.admin_password_wo",
+													},
+												},
+												"admin_password_wo": {
+													Type:        schema.TypeString,
+													Optional:    true,
+													Description: "The write-only alternative to `admin_password` (Terraform 1.11+): it is sent to the guest customization but never written to the plan or the state.",
+													Sensitive:   true,
+													WriteOnly:   true,
+													RequiredWith: []string{
+														"customize.0.windows_config.0.domain.0.admin_username",
+														"customize.0.windows_config.0.domain.0.name",
+													},
+													ExactlyOneOf: []string{
+														"customize.0.windows_config.0.domain.0.admin_password",
+														"customize.0.windows_config.0.domain.0.admin_password_wo",
+													},
+												},
+												"admin_password_wo_version": {
+													Type:         schema.TypeInt,
+													Optional:     true,
+													RequiredWith: []string{"customize.0.windows_config.0.domain.0.admin_password_wo"},
+													Description:  "Any value, to change when `admin_password_wo` changes: Terraform cannot see a change of a write-only value, changing this one runs the guest customization again.",
 												},
 											},
 										},
@@ -395,6 +451,7 @@ func resourceVirtualMachine() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"tags_all": tagsAllSchema(),
 			"backup_sla_policies": {
 				Type:        schema.TypeSet,
 				Optional:    true,
@@ -833,6 +890,7 @@ Test mode creates temporary virtual machines for development or testing, snapsho
 			},
 		},
 		CustomizeDiff: customdiff.All(
+			customizeDiffTagsAll,
 			customdiff.ValidateChange("os_disk", func(ctx context.Context, old, new, meta any) error {
 				o := len(old.([]interface{}))
 				n := len(new.([]interface{}))
@@ -1020,6 +1078,8 @@ func resolveVMwareUpdateSizing(dMemory, dCPU, dCores int, live *client.VirtualMa
 }
 
 func computeVirtualMachineCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	ctx, cancel := withConfiguredTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
 	c := getClient(meta)
 	name := d.Get("name").(string)
 
@@ -1037,7 +1097,7 @@ func computeVirtualMachineCreate(ctx context.Context, d *schema.ResourceData, me
 		})
 	}
 
-	for k, v := range d.Get("cloud_init").(map[string]interface{}) {
+	for k, v := range cloudInitConfig(d, vmwareCloudInitKeys) {
 		if !helpers.Exists(deployOptions, func(i *client.DeployOption) bool {
 			return i.ID == k
 		}) {
@@ -1064,6 +1124,9 @@ func computeVirtualMachineCreate(ctx context.Context, d *schema.ResourceData, me
 
 		activity, err := c.Activity().WaitForCompletion(ctx, activityId, getWaiterOptions(ctx))
 		setIdFromActivityState(d, activity)
+		if waitInterrupted(ctx, err) && d.Id() == "" {
+			return recordPendingCreate(ctx, d, "virtual machine", activityId, err)
+		}
 		if err != nil {
 			return diag.Errorf("failed to clone virtual machine, %s", err)
 		}
@@ -1091,6 +1154,9 @@ func computeVirtualMachineCreate(ctx context.Context, d *schema.ResourceData, me
 
 		activity, err := c.Activity().WaitForCompletion(ctx, activityId, getWaiterOptions(ctx))
 		setIdFromActivityState(d, activity)
+		if waitInterrupted(ctx, err) && d.Id() == "" {
+			return recordPendingCreate(ctx, d, "virtual machine", activityId, err)
+		}
 		if err != nil {
 			return diag.Errorf("failed to deploy content library item: %s", err)
 		}
@@ -1134,6 +1200,9 @@ func computeVirtualMachineCreate(ctx context.Context, d *schema.ResourceData, me
 
 		activity, err := c.Activity().WaitForCompletion(ctx, activityId, getWaiterOptions(ctx))
 		setIdFromActivityState(d, activity)
+		if waitInterrupted(ctx, err) && d.Id() == "" {
+			return recordPendingCreate(ctx, d, "virtual machine", activityId, err)
+		}
 		if err != nil {
 			return diag.Errorf("failed to deploy marketplace item: %s", err)
 		}
@@ -1161,6 +1230,9 @@ func computeVirtualMachineCreate(ctx context.Context, d *schema.ResourceData, me
 
 		activity, err := c.Activity().WaitForCompletion(ctx, activityId, getWaiterOptions(ctx))
 		setIdFromActivityConcernedItems(d, activity, "virtual_machine")
+		if waitInterrupted(ctx, err) && d.Id() == "" {
+			return recordPendingCreate(ctx, d, "virtual machine", activityId, err)
+		}
 		if err != nil {
 			return diag.Errorf("failed to create virtual machine: %s", err)
 		}
@@ -1194,77 +1266,144 @@ func computeVirtualMachineCreate(ctx context.Context, d *schema.ResourceData, me
 		return diag.FromErr(err)
 	}
 
-	if len(d.Get("customize").([]interface{})) > 0 {
-		customizationRequest := helpers.BuildGuestOSCustomizationRequest(ctx, d)
-		activityId, err = c.Compute().VirtualMachine().CustomizeGuestOS(ctx, d.Id(), customizationRequest)
-		if err != nil {
-			return diag.Errorf("failed to customize virtual machine guest os: %s", err)
-		}
+	if diags := customizeVirtualMachineGuestOS(ctx, c, d); diags != nil {
+		return diags
+	}
 
-		_, err = c.Activity().WaitForCompletion(ctx, activityId, getWaiterOptions(ctx))
-		if err != nil {
-			return diag.Errorf("an error has occured while customizing virtual machine guest os, %s", err)
-		}
+	if diags := assignVirtualMachineBackupSLAPolicies(ctx, c, d); diags != nil {
+		return diags
 	}
 
-	if len(d.Get("backup_sla_policies").(*schema.Set).List()) > 0 {
-		// First we need to update the catalog
-		jobs, err := c.Backup().Job().List(ctx, &client.BackupJobFilter{
-			Type: "catalog",
-		})
-		if err != nil {
-			return diag.Errorf("failed to find catalog job: %s", err)
-		}
+	return updateVirtualMachine(ctx, d, meta, d.Get("power_state").(string) == "on", true)
+}
 
-		var job = &client.BackupJob{}
-		for _, currJob := range jobs {
-			if currJob.Name == "Hypervisor Inventory" {
-				job = currJob
-			}
-		}
+// customizeVirtualMachineGuestOS customizes the guest OS of a created virtual
+// machine, when a customize block is set.
+func customizeVirtualMachineGuestOS(ctx context.Context, c *client.Client, d *schema.ResourceData) diag.Diagnostics {
+	if len(d.Get("customize").([]interface{})) == 0 {
+		return nil
+	}
 
-		activityId, err := c.Backup().Job().Run(ctx, &client.BackupJobRunRequest{
-			JobId: job.ID,
-		})
-		if err != nil {
-			return diag.Errorf("failed to update catalog: %s", err)
-		}
+	customizationRequest := helpers.BuildGuestOSCustomizationRequest(ctx, d)
+	activityId, err := c.Compute().VirtualMachine().CustomizeGuestOS(ctx, d.Id(), customizationRequest)
+	if err != nil {
+		return diag.Errorf("failed to customize virtual machine guest os: %s", err)
+	}
 
-		_, err = c.Activity().WaitForCompletion(ctx, activityId, getWaiterOptions(ctx))
-		if err != nil {
-			return diag.Errorf("failed to update catalog, %s", err)
-		}
+	_, err = c.Activity().WaitForCompletion(ctx, activityId, getWaiterOptions(ctx))
+	if err != nil {
+		return diag.Errorf("an error has occured while customizing virtual machine guest os, %s", err)
+	}
+	return nil
+}
 
-		_, err = c.Backup().VirtualMachine().WaitForInventory(ctx, d.Id(), getWaiterOptions(ctx))
-		if err != nil {
-			return diag.Errorf("failed to find virtual machine in backup inventory : %s", err)
+// assignVirtualMachineBackupSLAPolicies assigns the backup_sla_policies to a
+// created virtual machine, once the backup catalog lists it.
+func assignVirtualMachineBackupSLAPolicies(ctx context.Context, c *client.Client, d *schema.ResourceData) diag.Diagnostics {
+	if len(d.Get("backup_sla_policies").(*schema.Set).List()) == 0 {
+		return nil
+	}
+
+	// First we need to update the catalog
+	jobs, err := c.Backup().Job().List(ctx, &client.BackupJobFilter{
+		Type: "catalog",
+	})
+	if err != nil {
+		return diag.Errorf("failed to find catalog job: %s", err)
+	}
+
+	var job = &client.BackupJob{}
+	for _, currJob := range jobs {
+		if currJob.Name == "Hypervisor Inventory" {
+			job = currJob
 		}
+	}
 
-		slaPolicies := []string{}
-		for _, policy := range d.Get("backup_sla_policies").(*schema.Set).List() {
-			slaPolicies = append(slaPolicies, policy.(string))
+	activityId, err := c.Backup().Job().Run(ctx, &client.BackupJobRunRequest{
+		JobId: job.ID,
+	})
+	if err != nil {
+		return diag.Errorf("failed to update catalog: %s", err)
+	}
+
+	_, err = c.Activity().WaitForCompletion(ctx, activityId, getWaiterOptions(ctx))
+	if err != nil {
+		return diag.Errorf("failed to update catalog, %s", err)
+	}
+
+	_, err = c.Backup().VirtualMachine().WaitForInventory(ctx, d.Id(), getWaiterOptions(ctx))
+	if err != nil {
+		return diag.Errorf("failed to find virtual machine in backup inventory : %s", err)
+	}
+
+	slaPolicies := []string{}
+	for _, policy := range d.Get("backup_sla_policies").(*schema.Set).List() {
+		slaPolicies = append(slaPolicies, policy.(string))
+	}
+	activityId, err = c.Backup().SLAPolicy().AssignVirtualMachine(ctx, &client.BackupAssignVirtualMachineRequest{
+		VirtualMachineIds: []string{d.Id()},
+		SLAPolicies:       slaPolicies,
+	})
+	if err != nil {
+		return diag.Errorf("failed to assign policies to virtual machine, %s", err)
+	}
+
+	_, err = c.Activity().WaitForCompletion(ctx, activityId, getWaiterOptions(ctx))
+	if err != nil {
+		return diag.Errorf("failed to assign policies to virtual machine, %s", err)
+	}
+	return nil
+}
+
+// vmwareVirtualMachineFinishCreate runs, on a virtual machine adopted from an
+// interrupted create, the create steps that follow the activity: the guest OS
+// customization, the backup SLA policies, the tags and the power state.
+func vmwareVirtualMachineFinishCreate(c *client.Client, d *schema.ResourceData) pendingCreateFinisher {
+	return func(ctx context.Context) diag.Diagnostics {
+		if diags := customizeVirtualMachineGuestOS(ctx, c, d); diags != nil {
+			return diags
 		}
-		activityId, err = c.Backup().SLAPolicy().AssignVirtualMachine(ctx, &client.BackupAssignVirtualMachineRequest{
-			VirtualMachineIds: []string{d.Id()},
-			SLAPolicies:       slaPolicies,
-		})
-		if err != nil {
-			return diag.Errorf("failed to assign policies to virtual machine, %s", err)
+		if diags := assignVirtualMachineBackupSLAPolicies(ctx, c, d); diags != nil {
+			return diags
+		}
+		if diags := writeTags(ctx, c, d, d.Id(), "vcenter_virtual_machine", "vmware"); diags != nil {
+			return diags
 		}
+		if d.Get("power_state").(string) == "on" {
+			return powerVirtualMachine(ctx, c, d.Id(), "on")
+		}
+		return nil
+	}
+}
 
-		_, err = c.Activity().WaitForCompletion(ctx, activityId, getWaiterOptions(ctx))
+// vmwareVirtualMachineCreateResolver resumes a create interrupted while waiting on
+// its activity (see resumePendingCreate). The new VM id is read where the create
+// reads it: the single state result for a clone or a deployment, the
+// "virtual_machine" concerned item for a VM created from scratch.
+func vmwareVirtualMachineCreateResolver(c *client.Client, d *schema.ResourceData) pendingCreateResolver {
+	deployed := d.Get("clone_virtual_machine_id").(string) != "" ||
+		d.Get("content_library_item_id").(string) != "" ||
+		d.Get("marketplace_item_id").(string) != ""
+	return func(ctx context.Context, activityID string) (string, error) {
+		if deployed {
+			return c.Activity().WaitForCreatedID(ctx, activityID, "virtual machine create", getWaiterOptions(ctx))
+		}
+		activity, err := c.Activity().WaitForCompletion(ctx, activityID, getWaiterOptions(ctx))
 		if err != nil {
-			return diag.Errorf("failed to assign policies to virtual machine, %s", err)
+			return "", err
 		}
+		return activityConcernedItemID(activity, "virtual_machine"), nil
 	}
-
-	return updateVirtualMachine(ctx, d, meta, d.Get("power_state").(string) == "on", true)
 }
 
 func computeVirtualMachineRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	c := getClient(meta)
 	var diags diag.Diagnostics
 
+	if diags, stop := resumeAndFinishPendingCreate(ctx, d, "virtual machine", resumePendingCreate, vmwareVirtualMachineCreateResolver(c, d), vmwareVirtualMachineFinishCreate(c, d)); stop {
+		return diags
+	}
+
 	// Récupérer la machine virtuelle par son ID
 	id := d.Id()
 	vm, err := c.Compute().VirtualMachine().Read(ctx, id)
@@ -1366,11 +1505,7 @@ func computeVirtualMachineRead(ctx context.Context, d *schema.ResourceData, meta
 		return diag.Errorf("failed to get tags: %s", err)
 	}
 
-	tagsMap := make(map[string]interface{})
-	for _, tag := range tags {
-		tagsMap[tag.Key] = tag.Value
-	}
-	vmData["tags"] = tagsMap
+	vmData["tags"], vmData["tags_all"] = flattenTags(meta, d, tags)
 
 	// Définir les données dans le state
 	for k, v := range vmData {
@@ -1711,6 +1846,10 @@ func osDiskCapacityShrink(liveCap, requestedCap int) bool {
 }
 
 func computeVirtualMachineUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := getClient(meta)
+	if diags, stop := resumeAndFinishPendingCreate(ctx, d, "virtual machine", resumePendingCreateForWrite, vmwareVirtualMachineCreateResolver(c, d), vmwareVirtualMachineFinishCreate(c, d)); stop {
+		return diags
+	}
 	return updateVirtualMachine(ctx, d, meta, d.HasChange("power_state"), false)
 }
 
@@ -2016,34 +2155,41 @@ func updateVirtualMachine(ctx context.Context, d *schema.ResourceData, meta any,
 	}
 
 	if updatePower && !powerSettled {
-		powerState := d.Get("power_state").(string)
-
-		vm, vmDiags := readVirtualMachineForOp(ctx, c, d.Id(), "power")
-		if vmDiags.HasError() {
-			return vmDiags
+		if diags := powerVirtualMachine(ctx, c, d.Id(), d.Get("power_state").(string)); diags != nil {
+			return diags
 		}
+	}
 
-		recommendation, err := helpers.GetPowerRecommendation(vm, powerState, ctx, c)
-		if err != nil {
-			return diag.Errorf("failed to get power recommendation for virtual machine: %s", err)
-		}
+	return computeVirtualMachineRead(ctx, d, meta)
+}
 
-		activityId, err = c.Compute().VirtualMachine().Power(ctx, &client.PowerRequest{
-			ID:             d.Id(),
-			DatacenterId:   vm.Datacenter.ID,
-			PowerAction:    powerState,
-			Recommendation: recommendation,
-		})
-		if err != nil {
-			return diag.Errorf("failed to power %s virtual machine: %s", powerState, err)
-		}
-		_, err = c.Activity().WaitForCompletion(ctx, activityId, getWaiterOptions(ctx))
-		if err != nil {
-			return diag.Errorf("failed to power %s virtual machine, %s", powerState, err)
-		}
+// powerVirtualMachine powers the virtual machine on or off, following the power
+// recommendation of its datacenter.
+func powerVirtualMachine(ctx context.Context, c *client.Client, id, powerState string) diag.Diagnostics {
+	vm, vmDiags := readVirtualMachineForOp(ctx, c, id, "power")
+	if vmDiags.HasError() {
+		return vmDiags
 	}
 
-	return computeVirtualMachineRead(ctx, d, meta)
+	recommendation, err := helpers.GetPowerRecommendation(vm, powerState, ctx, c)
+	if err != nil {
+		return diag.Errorf("failed to get power recommendation for virtual machine: %s", err)
+	}
+
+	activityId, err := c.Compute().VirtualMachine().Power(ctx, &client.PowerRequest{
+		ID:             id,
+		DatacenterId:   vm.Datacenter.ID,
+		PowerAction:    powerState,
+		Recommendation: recommendation,
+	})
+	if err != nil {
+		return diag.Errorf("failed to power %s virtual machine: %s", powerState, err)
+	}
+	_, err = c.Activity().WaitForCompletion(ctx, activityId, getWaiterOptions(ctx))
+	if err != nil {
+		return diag.Errorf("failed to power %s virtual machine, %s", powerState, err)
+	}
+	return nil
 }
 
 // readVirtualMachineForOp reads a VM by id for a CRUD operation and converts an
@@ -2065,6 +2211,9 @@ func readVirtualMachineForOp(ctx context.Context, c *client.Client, id, action s
 
 func computeVirtualMachineDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	c := getClient(meta)
+	if diags, stop := resumePendingCreateForWrite(ctx, d, "virtual machine", vmwareVirtualMachineCreateResolver(c, d)); stop {
+		return diags
+	}
 
 	vm, vmDiags := readVirtualMachineForOp(ctx, c, d.Id(), "delete")
 	if vmDiags.HasError() {
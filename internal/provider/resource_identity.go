@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// withIdentity declares the resource identity of r: the single attribute that
+// Terraform 1.12+ imports by (`identity = { … }` in an import block) and that
+// the list resources return. The identity is written after every create, read
+// and update, whichever code path set the ID and whichever of the context or
+// the without-timeout functions the resource implements, since SDKv2 fails an
+// apply that leaves it empty.
+func withIdentity(r *schema.Resource, attribute string) *schema.Resource {
+	r.Identity = &schema.ResourceIdentity{
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				attribute: {
+					Type:              schema.TypeString,
+					RequiredForImport: true,
+					Description:       fmt.Sprintf("The %s of the resource.", identityAttributeName(attribute)),
+				},
+			}
+		},
+	}
+
+	r.CreateContext = setIdentityAfterCreate(r.CreateContext, attribute)
+	r.ReadContext = setIdentityAfter(r.ReadContext, attribute)
+	r.UpdateContext = setIdentityAfter(r.UpdateContext, attribute)
+	r.CreateWithoutTimeout = setIdentityAfterCreate(r.CreateWithoutTimeout, attribute)
+	r.ReadWithoutTimeout = setIdentityAfter(r.ReadWithoutTimeout, attribute)
+	r.UpdateWithoutTimeout = setIdentityAfter(r.UpdateWithoutTimeout, attribute)
+	return r
+}
+
+func identityAttributeName(attribute string) string {
+	if attribute == "id" {
+		return "ID"
+	}
+	return attribute
+}
+
+func setIdentityAfter[F ~func(context.Context, *schema.ResourceData, any) diag.Diagnostics](f F, attribute string) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+		diags := f(ctx, d, meta)
+		return append(diags, setIdentity(d, attribute)...)
+	}
+}
+
+// setIdentityAfterCreate is setIdentityAfter for a create. A create left pending
+// (see pendingCreatePrefix) has no identity yet, and SDKv2 fails the create of
+// a resource without one, tainting it: the error says to untaint it, so that the
+// next run resumes the creation instead of replacing the object.
+func setIdentityAfterCreate[F ~func(context.Context, *schema.ResourceData, any) diag.Diagnostics](f F, attribute string) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+		diags := f(ctx, d, meta)
+		if activityID, pending := pendingCreateActivityID(d.Id()); pending && !diags.HasError() {
+			return append(diags, diag.Errorf("the creation is still in progress (activity %q): the resource has no identity until it completes, so Terraform fails the create and taints it. Untaint it (terraform untaint) so that the next plan or apply resumes waiting on the activity and adopts the created object, instead of replacing it.", activityID)...)
+		}
+		return append(diags, setIdentity(d, attribute)...)
+	}
+}
+
+// setIdentity copies the identity attribute from the state. A resource that is
+// gone, or was never created, has no identity, and neither has a create still
+// pending (see pendingCreatePrefix): its placeholder ID is not the object's.
+func setIdentity(d *schema.ResourceData, attribute string) diag.Diagnostics {
+	if d.Id() == "" {
+		return nil
+	}
+	if _, pending := pendingCreateActivityID(d.Id()); pending {
+		return nil
+	}
+
+	value := d.Id()
+	if attribute != "id" {
+		value = d.Get(attribute).(string)
+	}
+	if value == "" {
+		return nil
+	}
+
+	identity, err := d.Identity()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := identity.Set(attribute, value); err != nil {
+		return diag.Errorf("failed to set the resource identity: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// identityResources are the resources the provider wraps withIdentity, with
+// their identity attribute.
+var identityResources = map[string]struct {
+	resource  func() *schema.Resource
+	attribute string
+}{
+	"cloudtemple_compute_virtual_machine":                 {resourceVirtualMachine, "id"},
+	"cloudtemple_compute_iaas_opensource_virtual_machine": {resourceOpenIaasVirtualMachine, "id"},
+	"cloudtemple_object_storage_bucket":                   {resourceBucket, "name"},
+	"cloudtemple_object_storage_storage_account":          {resourceStorageAccount, "name"},
+	"cloudtemple_public_cloud_vm_instance":                {resourcePublicCloudVMInstance, "id"},
+}
+
+type identityTestFunc = func(context.Context, *schema.ResourceData, any) diag.Diagnostics
+
+// newIdentityTestServer serves the resource typeName with its create, read and
+// update replaced by the stubs, in whichever of the context or without-timeout
+// functions the resource implements, then wrapped withIdentity.
+func newIdentityTestServer(t *testing.T, typeName string, create, read identityTestFunc) (tfprotov5.ProviderServer, *schema.Resource) {
+	t.Helper()
+	r := identityResources[typeName].resource()
+	if r.CreateWithoutTimeout != nil {
+		r.CreateWithoutTimeout = create
+	} else {
+		r.CreateContext = create
+	}
+	if r.ReadWithoutTimeout != nil {
+		r.ReadWithoutTimeout = read
+	} else {
+		r.ReadContext = read
+	}
+	if r.UpdateWithoutTimeout != nil {
+		r.UpdateWithoutTimeout = read
+	} else {
+		r.UpdateContext = read
+	}
+	// The plan-time checks read the API through the provider's client.
+	r.CustomizeDiff = nil
+	r = withIdentity(r, identityResources[typeName].attribute)
+
+	return schema.NewGRPCProviderServer(&schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{typeName: r},
+	}), r
+}
+
+// identityTestConfig is a configuration of r setting values only.
+func identityTestConfig(r *schema.Resource, values map[string]cty.Value) cty.Value {
+	block := r.CoreConfigSchema()
+	attributes := map[string]cty.Value{}
+	for name, ty := range block.ImpliedType().AttributeTypes() {
+		_, nested := block.BlockTypes[name]
+		switch {
+		case values[name] != cty.NilVal:
+			attributes[name] = values[name]
+		case nested && ty.IsListType():
+			attributes[name] = cty.ListValEmpty(ty.ElementType())
+		case nested && ty.IsSetType():
+			attributes[name] = cty.SetValEmpty(ty.ElementType())
+		default:
+			attributes[name] = cty.NullVal(ty)
+		}
+	}
+	return cty.ObjectVal(attributes)
+}
+
+func identityTestValue(t *testing.T, r *schema.Resource, v cty.Value) *tfprotov5.DynamicValue {
+	t.Helper()
+	b, err := msgpack.Marshal(v, r.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &tfprotov5.DynamicValue{MsgPack: b}
+}
+
+// identityTestCreate plans and applies the creation of the resource typeName.
+func identityTestCreate(t *testing.T, server tfprotov5.ProviderServer, r *schema.Resource, typeName string) *tfprotov5.ApplyResourceChangeResponse {
+	t.Helper()
+	ctx := context.Background()
+	values := map[string]cty.Value{}
+	if attribute := identityResources[typeName].attribute; attribute != "id" {
+		values[attribute] = cty.StringVal("obj-name")
+	}
+	config := identityTestValue(t, r, identityTestConfig(r, values))
+	prior := identityTestValue(t, r, cty.NullVal(r.CoreConfigSchema().ImpliedType()))
+
+	planned, err := server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       prior,
+		ProposedNewState: config,
+		Config:           config,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireNoErrorDiagnostics(t, planned.Diagnostics)
+
+	applied, err := server.ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{
+		TypeName:        typeName,
+		PriorState:      prior,
+		PlannedState:    planned.PlannedState,
+		Config:          config,
+		PlannedPrivate:  planned.PlannedPrivate,
+		PlannedIdentity: planned.PlannedIdentity,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return applied
+}
+
+// identityTestAttribute returns the identity attribute of typeName in identity,
+// "" when there is none.
+func identityTestAttribute(t *testing.T, server tfprotov5.ProviderServer, typeName string, identity *tfprotov5.ResourceIdentityData) string {
+	t.Helper()
+	if identity == nil || identity.IdentityData == nil {
+		return ""
+	}
+	schemas, err := server.GetResourceIdentitySchemas(context.Background(), &tfprotov5.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := identity.IdentityData.Unmarshal(schemas.IdentitySchemas[typeName].ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var attributes map[string]tftypes.Value
+	if err := value.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	var s string
+	if err := attributes[identityResources[typeName].attribute].As(&s); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// TestIdentityResourcesAreListed keeps identityResources in step with the
+// provider, so that every resource with an identity goes through
+// TestWithIdentityCreate.
+func TestIdentityResourcesAreListed(t *testing.T) {
+	for typeName, r := range New("test")().ResourcesMap {
+		if _, listed := identityResources[typeName]; listed != (r.Identity != nil) {
+			t.Errorf("%s: declares an identity: %v, listed in identityResources: %v", typeName, r.Identity != nil, listed)
+		}
+	}
+}
+
+// TestWithIdentityCreate drives a create through the SDKv2 gRPC server, which
+// fails the apply of a resource left without an identity.
+func TestWithIdentityCreate(t *testing.T) {
+	for typeName := range identityResources {
+		t.Run(typeName, func(t *testing.T) {
+			created := func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+				d.SetId("obj-id")
+				return nil
+			}
+			server, r := newIdentityTestServer(t, typeName, created, created)
+
+			applied := identityTestCreate(t, server, r, typeName)
+			requireNoErrorDiagnostics(t, applied.Diagnostics)
+			want := "obj-id"
+			if identityResources[typeName].attribute != "id" {
+				want = "obj-name"
+			}
+			if got := identityTestAttribute(t, server, typeName, applied.NewIdentity); got != want {
+				t.Errorf("identity = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+// TestWithIdentityPendingCreate follows a create left pending to its takeover:
+// the placeholder ID never reaches the identity, which is only set once the
+// refresh adopts the created object, and is stable from then on.
+func TestWithIdentityPendingCreate(t *testing.T) {
+	const typeName = "cloudtemple_compute_virtual_machine"
+	ctx := context.Background()
+	server, r := newIdentityTestServer(t, typeName, func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+		return recordPendingCreate(ctx, d, "virtual machine", "act-1", context.Canceled)
+	}, func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+		diags, _ := resumePendingCreate(ctx, d, "virtual machine", func(ctx context.Context, activityID string) (string, error) {
+			return "vm-1", nil
+		})
+		return diags
+	})
+
+	applied := identityTestCreate(t, server, r, typeName)
+	hasError := false
+	for _, d := range applied.Diagnostics {
+		hasError = hasError || d.Severity == tfprotov5.DiagnosticSeverityError
+	}
+	if !hasError {
+		t.Fatal("a create left pending has no identity: SDKv2 fails it, and so must the provider, with how to resume it")
+	}
+	if got := identityTestAttribute(t, server, typeName, applied.NewIdentity); got != "" {
+		t.Fatalf("identity = %q, want none while the create is pending", got)
+	}
+
+	state, identity := applied.NewState, applied.NewIdentity
+	for i, want := range []string{"vm-1", "vm-1"} {
+		read, err := server.ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+			TypeName:        typeName,
+			CurrentState:    state,
+			CurrentIdentity: identity,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		requireNoErrorDiagnostics(t, read.Diagnostics)
+		if got := identityTestAttribute(t, server, typeName, read.NewIdentity); got != want {
+			t.Fatalf("read %d: identity = %q, want %q", i, got, want)
+		}
+		state, identity = read.NewState, read.NewIdentity
+	}
+}
@@ -0,0 +1,596 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	marketplaceDeploymentCreateTimeout = 60 * time.Minute
+	marketplaceDeploymentDeleteTimeout = 30 * time.Minute
+
+	marketplaceTargetOpenIaaS = "openiaas"
+	marketplaceTargetVMware   = "vmware"
+)
+
+// marketplaceInfoTargets maps the resource target to the target segment of the
+// marketplace item info endpoint.
+var marketplaceInfoTargets = map[string]string{
+	marketplaceTargetOpenIaaS: "open_iaas",
+	marketplaceTargetVMware:   "vmware",
+}
+
+func resourceMarketplaceDeployment() *schema.Resource {
+	return &schema.Resource{
+		Description: "Deploys a marketplace item on Open IaaS or VMware and tracks every virtual machine the deployment created, including multi-VM items. The deployment is immutable: any change redeploys the item, and destroying it deletes all the virtual machines it created.",
+
+		CreateContext: marketplaceDeploymentCreate,
+		ReadContext:   marketplaceDeploymentRead,
+		DeleteContext: marketplaceDeploymentDelete,
+		CustomizeDiff: marketplaceDeploymentCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(marketplaceDeploymentCreateTimeout),
+			Delete: schema.DefaultTimeout(marketplaceDeploymentDeleteTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			// In
+			"marketplace_item_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+				Description:  "The ID of the marketplace item to deploy.",
+			},
+			"target": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{marketplaceTargetOpenIaaS, marketplaceTargetVMware}, false),
+				Description:  "The infrastructure to deploy the item on: `openiaas` or `vmware`. The matching `openiaas` or `vmware` block must be set.",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The name given to the deployed virtual machine(s).",
+			},
+			"network_ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsUUID,
+				},
+				Description: "The IDs of the destination networks, in the order of the item's network adapters for the target. When set, it must have exactly one entry per network adapter of the item; when omitted the item's default network mapping is kept.",
+			},
+			"openiaas": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"vmware"},
+				Description:   "The Open IaaS placement. Required when `target` is `openiaas`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"storage_repository_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IsUUID,
+							Description:  "The storage repository to deploy the virtual machine(s) on.",
+						},
+						"cloud_init": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							ForceNew: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							ValidateDiagFunc: validation.MapKeyMatch(regexp.MustCompile(strings.Join([]string{
+								"^cloud_config$",
+								"^network_config$"},
+								"|")), `The following key is not allowed for cloud-init`),
+							Description: "A set of cloud-init compatible key/value (`cloud_config`, `network_config`) applied at first boot. `cloud_config` is required when `network_config` is set.",
+						},
+					},
+				},
+			},
+			"vmware": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"openiaas"},
+				Description:   "The VMware placement. Required when `target` is `vmware`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datacenter_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IsUUID,
+							Description:  "The datacenter to deploy the virtual machine(s) in.",
+						},
+						"host_cluster_id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IsUUID,
+							Description:  "The host cluster to deploy the virtual machine(s) on.",
+						},
+						"host_id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IsUUID,
+							Description:  "The host to deploy the virtual machine(s) on.",
+						},
+						"datastore_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IsUUID,
+							Description:  "The datastore to store the virtual machine(s) data on.",
+						},
+						"deploy_options": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							ForceNew: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The deploy options of the item (e.g. its `extra_config` keys), as a key/value map.",
+						},
+					},
+				},
+			},
+
+			// Out
+			"virtual_machine_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of every virtual machine created by the deployment.",
+			},
+		},
+	}
+}
+
+// marketplaceDeploymentCustomizeDiff requires the placement block matching the
+// target, so a mismatch is reported at plan time rather than after the deploy.
+func marketplaceDeploymentCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	target := diff.Get("target").(string)
+	if target == "" {
+		return nil
+	}
+	if len(diff.Get(target).([]interface{})) == 0 {
+		return fmt.Errorf("the %q block is required when target is %q", target, target)
+	}
+	return nil
+}
+
+// marketplaceDeploymentCRUDFuncs abstracts the client surface of the create
+// path for unit testing without HTTP.
+type marketplaceDeploymentCRUDFuncs struct {
+	readInfo       func(ctx context.Context, id string, target string) (*client.MarketplaceOpenIaasItemInfo, *client.MarketplaceVMWareItemInfo, error)
+	deployOpenIaas func(ctx context.Context, req *client.MarketplaceOpenIaasDeployementRequest) (string, error)
+	deployVMware   func(ctx context.Context, req *client.MarketplaceVMWareDeployementRequest) (string, error)
+	waitActivity   func(ctx context.Context, activityID string) (*client.Activity, error)
+}
+
+func marketplaceDeploymentClientFuncs(c *client.Client) marketplaceDeploymentCRUDFuncs {
+	item := c.Marketplace().Item()
+	return marketplaceDeploymentCRUDFuncs{
+		readInfo:       item.ReadInfo,
+		deployOpenIaas: item.DeployOpenIaasItem,
+		deployVMware:   item.DeployVMWareItem,
+		waitActivity: func(ctx context.Context, activityID string) (*client.Activity, error) {
+			return c.Activity().WaitForCompletion(ctx, activityID, getWaiterOptions(ctx))
+		},
+	}
+}
+
+func marketplaceDeploymentCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	if diags := createMarketplaceDeploymentWith(ctx, d, marketplaceDeploymentClientFuncs(getClient(meta))); diags != nil {
+		return diags
+	}
+	return marketplaceDeploymentRead(ctx, d, meta)
+}
+
+// marketplaceNetworkData maps the configured network ids onto the item's
+// network adapters, in order. No network ids keeps the item's default mapping.
+func marketplaceNetworkData(networkIDs []string, adapterCount int, mapping func(i int, networkID string) client.NetworkDataMapping) ([]client.NetworkDataMapping, error) {
+	if len(networkIDs) == 0 {
+		return nil, nil
+	}
+	if len(networkIDs) != adapterCount {
+		return nil, fmt.Errorf("the number of network_ids (%d) must match the number of network adapters in the marketplace item (%d)", len(networkIDs), adapterCount)
+	}
+	networkData := make([]client.NetworkDataMapping, 0, len(networkIDs))
+	for i, networkID := range networkIDs {
+		networkData = append(networkData, mapping(i, networkID))
+	}
+	return networkData, nil
+}
+
+// marketplaceDeployedVMIDs returns the ids of the virtual machines a completed
+// deployment created: every "virtual_machine" concerned item, else the single
+// terminal state result. Any candidate that is not a UUID is an error, so an
+// unexpected activity shape never produces a wrong state.
+func marketplaceDeployedVMIDs(activity *client.Activity) ([]string, error) {
+	var ids []string
+	if activity != nil {
+		for _, ci := range activity.ConcernedItems {
+			if ci.Type != "virtual_machine" || ci.ID == "" {
+				continue
+			}
+			duplicate := false
+			for _, id := range ids {
+				if sameUUID(id, ci.ID) {
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				ids = append(ids, ci.ID)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		if result := singleActivityResult(activity); result != "" {
+			ids = []string{result}
+		}
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("the activity did not report any virtual machine")
+	}
+	for _, id := range ids {
+		if !isUUID(id) {
+			return nil, fmt.Errorf("the activity reported an invalid virtual machine id %q", id)
+		}
+	}
+	return ids, nil
+}
+
+// createMarketplaceDeploymentWith reads the item info for the target, deploys
+// it with the configured placement and, once the activity completes, adopts the
+// activity id as the resource id and records the created virtual machines.
+func createMarketplaceDeploymentWith(ctx context.Context, d *schema.ResourceData, funcs marketplaceDeploymentCRUDFuncs) diag.Diagnostics {
+	itemID := d.Get("marketplace_item_id").(string)
+	target := d.Get("target").(string)
+	name := d.Get("name").(string)
+
+	placements := d.Get(target).([]interface{})
+	if len(placements) == 0 || placements[0] == nil {
+		return diag.Errorf("the %q block is required when target is %q", target, target)
+	}
+	placement := placements[0].(map[string]interface{})
+
+	networkIDs := []string{}
+	for _, id := range d.Get("network_ids").([]interface{}) {
+		networkIDs = append(networkIDs, id.(string))
+	}
+
+	openIaasInfo, vmwareInfo, err := funcs.readInfo(ctx, itemID, marketplaceInfoTargets[target])
+	if err != nil {
+		return diag.Errorf("failed to read the %s information of marketplace item %s: %s", target, itemID, err)
+	}
+
+	var activityID string
+	switch target {
+	case marketplaceTargetOpenIaaS:
+		if openIaasInfo == nil {
+			return diag.Errorf("marketplace item %s has no %s deployment information (it does not exist or cannot be deployed on %s)", itemID, target, target)
+		}
+		networkData, err := marketplaceNetworkData(networkIDs, len(openIaasInfo.NetworkAdapters), func(i int, networkID string) client.NetworkDataMapping {
+			// Both adapter identifiers are sent, as for the Open IaaS virtual
+			// machine resource; the adapter name takes priority server-side.
+			return client.NetworkDataMapping{
+				NetworkAdapterName:   openIaasInfo.NetworkAdapters[i].Name,
+				SourceNetworkName:    openIaasInfo.NetworkAdapters[i].NetworkName,
+				DestinationNetworkId: networkID,
+			}
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		cloudInitRaw, _ := placement["cloud_init"].(map[string]interface{})
+		cloudInit, err := buildOpenIaasCloudInit(cloudInitRaw)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		activityID, err = funcs.deployOpenIaas(ctx, &client.MarketplaceOpenIaasDeployementRequest{
+			ID:                  itemID,
+			Name:                name,
+			StorageRepositoryID: placement["storage_repository_id"].(string),
+			NetworkData:         networkData,
+			CloudInit:           cloudInit,
+		})
+		if err != nil {
+			return diag.Errorf("failed to deploy marketplace item %s on %s: %s", itemID, target, err)
+		}
+
+	case marketplaceTargetVMware:
+		if vmwareInfo == nil {
+			return diag.Errorf("marketplace item %s has no %s deployment information (it does not exist or cannot be deployed on %s)", itemID, target, target)
+		}
+		networkData, err := marketplaceNetworkData(networkIDs, len(vmwareInfo.NetworkAdapters), func(i int, networkID string) client.NetworkDataMapping {
+			return client.NetworkDataMapping{
+				SourceNetworkName:    vmwareInfo.NetworkAdapters[i].NetworkName,
+				DestinationNetworkId: networkID,
+			}
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		var deployOptions []*client.DeployOption
+		for k, v := range placement["deploy_options"].(map[string]interface{}) {
+			deployOptions = append(deployOptions, &client.DeployOption{
+				ID:    k,
+				Value: v.(string),
+			})
+		}
+		activityID, err = funcs.deployVMware(ctx, &client.MarketplaceVMWareDeployementRequest{
+			ID:            itemID,
+			Name:          name,
+			DatacenterID:  placement["datacenter_id"].(string),
+			HostClusterID: placement["host_cluster_id"].(string),
+			HostID:        placement["host_id"].(string),
+			DatastoreID:   placement["datastore_id"].(string),
+			NetworkData:   networkData,
+			DeployOptions: deployOptions,
+		})
+		if err != nil {
+			return diag.Errorf("failed to deploy marketplace item %s on %s: %s", itemID, target, err)
+		}
+
+	default:
+		return diag.Errorf("unsupported marketplace deployment target %q", target)
+	}
+
+	activity, err := funcs.waitActivity(ctx, activityID)
+	if err != nil {
+		return diag.Errorf(
+			"deployment of marketplace item %s on %s: activity %q did not complete: %s. Any virtual machine it created is ORPHANED outside the state — audit the activity's concerned items and delete or import them before re-applying.",
+			itemID, target, activityID, err,
+		)
+	}
+	vmIDs, err := marketplaceDeployedVMIDs(activity)
+	if err != nil {
+		return diag.Errorf(
+			"deployment of marketplace item %s on %s (activity %q) completed but its virtual machines could not be determined: %s; refusing to guess. Audit the activity's concerned items and delete or import them.",
+			itemID, target, activityID, err,
+		)
+	}
+
+	d.SetId(activityID)
+	sw := newStateWriter(d)
+	sw.set("virtual_machine_ids", vmIDs)
+	return sw.diags
+}
+
+func marketplaceDeploymentVMIDs(d *schema.ResourceData) []string {
+	ids := []string{}
+	for _, id := range d.Get("virtual_machine_ids").([]interface{}) {
+		ids = append(ids, id.(string))
+	}
+	return ids
+}
+
+// marketplaceDeploymentVMwareDatacenter returns the datacenter id of the
+// VMware placement, used to scope the strict liveness listings.
+func marketplaceDeploymentVMwareDatacenter(d *schema.ResourceData) string {
+	placements := d.Get(marketplaceTargetVMware).([]interface{})
+	if len(placements) == 0 || placements[0] == nil {
+		return ""
+	}
+	return placements[0].(map[string]interface{})["datacenter_id"].(string)
+}
+
+// marketplaceVMwareVMsInDatacenter lists the ids of the VMware virtual machines
+// of a datacenter through the strict listing (a complete 200 answer only).
+func marketplaceVMwareVMsInDatacenter(c *client.Client, datacenterID string) func(ctx context.Context) ([]string, error) {
+	return func(ctx context.Context) ([]string, error) {
+		vms, err := c.Compute().VirtualMachine().ListStrict(ctx, &client.VirtualMachineFilter{Datacenters: []string{datacenterID}})
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]string, 0, len(vms))
+		for _, vm := range vms {
+			if vm != nil {
+				ids = append(ids, vm.ID)
+			}
+		}
+		return ids, nil
+	}
+}
+
+// openIaasVMConfirmedGone reports whether an Open IaaS virtual machine whose
+// per-id read returned nil is absent from the strict tenant listing.
+func openIaasVMConfirmedGone(ctx context.Context, c *client.Client, id string) (bool, error) {
+	vms, err := c.Compute().OpenIaaS().VirtualMachine().ListStrict(ctx, &client.OpenIaaSVirtualMachineFilter{})
+	if err != nil {
+		return false, err
+	}
+	for _, vm := range vms {
+		if vm != nil && sameUUID(vm.ID, id) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// marketplaceDeploymentRead checks that the deployed virtual machines still
+// exist. On Open IaaS a virtual machine is considered gone only once the strict
+// tenant listing confirms it; the deployment is dropped when all of them are
+// gone and only warned about when some are. On VMware a missing virtual machine
+// is never dropped (confirmVMwareDeviceOrKeep).
+func marketplaceDeploymentRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := getClient(meta)
+	vmIDs := marketplaceDeploymentVMIDs(d)
+
+	switch d.Get("target").(string) {
+	case marketplaceTargetOpenIaaS:
+		var gone []string
+		for _, id := range vmIDs {
+			vm, err := c.Compute().OpenIaaS().VirtualMachine().Read(ctx, id)
+			if err != nil {
+				return diag.Errorf("failed to read virtual machine %s of the marketplace deployment: %s", id, err)
+			}
+			if vm != nil {
+				continue
+			}
+			confirmed, err := openIaasVMConfirmedGone(ctx, c, id)
+			if err != nil {
+				return diag.Errorf("virtual machine %s of the marketplace deployment could not be read and its deletion could not be confirmed: %s", id, err)
+			}
+			if !confirmed {
+				return diag.Errorf("virtual machine %s of the marketplace deployment could not be read but is still listed: refusing to drop it from the state (possible access restriction)", id)
+			}
+			gone = append(gone, id)
+		}
+		if len(vmIDs) > 0 && len(gone) == len(vmIDs) {
+			// Every virtual machine is confirmed deleted: the deployment is gone.
+			d.SetId("")
+			return nil
+		}
+		if len(gone) > 0 {
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "Marketplace deployment partially deleted",
+				Detail:   fmt.Sprintf("The virtual machines %s created by this deployment were deleted outside Terraform. Replace the deployment (terraform apply -replace) to redeploy the item.", strings.Join(gone, ", ")),
+			}}
+		}
+
+	case marketplaceTargetVMware:
+		datacenterID := marketplaceDeploymentVMwareDatacenter(d)
+		for _, id := range vmIDs {
+			vm, err := c.Compute().VirtualMachine().Read(ctx, id)
+			if err != nil {
+				return diag.Errorf("failed to read virtual machine %s of the marketplace deployment: %s", id, err)
+			}
+			if vm == nil {
+				return confirmVMwareDeviceOrKeep(ctx, id, "virtual machine", "datacenter", datacenterID, marketplaceVMwareVMsInDatacenter(c, datacenterID))
+			}
+		}
+	}
+	return nil
+}
+
+// marketplaceDeploymentDelete deletes every deployed virtual machine, one at a
+// time under the per-VM lock. virtual_machine_ids is rewritten after each
+// deletion so an interrupted destroy resumes with the remaining ones only. A
+// virtual machine that cannot be read is skipped only once a strict listing
+// confirms it is gone.
+func marketplaceDeploymentDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := getClient(meta)
+	target := d.Get("target").(string)
+	remaining := marketplaceDeploymentVMIDs(d)
+
+	for len(remaining) > 0 {
+		id := remaining[0]
+		var diags diag.Diagnostics
+		switch target {
+		case marketplaceTargetOpenIaaS:
+			diags = deleteMarketplaceOpenIaasVM(ctx, c, id)
+		case marketplaceTargetVMware:
+			diags = deleteMarketplaceVMwareVM(ctx, c, id, marketplaceDeploymentVMwareDatacenter(d))
+		default:
+			diags = diag.Errorf("unsupported marketplace deployment target %q", target)
+		}
+		if diags.HasError() {
+			return diags
+		}
+		remaining = remaining[1:]
+		if err := d.Set("virtual_machine_ids", remaining); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+func deleteMarketplaceOpenIaasVM(ctx context.Context, c *client.Client, id string) diag.Diagnostics {
+	unlock := openIaasVirtualMachineMutex.lock(id)
+	defer unlock()
+
+	vm, err := c.Compute().OpenIaaS().VirtualMachine().Read(ctx, id)
+	if err != nil {
+		return diag.Errorf("cannot delete virtual machine %s: %s", id, err)
+	}
+	if vm == nil {
+		confirmed, err := openIaasVMConfirmedGone(ctx, c, id)
+		if err != nil {
+			return diag.Errorf("virtual machine %s could not be read and its deletion could not be confirmed: %s", id, err)
+		}
+		if !confirmed {
+			return diag.Errorf("virtual machine %s could not be read but is still listed: refusing to assume it was deleted (possible access restriction)", id)
+		}
+		return nil
+	}
+
+	activityID, err := c.Compute().OpenIaaS().VirtualMachine().Delete(ctx, id)
+	if err != nil {
+		return diag.Errorf("failed to delete virtual machine %s: %s", id, err)
+	}
+	if _, err := c.Activity().WaitForCompletion(ctx, activityID, getWaiterOptions(ctx)); err != nil {
+		return diag.Errorf("failed to delete virtual machine %s: activity %q did not complete: %s", id, activityID, err)
+	}
+	return nil
+}
+
+func deleteMarketplaceVMwareVM(ctx context.Context, c *client.Client, id, datacenterID string) diag.Diagnostics {
+	unlock := vmwareVirtualMachineMutex.lock(id)
+	defer unlock()
+
+	vm, err := c.Compute().VirtualMachine().Read(ctx, id)
+	if err != nil {
+		return diag.Errorf("cannot delete virtual machine %s: %s", id, err)
+	}
+	if vm == nil {
+		if datacenterID == "" {
+			return diag.Errorf("virtual machine %s could not be read and its deletion could not be confirmed because the datacenter id is missing from the state", id)
+		}
+		present, err := confirmVMwareDeviceLiveness(ctx, id, marketplaceVMwareVMsInDatacenter(c, datacenterID))
+		if err != nil {
+			return diag.Errorf("virtual machine %s could not be read and its deletion could not be confirmed: %s", id, err)
+		}
+		if present {
+			return diag.Errorf("virtual machine %s could not be read but is still listed in datacenter %s: refusing to assume it was deleted (possible access restriction)", id, datacenterID)
+		}
+		return nil
+	}
+
+	if vm.PowerState == "running" {
+		activityID, err := c.Compute().VirtualMachine().Power(ctx, &client.PowerRequest{
+			ID:           id,
+			DatacenterId: vm.Datacenter.ID,
+			PowerAction:  "off",
+		})
+		if err != nil {
+			return diag.Errorf("failed to power off virtual machine %s: %s", id, err)
+		}
+		if _, err := c.Activity().WaitForCompletion(ctx, activityID, getWaiterOptions(ctx)); err != nil {
+			return diag.Errorf("failed to power off virtual machine %s: %s", id, err)
+		}
+	}
+
+	activityID, err := c.Compute().VirtualMachine().Delete(ctx, id)
+	if err != nil {
+		return diag.Errorf("failed to delete virtual machine %s: %s", id, err)
+	}
+	if _, err := c.Activity().WaitForCompletion(ctx, activityID, getWaiterOptions(ctx)); err != nil {
+		return diag.Errorf("failed to delete virtual machine %s: activity %q did not complete: %s", id, activityID, err)
+	}
+	return nil
+}
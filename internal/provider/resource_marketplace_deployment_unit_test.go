@@ -0,0 +1,251 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const (
+	mktTestItemID = "11111111-2222-3333-4444-555555555555"
+	mktTestSRID   = "22222222-2222-3333-4444-555555555555"
+	mktTestNetA   = "33333333-2222-3333-4444-555555555555"
+	mktTestNetB   = "44444444-2222-3333-4444-555555555555"
+	mktTestVM1    = "55555555-2222-3333-4444-555555555555"
+	mktTestVM2    = "66666666-2222-3333-4444-555555555555"
+	mktTestDCID   = "77777777-2222-3333-4444-555555555555"
+	mktTestDSID   = "88888888-2222-3333-4444-555555555555"
+)
+
+func newMarketplaceDeploymentRD(t *testing.T, raw map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+	cfg := map[string]interface{}{
+		"marketplace_item_id": mktTestItemID,
+		"name":                "appliance",
+	}
+	for k, v := range raw {
+		cfg[k] = v
+	}
+	return schema.TestResourceDataRaw(t, resourceMarketplaceDeployment().Schema, cfg)
+}
+
+func mktOpenIaasInfo(adapters ...string) *client.MarketplaceOpenIaasItemInfo {
+	info := &client.MarketplaceOpenIaasItemInfo{}
+	for _, name := range adapters {
+		info.NetworkAdapters = append(info.NetworkAdapters, struct {
+			Name        string
+			NetworkName string
+			MTU         int
+		}{Name: name, NetworkName: "net-" + name})
+	}
+	return info
+}
+
+func mktCompleted(items ...client.ActivityConcernedItem) func(ctx context.Context, a string) (*client.Activity, error) {
+	return func(ctx context.Context, a string) (*client.Activity, error) {
+		return &client.Activity{ID: a, ConcernedItems: items}, nil
+	}
+}
+
+func TestCreateMarketplaceDeploymentWith(t *testing.T) {
+	t.Run("openiaas: placement, network mapping and every created VM", func(t *testing.T) {
+		d := newMarketplaceDeploymentRD(t, map[string]interface{}{
+			"target":      "openiaas",
+			"network_ids": []interface{}{mktTestNetA, mktTestNetB},
+			"openiaas": []interface{}{map[string]interface{}{
+				"storage_repository_id": mktTestSRID,
+				"cloud_init":            map[string]interface{}{"cloud_config": "#cloud-config"},
+			}},
+		})
+		var infoTarget string
+		var sent *client.MarketplaceOpenIaasDeployementRequest
+		funcs := marketplaceDeploymentCRUDFuncs{
+			readInfo: func(ctx context.Context, id, target string) (*client.MarketplaceOpenIaasItemInfo, *client.MarketplaceVMWareItemInfo, error) {
+				infoTarget = target
+				return mktOpenIaasInfo("VIF #0", "VIF #1"), nil, nil
+			},
+			deployOpenIaas: func(ctx context.Context, req *client.MarketplaceOpenIaasDeployementRequest) (string, error) {
+				sent = req
+				return "act-1", nil
+			},
+			waitActivity: mktCompleted(
+				client.ActivityConcernedItem{ID: mktTestVM1, Type: "virtual_machine"},
+				client.ActivityConcernedItem{ID: mktTestSRID, Type: "storage_repository"},
+				client.ActivityConcernedItem{ID: mktTestVM2, Type: "virtual_machine"},
+			),
+		}
+		if diags := createMarketplaceDeploymentWith(context.Background(), d, funcs); diags.HasError() {
+			t.Fatalf("unexpected diags: %v", diags)
+		}
+		if infoTarget != "open_iaas" {
+			t.Fatalf("ReadInfo target = %q, want open_iaas", infoTarget)
+		}
+		if sent == nil || sent.ID != mktTestItemID || sent.StorageRepositoryID != mktTestSRID || sent.CloudInit == nil {
+			t.Fatalf("unexpected deploy request: %+v", sent)
+		}
+		if len(sent.NetworkData) != 2 || sent.NetworkData[1].NetworkAdapterName != "VIF #1" || sent.NetworkData[1].DestinationNetworkId != mktTestNetB {
+			t.Fatalf("unexpected network mapping: %+v", sent.NetworkData)
+		}
+		if d.Id() != "act-1" {
+			t.Fatalf("id = %q, want the activity id", d.Id())
+		}
+		ids := marketplaceDeploymentVMIDs(d)
+		if len(ids) != 2 || ids[0] != mktTestVM1 || ids[1] != mktTestVM2 {
+			t.Fatalf("virtual_machine_ids = %v", ids)
+		}
+	})
+
+	t.Run("vmware: placement and deploy options", func(t *testing.T) {
+		d := newMarketplaceDeploymentRD(t, map[string]interface{}{
+			"target": "vmware",
+			"vmware": []interface{}{map[string]interface{}{
+				"datacenter_id":  mktTestDCID,
+				"datastore_id":   mktTestDSID,
+				"deploy_options": map[string]interface{}{"hostname": "app-1"},
+			}},
+		})
+		var sent *client.MarketplaceVMWareDeployementRequest
+		funcs := marketplaceDeploymentCRUDFuncs{
+			readInfo: func(ctx context.Context, id, target string) (*client.MarketplaceOpenIaasItemInfo, *client.MarketplaceVMWareItemInfo, error) {
+				return nil, &client.MarketplaceVMWareItemInfo{}, nil
+			},
+			deployVMware: func(ctx context.Context, req *client.MarketplaceVMWareDeployementRequest) (string, error) {
+				sent = req
+				return "act-2", nil
+			},
+			waitActivity: func(ctx context.Context, a string) (*client.Activity, error) {
+				return &client.Activity{State: map[string]client.ActivityState{"completed": {Result: mktTestVM1}}}, nil
+			},
+		}
+		if diags := createMarketplaceDeploymentWith(context.Background(), d, funcs); diags.HasError() {
+			t.Fatalf("unexpected diags: %v", diags)
+		}
+		if sent == nil || sent.DatacenterID != mktTestDCID || sent.DatastoreID != mktTestDSID || len(sent.DeployOptions) != 1 || sent.DeployOptions[0].ID != "hostname" {
+			t.Fatalf("unexpected deploy request: %+v", sent)
+		}
+		if ids := marketplaceDeploymentVMIDs(d); len(ids) != 1 || ids[0] != mktTestVM1 {
+			t.Fatalf("virtual_machine_ids = %v", ids)
+		}
+	})
+
+	t.Run("a network count mismatch fails before deploying", func(t *testing.T) {
+		d := newMarketplaceDeploymentRD(t, map[string]interface{}{
+			"target":      "openiaas",
+			"network_ids": []interface{}{mktTestNetA},
+			"openiaas":    []interface{}{map[string]interface{}{"storage_repository_id": mktTestSRID}},
+		})
+		funcs := marketplaceDeploymentCRUDFuncs{
+			readInfo: func(ctx context.Context, id, target string) (*client.MarketplaceOpenIaasItemInfo, *client.MarketplaceVMWareItemInfo, error) {
+				return mktOpenIaasInfo("VIF #0", "VIF #1"), nil, nil
+			},
+			deployOpenIaas: func(ctx context.Context, req *client.MarketplaceOpenIaasDeployementRequest) (string, error) {
+				t.Fatal("a mismatch must not deploy")
+				return "", nil
+			},
+		}
+		if diags := createMarketplaceDeploymentWith(context.Background(), d, funcs); !diags.HasError() {
+			t.Fatal("a network count mismatch must fail")
+		}
+	})
+
+	t.Run("an item not deployable on the target fails", func(t *testing.T) {
+		d := newMarketplaceDeploymentRD(t, map[string]interface{}{
+			"target":   "openiaas",
+			"openiaas": []interface{}{map[string]interface{}{"storage_repository_id": mktTestSRID}},
+		})
+		funcs := marketplaceDeploymentCRUDFuncs{
+			readInfo: func(ctx context.Context, id, target string) (*client.MarketplaceOpenIaasItemInfo, *client.MarketplaceVMWareItemInfo, error) {
+				return nil, nil, nil
+			},
+		}
+		if diags := createMarketplaceDeploymentWith(context.Background(), d, funcs); !diags.HasError() {
+			t.Fatal("missing item info must fail")
+		}
+	})
+
+	t.Run("a failed activity names the orphan risk and sets no id", func(t *testing.T) {
+		d := newMarketplaceDeploymentRD(t, map[string]interface{}{
+			"target":   "openiaas",
+			"openiaas": []interface{}{map[string]interface{}{"storage_repository_id": mktTestSRID}},
+		})
+		funcs := marketplaceDeploymentCRUDFuncs{
+			readInfo: func(ctx context.Context, id, target string) (*client.MarketplaceOpenIaasItemInfo, *client.MarketplaceVMWareItemInfo, error) {
+				return mktOpenIaasInfo(), nil, nil
+			},
+			deployOpenIaas: func(ctx context.Context, req *client.MarketplaceOpenIaasDeployementRequest) (string, error) {
+				return "act-3", nil
+			},
+			waitActivity: func(ctx context.Context, a string) (*client.Activity, error) { return nil, errors.New("failed") },
+		}
+		diags := createMarketplaceDeploymentWith(context.Background(), d, funcs)
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, "ORPHANED") || !strings.Contains(diags[0].Summary, "act-3") {
+			t.Fatalf("expected an orphan diagnostic naming the activity, got %v", diags)
+		}
+		if d.Id() != "" {
+			t.Fatal("a failed deployment must not set an id")
+		}
+	})
+}
+
+func TestMarketplaceDeployedVMIDs(t *testing.T) {
+	t.Run("duplicates are collapsed", func(t *testing.T) {
+		ids, err := marketplaceDeployedVMIDs(&client.Activity{ConcernedItems: []client.ActivityConcernedItem{
+			{ID: mktTestVM1, Type: "virtual_machine"},
+			{ID: strings.ToUpper(mktTestVM1), Type: "virtual_machine"},
+		}})
+		if err != nil || len(ids) != 1 {
+			t.Fatalf("ids = %v, err = %v", ids, err)
+		}
+	})
+
+	for name, activity := range map[string]*client.Activity{
+		"nil activity":     nil,
+		"no vm":            {ConcernedItems: []client.ActivityConcernedItem{{ID: mktTestSRID, Type: "storage_repository"}}},
+		"non-uuid item":    {ConcernedItems: []client.ActivityConcernedItem{{ID: "vm-1", Type: "virtual_machine"}}},
+		"non-uuid result":  {State: map[string]client.ActivityState{"completed": {Result: "ok"}}},
+		"ambiguous states": {State: map[string]client.ActivityState{"a": {Result: mktTestVM1}, "b": {Result: mktTestVM2}}},
+	} {
+		if _, err := marketplaceDeployedVMIDs(activity); err == nil {
+			t.Fatalf("%s: must fail closed", name)
+		}
+	}
+}
+
+func TestMarketplaceDeploymentCustomizeDiffRequiresTargetBlock(t *testing.T) {
+	res := resourceMarketplaceDeployment()
+	base := map[string]interface{}{
+		"marketplace_item_id": mktTestItemID,
+		"name":                "appliance",
+	}
+	cfg := func(extra map[string]interface{}) *terraform.ResourceConfig {
+		raw := map[string]interface{}{}
+		for k, v := range base {
+			raw[k] = v
+		}
+		for k, v := range extra {
+			raw[k] = v
+		}
+		return terraform.NewResourceConfigRaw(raw)
+	}
+
+	mismatch := cfg(map[string]interface{}{
+		"target":   "vmware",
+		"openiaas": []interface{}{map[string]interface{}{"storage_repository_id": mktTestSRID}},
+	})
+	if _, err := res.Diff(context.Background(), nil, mismatch, nil); err == nil || !strings.Contains(err.Error(), `"vmware" block is required`) {
+		t.Fatalf("expected the missing vmware block to be reported at plan time, got %v", err)
+	}
+
+	match := cfg(map[string]interface{}{
+		"target":   "openiaas",
+		"openiaas": []interface{}{map[string]interface{}{"storage_repository_id": mktTestSRID}},
+	})
+	if _, err := res.Diff(context.Background(), nil, match, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -21,7 +21,7 @@ func resourceBucket() *schema.Resource {
 		UpdateContext: objectStorageBucketUpdate,
 		DeleteContext: objectStorageBucketDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: objectStorageBucketImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -373,3 +373,35 @@ func objectStorageBucketDelete(ctx context.Context, d *schema.ResourceData, meta
 
 	return nil
 }
+
+// objectStorageBucketImport resolves an import by identity: the bucket API is
+// addressed by name, the name identifies the bucket and its ID is looked up.
+// An import by ID is passed through as before.
+func objectStorageBucketImport(ctx context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	if d.Id() != "" {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	identity, err := d.Identity()
+	if err != nil {
+		return nil, err
+	}
+	name, _ := identity.Get("name").(string)
+	if name == "" {
+		return nil, fmt.Errorf("the identity of the bucket has no name")
+	}
+
+	bucket, err := getClient(meta).ObjectStorage().Bucket().Read(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bucket %q: %s", name, err)
+	}
+	if bucket == nil {
+		return nil, fmt.Errorf("bucket %q not found", name)
+	}
+
+	d.SetId(bucket.ID)
+	if err := d.Set("name", bucket.Name); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
@@ -17,7 +17,7 @@ func resourceStorageAccount() *schema.Resource {
 		ReadContext:   objectStorageStorageAccountRead,
 		DeleteContext: objectStorageStorageAccountDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: schema.ImportStatePassthroughWithIdentity("name"),
 		},
 
 		Schema: map[string]*schema.Schema{
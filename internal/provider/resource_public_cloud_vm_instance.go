@@ -40,7 +40,7 @@ func resourcePublicCloudVMInstance() *schema.Resource {
 		UpdateContext: resourcePublicCloudVMInstanceUpdate,
 		DeleteContext: resourcePublicCloudVMInstanceDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: schema.ImportStatePassthroughWithIdentity("id"),
 		},
 		CustomizeDiff: customizeVMInstanceDiff,
 
@@ -153,6 +153,8 @@ func resourcePublicCloudVMInstance() *schema.Resource {
 					"the only allowed cloud_init keys are cloud_config and network_config",
 				),
 			},
+			"cloud_init_wo":         cloudInitWriteOnlySchema(noCloudInitKeys, true),
+			"cloud_init_wo_version": cloudInitWriteOnlyVersionSchema(true),
 
 			// Out
 			"status": {
@@ -405,6 +407,8 @@ func resourcePublicCloudVMInstanceDelete(ctx context.Context, d *schema.Resource
 // createVMInstanceWith holds the testable create orchestration. State safety: the
 // worst outcome is an ORPHAN (created platform-side, absent from state).
 //   - create error -> FAIL, never SetId.
+//   - activity wait interrupted (apply cancelled, timeout) -> keep the activity as
+//     a pending create (recordPendingCreate): the next run resumes it.
 //   - activity wait failure -> FAIL with the activityId + an audit hint (a VM may
 //     exist; import or delete it before re-applying).
 //   - the id is taken ONLY from the completed activity (concernedItems "vmi" or
@@ -439,6 +443,11 @@ func createVMInstanceWith(ctx context.Context, d *schema.ResourceData, funcs vmI
 	}
 
 	activity, err := funcs.waitActivity(ctx, activityID)
+	if waitInterrupted(ctx, err) {
+		// The VM keeps being created: record the activity so the next run
+		// resumes it instead of creating a second VM.
+		return recordPendingCreate(ctx, d, "VM instance", activityID, err)
+	}
 	if err != nil {
 		return diag.Errorf(
 			"VM instance %q create activity %q did not complete: %s. If a VM was created it is now ORPHANED outside the state — audit the VM instances for a recently-created %q and import it (terraform import) or delete it before re-applying.",
@@ -446,11 +455,8 @@ func createVMInstanceWith(ctx context.Context, d *schema.ResourceData, funcs vmI
 		)
 	}
 
-	// The id comes ONLY from the completed activity: the concernedItems entry of
-	// type "vmi", or the terminal state result. Never guess it from the name.
-	setIdFromActivityConcernedItems(d, activity, "vmi")
-	if d.Id() == "" {
-		setIdFromActivityState(d, activity)
+	if id := vmInstanceIDFromActivity(activity); id != "" {
+		d.SetId(id)
 	}
 	if d.Id() == "" {
 		return diag.Errorf(
@@ -463,6 +469,28 @@ func createVMInstanceWith(ctx context.Context, d *schema.ResourceData, funcs vmI
 	return readVMInstanceInto(ctx, d, funcs, vmInstanceReadAfterWrite)
 }
 
+// vmInstanceIDFromActivity returns the VM id of a completed create activity: the
+// concernedItems entry of type "vmi", or the terminal state result. The id comes
+// ONLY from the activity, it is never guessed from the name.
+func vmInstanceIDFromActivity(activity *client.Activity) string {
+	if id := activityConcernedItemID(activity, "vmi"); id != "" {
+		return id
+	}
+	return singleActivityResult(activity)
+}
+
+// vmInstanceCreateResolver resumes a create interrupted while waiting on its
+// activity (see resumePendingCreate).
+func vmInstanceCreateResolver(funcs vmInstanceCRUDFuncs) pendingCreateResolver {
+	return func(ctx context.Context, activityID string) (string, error) {
+		activity, err := funcs.waitActivity(ctx, activityID)
+		if err != nil {
+			return "", err
+		}
+		return vmInstanceIDFromActivity(activity), nil
+	}
+}
+
 // readVMInstanceInto holds the testable read logic. The resource is NEVER dropped
 // on an inconclusive read (E0-9):
 //   - read error (403/5xx/transport) -> FAIL CLOSED, keep the resource.
@@ -472,6 +500,9 @@ func createVMInstanceWith(ctx context.Context, d *schema.ResourceData, funcs vmI
 //     drop ONLY if the id is absent from it; if it is still listed, or the listing
 //     fails, keep the resource and error.
 func readVMInstanceInto(ctx context.Context, d *schema.ResourceData, funcs vmInstanceCRUDFuncs, mode vmInstanceReadMode) diag.Diagnostics {
+	if diags, stop := resumePendingCreate(ctx, d, "VM instance", vmInstanceCreateResolver(funcs)); stop {
+		return diags
+	}
 	id := d.Id()
 
 	vm, err := funcs.read(ctx, id)
@@ -692,6 +723,9 @@ func executeVMInstanceUpdate(ctx context.Context, id string, plan []vmUpdateOp,
 // deterministic op plan (metadata -> stop -> resize -> start), builds the request
 // bodies for only the changed dimensions, and executes the plan in order.
 func updateVMInstanceWith(ctx context.Context, d *schema.ResourceData, funcs vmInstanceCRUDFuncs) diag.Diagnostics {
+	if diags, stop := resumePendingCreateForWrite(ctx, d, "VM instance", vmInstanceCreateResolver(funcs)); stop {
+		return diags
+	}
 	id := d.Id()
 
 	metadataChanged := d.HasChange("name") || d.HasChange("backup_policy_id")
@@ -744,6 +778,9 @@ func updateVMInstanceWith(ctx context.Context, d *schema.ResourceData, funcs vmI
 
 // deleteVMInstanceWith holds the testable delete orchestration.
 func deleteVMInstanceWith(ctx context.Context, d *schema.ResourceData, funcs vmInstanceCRUDFuncs) diag.Diagnostics {
+	if diags, stop := resumePendingCreateForWrite(ctx, d, "VM instance", vmInstanceCreateResolver(funcs)); stop {
+		return diags
+	}
 	if err := runVMInstanceActivity(ctx, funcs.waitActivity, func() (string, error) { return funcs.del(ctx, d.Id()) }); err != nil {
 		return diag.Errorf("failed to delete VM instance %s: %s", d.Id(), err)
 	}
@@ -776,7 +813,7 @@ func buildCreateVMInstanceRequest(d *schema.ResourceData) (*client.CreateVMInsta
 		PowerState:         d.Get("power_state").(string),
 		NetworkInterfaces:  expandVMInstanceNICs(d.Get("os_network_adapter").([]interface{})),
 	}
-	req.CloudInit = expandVMInstanceCloudInit(d.Get("cloud_init").(map[string]interface{}))
+	req.CloudInit = expandVMInstanceCloudInit(cloudInitConfig(d, noCloudInitKeys))
 	return req, nil
 }
 
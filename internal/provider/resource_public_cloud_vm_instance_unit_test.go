@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
@@ -287,6 +288,27 @@ func TestCreateVMInstanceWith(t *testing.T) {
 		}
 	})
 
+	t.Run("an interrupted wait records the pending activity without an error", func(t *testing.T) {
+		d := createRD(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		funcs := vmInstanceCRUDFuncs{
+			networkRead: okNetworkReadPB,
+			create:      func(ctx context.Context, r *client.CreateVMInstanceRequest) (string, error) { return "act-1", nil },
+			waitActivity: func(ctx context.Context, a string) (*client.Activity, error) {
+				cancel()
+				return nil, ctx.Err()
+			},
+		}
+		diags := createVMInstanceWith(ctx, d, funcs)
+		// An error would taint the resource and plan a replacement: a second VM.
+		if diags.HasError() || len(diags) != 1 || !strings.Contains(diags[0].Summary, "act-1") {
+			t.Fatalf("expected a single warning naming the activity, got %v", diags)
+		}
+		if d.Id() != pendingCreateID("act-1") {
+			t.Fatalf("id = %q, want the pending activity placeholder", d.Id())
+		}
+	})
+
 	t.Run("a create error fails and never sets an id", func(t *testing.T) {
 		d := createRD(t)
 		funcs := vmInstanceCRUDFuncs{
@@ -305,6 +327,62 @@ func TestCreateVMInstanceWith(t *testing.T) {
 	})
 }
 
+// TestVMInstancePendingCreateIsResumed pins the resume side of an interrupted
+// create: the refresh waits on the recorded activity and adopts the VM, and the
+// delete of a still-pending create never issues a DELETE.
+func TestVMInstancePendingCreateIsResumed(t *testing.T) {
+	t.Run("the refresh adopts the created VM without a second create", func(t *testing.T) {
+		d := createRD(t)
+		d.SetId(pendingCreateID("act-1"))
+		var waited string
+		funcs := vmInstanceCRUDFuncs{
+			create: func(ctx context.Context, r *client.CreateVMInstanceRequest) (string, error) {
+				t.Fatal("a pending create must be resumed, never re-created")
+				return "", nil
+			},
+			waitActivity: func(ctx context.Context, a string) (*client.Activity, error) {
+				waited = a
+				return vmiCompletedActivity("vm-1", ""), nil
+			},
+			read: func(ctx context.Context, id string) (*client.PublicCloudVMInstance, error) {
+				return &client.PublicCloudVMInstance{ID: id, Name: "web", Status: "running", VCPU: 2, RAMGb: 4}, nil
+			},
+			listDisks: okListPrimaryDisk,
+		}
+		if diags := readVMInstanceInto(context.Background(), d, funcs, vmInstanceReadForRefresh); diags.HasError() {
+			t.Fatalf("unexpected diags: %v", diags)
+		}
+		if waited != "act-1" || d.Id() != "vm-1" {
+			t.Fatalf("waited on %q, id = %q; want act-1 / vm-1", waited, d.Id())
+		}
+		if d.Get("status").(string) != "running" {
+			t.Fatalf("the adopted VM must be read, status = %q", d.Get("status"))
+		}
+	})
+
+	t.Run("deleting a create still in progress fails without a DELETE", func(t *testing.T) {
+		d := createRD(t)
+		d.SetId(pendingCreateID("act-1"))
+		ctx, cancel := context.WithCancel(context.Background())
+		funcs := vmInstanceCRUDFuncs{
+			waitActivity: func(ctx context.Context, a string) (*client.Activity, error) {
+				cancel()
+				return nil, ctx.Err()
+			},
+			del: func(ctx context.Context, id string) (string, error) {
+				t.Fatalf("no DELETE expected while the create is pending, got %q", id)
+				return "", nil
+			},
+		}
+		if diags := deleteVMInstanceWith(ctx, d, funcs); !diags.HasError() {
+			t.Fatal("deleting a pending create must fail")
+		}
+		if d.Id() != pendingCreateID("act-1") {
+			t.Fatalf("the pending activity must be kept, id = %q", d.Id())
+		}
+	})
+}
+
 // TestCreateVMInstanceVPCGuard pins the phase-1 VPC rule: the inline
 // os_network_adapter block only supports Private Backbone networks. The
 // preflight resolves each declared network BEFORE the create POST — a VPC
@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceTag() *schema.Resource {
+	return &schema.Resource{
+		Description: "Attach a single tag to any platform object (datastore, network, host, bucket, public cloud instance, …), including objects that Terraform does not manage. Do not manage the same key both with this resource and with the `tags` attribute (or the provider `default_tags`) of the tagged resource: each would remove the other's value.",
+
+		CreateContext: tagCreate,
+		ReadContext:   tagRead,
+		UpdateContext: tagUpdate,
+		DeleteContext: tagDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: tagImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			// In
+			"resource_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+				Description:  "The ID of the object to tag.",
+			},
+			"resource_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The type of the object to tag, e.g. `vcenter_virtual_machine` or `iaas_opensource_virtual_machine`.",
+			},
+			"source": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The product the object belongs to, e.g. `vmware` or `iaas_opensource`.",
+			},
+			"key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The key of the tag.",
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The value of the tag.",
+			},
+		},
+	}
+}
+
+// tagID is the id of a cloudtemple_tag: "<resource_id>/<key>". The resource id
+// is a UUID, so the key is everything after the first slash.
+func tagID(resourceID, key string) string {
+	return resourceID + "/" + key
+}
+
+func parseTagID(id string) (resourceID, key string, err error) {
+	resourceID, key, found := strings.Cut(id, "/")
+	if !found || !isUUID(resourceID) || key == "" {
+		return "", "", fmt.Errorf(`invalid tag id %q: expected "<resource_id>/<key>"`, id)
+	}
+	return resourceID, key, nil
+}
+
+func createTag(ctx context.Context, c *client.Client, d *schema.ResourceData) error {
+	return c.Tag().Resource().Create(ctx, &client.CreateTagRequest{
+		Key:   d.Get("key").(string),
+		Value: d.Get("value").(string),
+		Resources: []*client.CreateTagRequestResource{
+			{
+				UUID:   d.Get("resource_id").(string),
+				Type:   d.Get("resource_type").(string),
+				Source: d.Get("source").(string),
+			},
+		},
+	})
+}
+
+func tagCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := getClient(meta)
+
+	resourceID := d.Get("resource_id").(string)
+	key := d.Get("key").(string)
+
+	existing, err := c.Tag().Resource().Read(ctx, resourceID)
+	if err != nil {
+		return diag.Errorf("failed to read the tags of %s: %s", resourceID, err)
+	}
+	for _, tag := range existing {
+		if tag.Key == key {
+			return diag.Errorf("the tag %q already exists on %s; import it with the id %q", key, resourceID,
+				strings.Join([]string{resourceID, d.Get("resource_type").(string), d.Get("source").(string), key}, "/"))
+		}
+	}
+
+	if err := createTag(ctx, c, d); err != nil {
+		return diag.Errorf("failed to create tag %q on %s: %s", key, resourceID, err)
+	}
+	d.SetId(tagID(resourceID, key))
+
+	return tagRead(ctx, d, meta)
+}
+
+func tagRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := getClient(meta)
+
+	resourceID, key, err := parseTagID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tags, err := c.Tag().Resource().Read(ctx, resourceID)
+	if err != nil {
+		// A 404 or a 403 of the tag listing is not proof that the tagged
+		// object is gone (#281): only a strict listing of its kind is.
+		if isStatusCode(err, http.StatusNotFound) || isStatusCode(err, http.StatusForbidden) {
+			gone, proofErr := taggedObjectGone(ctx, c, d.Get("resource_type").(string), resourceID)
+			if proofErr == nil && gone {
+				d.SetId("")
+				return nil
+			}
+		}
+		return diag.Errorf("failed to read the tags of %s, the tag is kept in the state: %s", resourceID, err)
+	}
+
+	for _, tag := range tags {
+		if tag.Key != key {
+			continue
+		}
+		sw := newStateWriter(d)
+		sw.set("resource_id", resourceID)
+		sw.set("key", tag.Key)
+		sw.set("value", tag.Value)
+		return sw.diags
+	}
+
+	// The listing succeeded and no longer holds the key: the tag was removed.
+	d.SetId("")
+	return nil
+}
+
+// taggedObjectGone reports whether a strict, complete listing of the kind of
+// the tagged object proves it no longer exists. The kinds without such a
+// listing never prove anything: their tag is kept in the state.
+func taggedObjectGone(ctx context.Context, c *client.Client, resourceType, id string) (bool, error) {
+	var ids []string
+	switch resourceType {
+	case "vcenter_virtual_machine":
+		vms, err := c.Compute().VirtualMachine().ListStrict(ctx, &client.VirtualMachineFilter{})
+		if err != nil {
+			return false, err
+		}
+		for _, vm := range vms {
+			ids = append(ids, vm.ID)
+		}
+	case "iaas_opensource_virtual_machine":
+		vms, err := c.Compute().OpenIaaS().VirtualMachine().ListStrict(ctx, &client.OpenIaaSVirtualMachineFilter{})
+		if err != nil {
+			return false, err
+		}
+		for _, vm := range vms {
+			ids = append(ids, vm.ID)
+		}
+	default:
+		return false, nil
+	}
+	for _, candidate := range ids {
+		if sameUUID(candidate, id) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// tagUpdate changes the value of the tag. The API has no update: the tag is
+// deleted, then created again with the new value, as the tags attribute of the
+// virtual machines does.
+func tagUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := getClient(meta)
+
+	resourceID, key, err := parseTagID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := c.Tag().Resource().Delete(ctx, resourceID, key); err != nil {
+		return diag.Errorf("failed to delete tag %q of %s: %s", key, resourceID, err)
+	}
+	if err := createTag(ctx, c, d); err != nil {
+		return diag.Errorf("failed to create tag %q on %s: %s. The tag was deleted and must be applied again.", key, resourceID, err)
+	}
+
+	return tagRead(ctx, d, meta)
+}
+
+func tagDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := getClient(meta)
+
+	resourceID, key, err := parseTagID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = c.Tag().Resource().Delete(ctx, resourceID, key)
+	if err != nil && !isStatusCode(err, http.StatusNotFound) {
+		return diag.Errorf("failed to delete tag %q of %s: %s", key, resourceID, err)
+	}
+	return nil
+}
+
+// tagImport takes "<resource_id>/<resource_type>/<source>/<key>": the type and
+// the source are needed to write the tag again, and the API does not return
+// them.
+func tagImport(ctx context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 4)
+	if len(parts) != 4 || !isUUID(parts[0]) || parts[1] == "" || parts[2] == "" || parts[3] == "" {
+		return nil, fmt.Errorf(`invalid import id %q: expected "<resource_id>/<resource_type>/<source>/<key>"`, d.Id())
+	}
+
+	d.SetId(tagID(parts[0], parts[3]))
+	sw := newStateWriter(d)
+	sw.set("resource_id", parts[0])
+	sw.set("resource_type", parts[1])
+	sw.set("source", parts[2])
+	sw.set("key", parts[3])
+	if sw.diags.HasError() {
+		return nil, fmt.Errorf("failed to import tag %q", d.Id())
+	}
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const tagTestResourceID = "11111111-2222-3333-4444-555555555555"
+
+func newTagData(t *testing.T, value string) *schema.ResourceData {
+	t.Helper()
+	return schema.TestResourceDataRaw(t, resourceTag().Schema, map[string]interface{}{
+		"resource_id":   tagTestResourceID,
+		"resource_type": "vcenter_datastore",
+		"source":        "vmware",
+		"key":           "cost_center",
+		"value":         value,
+	})
+}
+
+func TestParseTagID(t *testing.T) {
+	resourceID, key, err := parseTagID(tagTestResourceID + "/team/platform")
+	if err != nil || resourceID != tagTestResourceID || key != "team/platform" {
+		t.Fatalf("parseTagID = %q, %q, %v", resourceID, key, err)
+	}
+	for _, id := range []string{"", tagTestResourceID, tagTestResourceID + "/", "not-a-uuid/key"} {
+		if _, _, err := parseTagID(id); err == nil {
+			t.Errorf("parseTagID(%q) must fail", id)
+		}
+	}
+}
+
+func TestTagImport(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceTag().Schema, map[string]interface{}{})
+	d.SetId(tagTestResourceID + "/vcenter_datastore/vmware/cost_center")
+	if _, err := tagImport(context.Background(), d, nil); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != tagTestResourceID+"/cost_center" || d.Get("resource_type") != "vcenter_datastore" || d.Get("source") != "vmware" || d.Get("key") != "cost_center" {
+		t.Fatalf("imported id=%q state=%v", d.Id(), d.State())
+	}
+
+	d.SetId(tagTestResourceID + "/cost_center")
+	if _, err := tagImport(context.Background(), d, nil); err == nil {
+		t.Fatal("an import id without the resource type and source must be rejected")
+	}
+}
+
+// tagServer stubs the tag API of a single object holding tags.
+func tagServer(t *testing.T, tags map[string]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/tag/v1/tags/resources/"+tagTestResourceID):
+			var out []*client.Tag
+			for key, value := range tags {
+				out = append(out, &client.Tag{Key: key, Value: value})
+			}
+			_ = json.NewEncoder(w).Encode(out)
+		case r.Method == http.MethodDelete && strings.Contains(r.URL.Path, "/tag/v1/tags/resources/"+tagTestResourceID+"/keys/"):
+			escaped := r.URL.EscapedPath()
+			key, _ := url.PathUnescape(escaped[strings.LastIndex(escaped, "/")+1:])
+			delete(tags, key)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/tag/v1/tags"):
+			var req client.CreateTagRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if len(req.Resources) != 1 || req.Resources[0].UUID != tagTestResourceID || req.Resources[0].Type != "vcenter_datastore" || req.Resources[0].Source != "vmware" {
+				t.Errorf("unexpected tag request resources: %+v", req.Resources)
+			}
+			tags[req.Key] = req.Value
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
+
+func TestTagLifecycle(t *testing.T) {
+	tags := map[string]string{"owner": "ops"}
+	c := newAssignTestClient(t, tagServer(t, tags))
+	ctx := context.Background()
+
+	d := newTagData(t, "1234")
+	if diags := tagCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != tagTestResourceID+"/cost_center" || tags["cost_center"] != "1234" {
+		t.Fatalf("id=%q tags=%v", d.Id(), tags)
+	}
+
+	if diags := tagCreate(ctx, newTagData(t, "5678"), c); !diags.HasError() {
+		t.Fatal("creating a tag whose key already exists must fail and point to the import")
+	}
+
+	d.Set("value", "5678")
+	if diags := tagUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if tags["cost_center"] != "5678" || d.Get("value") != "5678" {
+		t.Fatalf("update did not change the value: tags=%v", tags)
+	}
+
+	if diags := tagDelete(ctx, d, c); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if _, found := tags["cost_center"]; found || tags["owner"] != "ops" {
+		t.Fatalf("delete must only remove its own key: tags=%v", tags)
+	}
+
+	if diags := tagRead(ctx, d, c); diags.HasError() || d.Id() != "" {
+		t.Fatalf("a tag missing from the listing must be removed from the state: id=%q diags=%v", d.Id(), diags)
+	}
+}
+
+func TestTagReadKeepsTheStateOnError(t *testing.T) {
+	c := newAssignTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	d := newTagData(t, "1234")
+	d.SetId(tagID(tagTestResourceID, "cost_center"))
+	if diags := tagRead(context.Background(), d, c); !diags.HasError() || d.Id() == "" {
+		t.Fatalf("a failed read is not proof of absence: id=%q diags=%v", d.Id(), diags)
+	}
+}
+
+func TestTagDeleteEscapesTheKey(t *testing.T) {
+	var deleted string
+	c := newAssignTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		deleted = r.URL.EscapedPath()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	d := newTagData(t, "1234")
+	d.SetId(tagID(tagTestResourceID, "team/a?b%c"))
+	if diags := tagDelete(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if want := "/tag/v1/tags/resources/" + tagTestResourceID + "/keys/team%2Fa%3Fb%25c"; deleted != want {
+		t.Fatalf("DELETE %s, want %s", deleted, want)
+	}
+}
+
+// TestTagReadOnAMissingTagListing: a 404 or 403 of the tag listing only drops
+// the tag when a strict listing of the tagged object's kind proves it is gone.
+func TestTagReadOnAMissingTagListing(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resourceType string
+		tagStatus    int
+		listStatus   int
+		listed       []string
+		dropped      bool
+	}{
+		"kind without a strict listing": {"vcenter_datastore", http.StatusNotFound, http.StatusOK, nil, false},
+		"virtual machine gone":          {"vcenter_virtual_machine", http.StatusNotFound, http.StatusOK, []string{"66666666-2222-3333-4444-555555555555"}, true},
+		"virtual machine gone, 403":     {"iaas_opensource_virtual_machine", http.StatusForbidden, http.StatusOK, nil, true},
+		"virtual machine still listed":  {"vcenter_virtual_machine", http.StatusNotFound, http.StatusOK, []string{strings.ToUpper(tagTestResourceID)}, false},
+		"listing denied":                {"vcenter_virtual_machine", http.StatusNotFound, http.StatusForbidden, nil, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := newAssignTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if strings.Contains(r.URL.Path, "/tag/v1/") {
+					w.WriteHeader(tc.tagStatus)
+					return
+				}
+				if !strings.HasSuffix(r.URL.Path, "/virtual_machines") {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+				w.WriteHeader(tc.listStatus)
+				vms := []map[string]string{}
+				for _, id := range tc.listed {
+					vms = append(vms, map[string]string{"id": id})
+				}
+				_ = json.NewEncoder(w).Encode(vms)
+			})
+			d := schema.TestResourceDataRaw(t, resourceTag().Schema, map[string]interface{}{
+				"resource_id":   tagTestResourceID,
+				"resource_type": tc.resourceType,
+				"source":        "vmware",
+				"key":           "cost_center",
+				"value":         "1234",
+			})
+			d.SetId(tagID(tagTestResourceID, "cost_center"))
+
+			diags := tagRead(context.Background(), d, c)
+			if tc.dropped && (diags.HasError() || d.Id() != "") {
+				t.Fatalf("a proven absence must drop the tag: id=%q diags=%v", d.Id(), diags)
+			}
+			if !tc.dropped && (!diags.HasError() || d.Id() == "") {
+				t.Fatalf("an unproven absence must keep the tag and fail: id=%q diags=%v", d.Id(), diags)
+			}
+		})
+	}
+}
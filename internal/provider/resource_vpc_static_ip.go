@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"regexp"
 	"strings"
@@ -155,8 +156,21 @@ const (
 func resourceVPCStaticIPCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	c := getClient(meta)
 	return createVPCStaticIPWith(ctx, d, vpcStaticIPCreateFuncs{
+		// Create = CreateStart + WaitCreate, composed here so a wait failure keeps
+		// its activity (pendingCreateError) and an interrupted create can resume.
 		create: func(ctx context.Context, privateNetworkID string, req *client.CreateStaticIPRequest) (string, error) {
-			return c.VPC().StaticIP().Create(ctx, privateNetworkID, req, getWaiterOptions(ctx))
+			activityID, syncID, err := c.VPC().StaticIP().CreateStart(ctx, privateNetworkID, req)
+			if err != nil || syncID != "" {
+				return syncID, err
+			}
+			id, err := c.VPC().StaticIP().WaitCreate(ctx, activityID, getWaiterOptions(ctx))
+			if err != nil {
+				return "", &pendingCreateError{
+					activityID: activityID,
+					err:        fmt.Errorf("static IP create on private network %s: activity %q did not complete: %w", privateNetworkID, activityID, err),
+				}
+			}
+			return id, nil
 		},
 		read: c.VPC().StaticIP().Read,
 		listStrict: func(ctx context.Context, privateNetworkID string) ([]*client.StaticIP, error) {
@@ -165,11 +179,19 @@ func resourceVPCStaticIPCreate(ctx context.Context, d *schema.ResourceData, meta
 	})
 }
 
+// vpcStaticIPCreateResolver resumes a create interrupted while waiting on its
+// activity (see resumePendingCreate), through the same WaitCreate as the create.
+func vpcStaticIPCreateResolver(c *client.Client) pendingCreateResolver {
+	return func(ctx context.Context, activityID string) (string, error) {
+		return c.VPC().StaticIP().WaitCreate(ctx, activityID, getWaiterOptions(ctx))
+	}
+}
+
 // vpcStaticIPCreateFuncs abstracts the create API surface so the create
 // orchestration is unit tested without HTTP calls. create returns the new static
-// IP id: the client (Create = CreateStart + WaitCreate) owns the async id
-// resolution and FAILS CLOSED (it never returns ("", nil); a wait failure is
-// wrapped WITH the activityID), so the provider only has to honour that contract.
+// IP id: CreateStart + WaitCreate own the async id resolution and FAIL CLOSED (it
+// never returns ("", nil); a wait failure is a pendingCreateError carrying the
+// activityID), so the provider only has to honour that contract.
 type vpcStaticIPCreateFuncs struct {
 	create     func(ctx context.Context, privateNetworkID string, req *client.CreateStaticIPRequest) (string, error)
 	read       vpcStaticIPReadFunc
@@ -180,6 +202,9 @@ type vpcStaticIPCreateFuncs struct {
 // failure mode — the worst outcome is an ORPHAN (created platform-side, absent from
 // the state):
 //
+//   - create activity wait interrupted (apply cancelled, timeout) -> keep the
+//     activity as a pending create (recordPendingCreate): the next run resumes it
+//     instead of creating a duplicate.
 //   - create error -> FAIL, never SetId. The id was never confirmed, so there is
 //     nothing to track; the client error already carries the activityID (R-Q2) for
 //     correlation, and the never-orphan backstop is the pre-create teardown net
@@ -199,6 +224,10 @@ func createVPCStaticIPWith(ctx context.Context, d *schema.ResourceData, funcs vp
 		IPAddress:           d.Get("ip_address").(string),
 		ResourceDescription: d.Get("resource_description").(string),
 	})
+	var pending *pendingCreateError
+	if errors.As(err, &pending) && waitInterrupted(ctx, err) {
+		return recordPendingCreate(ctx, d, "VPC static IP", pending.activityID, err)
+	}
 	if err != nil {
 		return diag.Errorf(
 			"failed to create VPC static IP on private network %s (MAC %s): %s. If the static IP was created, import it (terraform import) or release it platform-side before re-applying, to avoid a duplicate.",
@@ -222,6 +251,9 @@ func createVPCStaticIPWith(ctx context.Context, d *schema.ResourceData, funcs vp
 
 func resourceVPCStaticIPRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	c := getClient(meta)
+	if diags, stop := resumePendingCreate(ctx, d, "VPC static IP", vpcStaticIPCreateResolver(c)); stop {
+		return diags
+	}
 	return readVPCStaticIPInto(
 		ctx, d,
 		c.VPC().StaticIP().Read,
@@ -374,6 +406,9 @@ type vpcStaticIPUpdateFuncs struct {
 
 func resourceVPCStaticIPUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	c := getClient(meta)
+	if diags, stop := resumePendingCreateForWrite(ctx, d, "VPC static IP", vpcStaticIPCreateResolver(c)); stop {
+		return diags
+	}
 	if diags := updateVPCStaticIPWith(ctx, d, vpcStaticIPUpdateFuncs{
 		read:   c.VPC().StaticIP().Read,
 		update: c.VPC().StaticIP().Update,
@@ -475,6 +510,9 @@ type vpcActivityWaitFunc func(ctx context.Context, activityID string) error
 
 func resourceVPCStaticIPDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	c := getClient(meta)
+	if diags, stop := resumePendingCreateForWrite(ctx, d, "VPC static IP", vpcStaticIPCreateResolver(c)); stop {
+		return diags
+	}
 	return deleteVPCStaticIPWith(
 		ctx, d,
 		c.VPC().StaticIP().Delete,
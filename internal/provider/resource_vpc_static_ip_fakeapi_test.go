@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// newFakeAPIClient starts a fake API and returns it with a provider client
+// using it, whose read retries do not wait.
+func newFakeAPIClient(t *testing.T) (*fakeapi.Server, *client.Client) {
+	t.Helper()
+	s := fakeapi.NewServer()
+	t.Cleanup(s.Close)
+	config := s.Config()
+	config.ReadRetryMax = 3
+	config.ReadRetryBackoffBase = time.Millisecond
+	config.ReadRetryBackoffMax = time.Millisecond
+	c, err := client.NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, c
+}
+
+// TestVPCStaticIPLifecycle runs the create, update and delete of a static IP
+// against the fake API, through the faults the live API is known for: a burst
+// of 502 on the reads, and a static IP answering 404 right after its creation.
+func TestVPCStaticIPLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s, c := newFakeAPIClient(t)
+	s.SetRunningReads(1)
+
+	d := schema.TestResourceDataRaw(t, resourceVPCStaticIP().Schema, map[string]interface{}{
+		"private_network_id":   "pn-1",
+		"mac_address":          "00:50:56:AB:CD:EF",
+		"ip_address":           "10.0.1.50",
+		"resource_description": "managed",
+	})
+
+	// A 404 after the create is eventual consistency: the create fails but keeps
+	// the id, and so does a refresh while the static IP still answers 404. The
+	// next refresh populates it.
+	s.HideCreated(2)
+	diags := resourceVPCStaticIPCreate(ctx, d, c)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "not yet visible") {
+		t.Fatalf("a static IP not yet visible after its creation must fail the create, got %v", diags)
+	}
+	id := d.Id()
+	if id == "" || s.Get(fakeapi.StaticIP, id) == nil {
+		t.Fatalf("the id of the created static IP must be kept, got %q", id)
+	}
+	s.HideCreated(0)
+	if diags := resourceVPCStaticIPRead(ctx, d, c); !diags.HasError() || d.Id() != id {
+		t.Fatalf("a static IP answering 404 but listed must fail the refresh and be kept, got %v and the id %q", diags, d.Id())
+	}
+	if diags := resourceVPCStaticIPRead(ctx, d, c); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if d.Id() != id || d.Get("ip_address") != "10.0.1.50" || d.Get("mac_address") != "00:50:56:ab:cd:ef" {
+		t.Fatalf("refreshed the static IP %q to %q %v %v", id, d.Id(), d.Get("ip_address"), d.Get("mac_address"))
+	}
+
+	// The reads are retried over a burst of 502.
+	served := len(s.Requests())
+	s.FailRequests("GET", "/vpc/v1/static_ips/"+id, 502, 2)
+	s.FailRequests("GET", "/activity/v1/activities", 502, 2)
+	if err := d.Set("resource_description", "updated"); err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourceVPCStaticIPUpdate(ctx, d, c); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if got := s.Get(fakeapi.StaticIP, id)["resourceDescription"]; got != "updated" {
+		t.Fatalf("resourceDescription = %v, want updated", got)
+	}
+	reads := 0
+	for _, request := range s.Requests()[served:] {
+		if request == "GET /vpc/v1/static_ips/"+id {
+			reads++
+		}
+	}
+	if reads < 3 {
+		t.Fatalf("%d reads of the static IP, want the 2 failed ones retried", reads)
+	}
+
+	s.FailRequests("GET", "/activity/v1/activities", 502, 2)
+	if diags := resourceVPCStaticIPDelete(ctx, d, c); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if s.Len(fakeapi.StaticIP) != 0 {
+		t.Fatal("the static IP must be deleted")
+	}
+}
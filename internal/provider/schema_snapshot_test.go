@@ -21,7 +21,8 @@ package provider
 // plan-constraint lists ConflictsWith/ExactlyOneOf/AtLeastOneOf/RequiredWith,
 // and the explicit Elem kind (nil / value_type:<T> / a recursed resource).
 // Per resource/datasource it also captures SchemaVersion, each StateUpgrader's
-// Version plus a stable cty type representation, and has_customize_diff.
+// Version plus a stable cty type representation, has_customize_diff, and the
+// attributes of the resource identity, which is written to the state too.
 //
 // What it deliberately does NOT capture: Description and other human-text/doc
 // fields (doc churn, not contract), and any func body, pointer or address. A
@@ -92,6 +93,9 @@ type attributeSnapshot struct {
 	Computed  bool `json:"computed,omitempty"`
 	ForceNew  bool `json:"force_new,omitempty"`
 	Sensitive bool `json:"sensitive,omitempty"`
+	WriteOnly bool `json:"write_only,omitempty"`
+	// RequiredForImport is only set on the attributes of a resource identity.
+	RequiredForImport bool `json:"required_for_import,omitempty"`
 
 	MinItems   int    `json:"min_items,omitempty"`
 	MaxItems   int    `json:"max_items,omitempty"`
@@ -143,6 +147,7 @@ type resourceSnapshot struct {
 	HasCustomizeDiff bool                         `json:"has_customize_diff,omitempty"`
 	StateUpgraders   []stateUpgraderSnapshot      `json:"state_upgraders,omitempty"`
 	Schema           map[string]attributeSnapshot `json:"schema"`
+	Identity         map[string]attributeSnapshot `json:"identity,omitempty"`
 }
 
 // providerSnapshot is the whole serialized contract.
@@ -162,6 +167,8 @@ func serializeAttribute(s *schema.Schema) attributeSnapshot {
 		Computed:              s.Computed,
 		ForceNew:              s.ForceNew,
 		Sensitive:             s.Sensitive,
+		WriteOnly:             s.WriteOnly,
+		RequiredForImport:     s.RequiredForImport,
 		MinItems:              s.MinItems,
 		MaxItems:              s.MaxItems,
 		ConfigMode:            configModeString(s.ConfigMode),
@@ -233,6 +240,9 @@ func serializeResource(r *schema.Resource) resourceSnapshot {
 		HasCustomizeDiff: r.CustomizeDiff != nil,
 		Schema:           serializeSchemaMap(r.Schema),
 	}
+	if r.Identity != nil {
+		rs.Identity = serializeSchemaMap(r.Identity.SchemaMap())
+	}
 	for _, su := range r.StateUpgraders {
 		rs.StateUpgraders = append(rs.StateUpgraders, stateUpgraderSnapshot{
 			Version: su.Version,
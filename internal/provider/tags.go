@@ -2,24 +2,182 @@ package provider
 
 import (
 	"context"
+	"strings"
 
 	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// tagsAllSchema is the computed tags_all attribute of the taggable resources:
+// the effective tags, i.e. the provider default_tags merged under the resource's
+// own tags.
+func tagsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Description: "The tags effectively attached to the resource: the provider `default_tags` merged with `tags`, which take precedence on a shared key.",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// expandDefaultTags reads the provider default_tags block.
+func expandDefaultTags(raw []interface{}) map[string]string {
+	if len(raw) == 0 || raw[0] == nil {
+		return nil
+	}
+	tags := map[string]string{}
+	for key, value := range raw[0].(map[string]interface{})["tags"].(map[string]interface{}) {
+		tags[key] = value.(string)
+	}
+	return tags
+}
+
+// defaultTags returns the provider default_tags, if any. meta is nil when the
+// provider is not configured yet (e.g. validation).
+func defaultTags(meta any) map[string]string {
+	c, ok := meta.(*client.Client)
+	if !ok || c == nil {
+		return nil
+	}
+	return c.DefaultTags
+}
+
+// expandIgnoreTags reads the provider ignore_tags block.
+func expandIgnoreTags(raw []interface{}) (keys, keyPrefixes []string) {
+	if len(raw) == 0 || raw[0] == nil {
+		return nil, nil
+	}
+	block := raw[0].(map[string]interface{})
+	for _, key := range block["keys"].(*schema.Set).List() {
+		keys = append(keys, key.(string))
+	}
+	for _, prefix := range block["key_prefixes"].(*schema.Set).List() {
+		keyPrefixes = append(keyPrefixes, prefix.(string))
+	}
+	return keys, keyPrefixes
+}
+
+// ignoredTag reports whether the provider ignore_tags match key: such a tag is
+// owned by another tool, and is neither read into the state nor deleted.
+func ignoredTag(meta any, key string) bool {
+	c, ok := meta.(*client.Client)
+	if !ok || c == nil {
+		return false
+	}
+	for _, ignored := range c.IgnoreTagKeys {
+		if key == ignored {
+			return true
+		}
+	}
+	for _, prefix := range c.IgnoreTagKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutIgnoredTags drops the tags matched by the provider ignore_tags.
+func withoutIgnoredTags(meta any, tags map[string]interface{}) map[string]interface{} {
+	for key := range tags {
+		if ignoredTag(meta, key) {
+			delete(tags, key)
+		}
+	}
+	return tags
+}
+
+// mergeTags returns the effective tags of a resource: the default tags,
+// overridden by the resource's own tags on a shared key.
+func mergeTags(defaults map[string]string, tags map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(defaults)+len(tags))
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, value := range tags {
+		merged[key] = value
+	}
+	return merged
+}
+
+func sameTags(a, b map[string]interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, found := b[key]; !found || other != value {
+			return false
+		}
+	}
+	return true
+}
+
+// customizeDiffTagsAll plans tags_all, so that adding, changing or removing a
+// default tag shows up as a change of every taggable resource and is applied by
+// updateTags.
+func customizeDiffTagsAll(ctx context.Context, diff *schema.ResourceDiff, meta any) error {
+	if !diff.NewValueKnown("tags") {
+		return diff.SetNewComputed("tags_all")
+	}
+	merged := withoutIgnoredTags(meta, mergeTags(defaultTags(meta), diff.Get("tags").(map[string]interface{})))
+	if sameTags(merged, diff.Get("tags_all").(map[string]interface{})) {
+		return nil
+	}
+	return diff.SetNew("tags_all", merged)
+}
+
+// flattenTags splits the tags read on the platform into the resource's own tags
+// and tags_all. A tag carrying a default tag's key and value is left out of tags
+// unless the configuration sets it too, so the defaults do not show up as a
+// drift of every resource. A tag matched by ignore_tags is left out of both.
+func flattenTags(meta any, d *schema.ResourceData, remote []*client.Tag) (tags, all map[string]interface{}) {
+	defaults := defaultTags(meta)
+	configured := d.Get("tags").(map[string]interface{})
+
+	tags = map[string]interface{}{}
+	all = map[string]interface{}{}
+	for _, tag := range remote {
+		if ignoredTag(meta, tag.Key) {
+			continue
+		}
+		all[tag.Key] = tag.Value
+		if value, found := defaults[tag.Key]; found && value == tag.Value {
+			if _, set := configured[tag.Key]; !set {
+				continue
+			}
+		}
+		tags[tag.Key] = tag.Value
+	}
+	return tags, all
+}
+
 func updateTags(ctx context.Context, c *client.Client, d *schema.ResourceData, id, typ, source string) diag.Diagnostics {
-	if !d.HasChange("tags") {
+	if !d.HasChanges("tags", "tags_all") {
 		return nil
 	}
+	return writeTags(ctx, c, d, id, typ, source)
+}
 
-	wanted := d.Get("tags").(map[string]interface{})
+// writeTags makes the tags of the object id those of d, whether they changed or
+// not.
+func writeTags(ctx context.Context, c *client.Client, d *schema.ResourceData, id, typ, source string) diag.Diagnostics {
+	// Diff against the merged tags, so that a default tag removed from the
+	// provider configuration is removed from the resource as well. The tags
+	// matched by ignore_tags belong to other tools: they are never written.
+	wanted := withoutIgnoredTags(c, mergeTags(c.DefaultTags, d.Get("tags").(map[string]interface{})))
 	existing, err := c.Tag().Resource().Read(ctx, id)
 	if err != nil {
 		return diag.Errorf("failed to read tags: %s", err)
 	}
 
 	for _, tag := range existing {
+		if ignoredTag(c, tag.Key) {
+			continue
+		}
+
 		// The tag exists so we check if it has the correct value and if so
 		// we skip updating it
 		if value, found := wanted[tag.Key]; found && tag.Value == value.(string) {
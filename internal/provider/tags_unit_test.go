@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func newTaggableResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"tags_all": tagsAllSchema(),
+		},
+		CustomizeDiff: customizeDiffTagsAll,
+	}
+}
+
+func TestExpandDefaultTags(t *testing.T) {
+	if tags := expandDefaultTags(nil); tags != nil {
+		t.Fatalf("no default_tags block must yield no default tags, got %v", tags)
+	}
+	tags := expandDefaultTags([]interface{}{map[string]interface{}{
+		"tags": map[string]interface{}{"owner": "ops", "env": "prod"},
+	}})
+	if len(tags) != 2 || tags["owner"] != "ops" || tags["env"] != "prod" {
+		t.Fatalf("expandDefaultTags = %v", tags)
+	}
+}
+
+func TestMergeTagsResourceTagsWin(t *testing.T) {
+	merged := mergeTags(
+		map[string]string{"owner": "ops", "env": "prod"},
+		map[string]interface{}{"env": "staging", "app": "web"},
+	)
+	want := map[string]interface{}{"owner": "ops", "env": "staging", "app": "web"}
+	if !sameTags(merged, want) {
+		t.Fatalf("mergeTags = %v, want %v", merged, want)
+	}
+}
+
+func TestCustomizeDiffTagsAll(t *testing.T) {
+	res := newTaggableResource()
+	meta := &client.Client{DefaultTags: map[string]string{"owner": "ops", "env": "prod"}}
+
+	t.Run("the defaults are planned under the resource tags", func(t *testing.T) {
+		diff, err := res.Diff(context.Background(), &terraform.InstanceState{}, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"tags": map[string]interface{}{"env": "staging"},
+		}), meta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for key, want := range map[string]string{"tags_all.%": "2", "tags_all.owner": "ops", "tags_all.env": "staging"} {
+			if a, ok := diff.Attributes[key]; !ok || a.New != want {
+				t.Errorf("%s = %+v, want %q", key, a, want)
+			}
+		}
+	})
+
+	t.Run("a removed default tag is a change", func(t *testing.T) {
+		state := &terraform.InstanceState{ID: "vm-1", Attributes: map[string]string{
+			"tags_all.%":     "3",
+			"tags_all.owner": "ops",
+			"tags_all.env":   "prod",
+			"tags_all.team":  "core",
+		}}
+		diff, err := res.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{}), meta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff == nil {
+			t.Fatal("removing a default tag must plan a change of tags_all")
+		}
+		if a, ok := diff.Attributes["tags_all.team"]; !ok || !a.NewRemoved {
+			t.Fatalf("tags_all.team = %+v, want it removed", a)
+		}
+	})
+
+	t.Run("no change when the effective tags already match", func(t *testing.T) {
+		state := &terraform.InstanceState{ID: "vm-1", Attributes: map[string]string{
+			"tags_all.%":     "2",
+			"tags_all.owner": "ops",
+			"tags_all.env":   "prod",
+		}}
+		diff, err := res.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{}), meta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff != nil && len(diff.Attributes) > 0 {
+			t.Fatalf("unexpected diff: %+v", diff.Attributes)
+		}
+	})
+}
+
+func TestFlattenTags(t *testing.T) {
+	meta := &client.Client{DefaultTags: map[string]string{"owner": "ops", "env": "prod"}}
+	d := schema.TestResourceDataRaw(t, newTaggableResource().Schema, map[string]interface{}{
+		"tags": map[string]interface{}{"owner": "ops"},
+	})
+	remote := []*client.Tag{
+		{Key: "owner", Value: "ops"},  // default, also configured
+		{Key: "env", Value: "prod"},   // default only
+		{Key: "app", Value: "web"},    // resource only
+		{Key: "team", Value: "other"}, // not a default anymore: a drift to clean up
+	}
+
+	tags, all := flattenTags(meta, d, remote)
+	if want := map[string]interface{}{"owner": "ops", "app": "web", "team": "other"}; !sameTags(tags, want) {
+		t.Errorf("tags = %v, want %v", tags, want)
+	}
+	if len(all) != len(remote) {
+		t.Errorf("tags_all = %v, want every tag read", all)
+	}
+}
+
+func TestExpandIgnoreTags(t *testing.T) {
+	if keys, prefixes := expandIgnoreTags(nil); keys != nil || prefixes != nil {
+		t.Fatalf("no ignore_tags block must ignore nothing, got %v %v", keys, prefixes)
+	}
+	block := map[string]interface{}{
+		"keys":         schema.NewSet(schema.HashString, []interface{}{"cmdb_id"}),
+		"key_prefixes": schema.NewSet(schema.HashString, []interface{}{"backup:"}),
+	}
+	keys, prefixes := expandIgnoreTags([]interface{}{block})
+	if len(keys) != 1 || keys[0] != "cmdb_id" || len(prefixes) != 1 || prefixes[0] != "backup:" {
+		t.Fatalf("expandIgnoreTags = %v %v", keys, prefixes)
+	}
+}
+
+func TestFlattenTagsSkipsIgnoredTags(t *testing.T) {
+	meta := &client.Client{IgnoreTagKeys: []string{"cmdb_id"}, IgnoreTagKeyPrefixes: []string{"backup:"}}
+	d := schema.TestResourceDataRaw(t, newTaggableResource().Schema, map[string]interface{}{})
+	tags, all := flattenTags(meta, d, []*client.Tag{
+		{Key: "cmdb_id", Value: "42"},
+		{Key: "backup:policy", Value: "daily"},
+		{Key: "backup", Value: "yes"}, // only the prefix "backup:" is ignored
+		{Key: "app", Value: "web"},
+	})
+	want := map[string]interface{}{"backup": "yes", "app": "web"}
+	if !sameTags(tags, want) || !sameTags(all, want) {
+		t.Fatalf("tags = %v, tags_all = %v, want %v for both", tags, all, want)
+	}
+}
+
+// TestUpdateTagsLeavesIgnoredTagsAlone drives updateTags against a stub tag API:
+// the tags matched by ignore_tags are never deleted, even though the
+// configuration does not declare them.
+func TestUpdateTagsLeavesIgnoredTagsAlone(t *testing.T) {
+	var deleted, created []string
+	c := newAssignTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/tag/v1/tags/resources/vm-1"):
+			_, _ = w.Write([]byte(`[{"key":"backup:policy","value":"daily"},{"key":"cmdb_id","value":"42"},{"key":"owner","value":"dev"},{"key":"stale","value":"x"}]`))
+		case r.Method == http.MethodDelete && strings.Contains(r.URL.Path, "/tag/v1/tags/resources/vm-1/keys/"):
+			deleted = append(deleted, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/tag/v1/tags"):
+			var req client.CreateTagRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			created = append(created, req.Key+"="+req.Value)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+	c.IgnoreTagKeys = []string{"cmdb_id"}
+	c.IgnoreTagKeyPrefixes = []string{"backup:"}
+
+	d := schema.TestResourceDataRaw(t, newTaggableResource().Schema, map[string]interface{}{
+		"tags": map[string]interface{}{"owner": "ops"},
+	})
+	if diags := updateTags(context.Background(), c, d, "vm-1", "iaas_opensource_virtual_machine", "iaas_opensource"); diags.HasError() {
+		t.Fatalf("updateTags: %v", diags)
+	}
+
+	sort.Strings(deleted)
+	if strings.Join(deleted, ",") != "owner,stale" {
+		t.Errorf("deleted = %v, want only owner (wrong value) and stale", deleted)
+	}
+	if strings.Join(created, ",") != "owner=ops" {
+		t.Errorf("created = %v, want owner=ops", created)
+	}
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client/cassette"
+	providerpkg "github.com/cloud-temple/terraform-provider-cloudtemple/internal/provider"
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// testAccMode is set by TestMain from CLOUDTEMPLE_CASSETTE_MODE:
+//   - live (the default) runs the tests against the tenant;
+//   - record runs them against the tenant and saves a cassette per test under
+//     testdata/cassettes;
+//   - replay runs them from the cassettes, without network nor credentials. A
+//     test without a cassette is skipped, unless testAccRecorded lists it.
+var testAccMode = cassette.ModeLive
+
+// testAccFakeAPIEnvName, set in record mode, records the cassettes against an
+// in-process fake of the API (internal/client/fakeapi) rather than a tenant.
+const testAccFakeAPIEnvName = "CLOUDTEMPLE_CASSETTE_FAKE_API"
+
+// testAccRecorded are the tests whose cassette is committed under
+// testdata/cassettes: their replay fails, rather than being skipped, when the
+// cassette is missing.
+var testAccRecorded = map[string]bool{
+	"TestResourceObjectStorageBucketLifecycle":         true,
+	"TestResourceObjectStorageStorageAccountLifecycle": true,
+}
+
+// testAccEnvName matches the environment variables the tests read the
+// identifiers of the tenant from. They are saved in the cassette, and set
+// back during the replay.
+var testAccEnvName = regexp.MustCompile(`^(ACTIVITY|BACKUP|CLOUDTEMPLE_IAAS|COMPTE|COMPUTE|DATACENTER|IAM|MARKETPLACE|OBJECT_STORAGE|PUBLIC_CLOUD|TENANT)_|^SHIVA_TEST_COMPANY_ID$`)
+
+// testAccProviderFactories are used to instantiate a provider during acceptance
+// testing. The factory function will be invoked for every Terraform CLI command
+// executed to create a provider server to which the CLI can reattach.
+//
+// In replay mode it sets the environment saved in the cassette of t, so it must
+// be called before the test reads it.
+func testAccProviderFactories(t *testing.T) map[string]func() (*schema.Provider, error) {
+	t.Helper()
+
+	newProvider := providerpkg.New("dev")
+	if testAccMode != cassette.ModeLive {
+		recorder := testAccCassette(t)
+		newProvider = providerpkg.NewWithTransport("dev", recorder)
+	}
+
+	return map[string]func() (*schema.Provider, error){
+		"cloudtemple": func() (*schema.Provider, error) {
+			p := newProvider()
+			p.Schema["api_suffix"].Default = false
+			return p, nil
+		},
+	}
+}
+
+// testAccRecorders are the recorders of the running tests, so that a test
+// opens its cassette once whatever asks for it first.
+var testAccRecorders sync.Map
+
+func testAccCassette(t *testing.T) *cassette.Recorder {
+	t.Helper()
+	if recorder, ok := testAccRecorders.Load(t); ok {
+		return recorder.(*cassette.Recorder)
+	}
+
+	name := strings.ReplaceAll(t.Name(), "/", "_")
+	path := filepath.Join("testdata", "cassettes", name+".json")
+	recorder, err := cassette.New(path, testAccMode, cleanhttp.DefaultPooledTransport())
+	if cassette.IsNotRecorded(err) {
+		if testAccRecorded[t.Name()] {
+			t.Fatalf("the cassette of %s is missing: record it with `make testrecordfake`", t.Name())
+		}
+		t.Skipf("no cassette recorded for %s", t.Name())
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	testAccRecorders.Store(t, recorder)
+	t.Cleanup(func() { testAccRecorders.Delete(t) })
+
+	if testAccMode == cassette.ModeReplay {
+		for name, value := range recorder.Env() {
+			t.Setenv(name, value)
+		}
+		return recorder
+	}
+
+	for _, env := range os.Environ() {
+		if name, value, _ := strings.Cut(env, "="); testAccEnvName.MatchString(name) {
+			recorder.SetEnv(name, value)
+		}
+	}
+	t.Cleanup(func() {
+		// A skipped or failed run must not replace a good cassette.
+		if t.Skipped() || t.Failed() {
+			return
+		}
+		if err := recorder.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	return recorder
+}
+
+// testAccPATExpirationDate returns the expiration date of the personal access
+// tokens of t, a day from now. The date is saved in the cassette, so that a
+// replay sends the date that was recorded.
+func testAccPATExpirationDate(t *testing.T) string {
+	t.Helper()
+	const name = "PAT_EXPIRATION_DATE"
+
+	switch testAccMode {
+	case cassette.ModeReplay:
+		return testAccCassette(t).Env()[name]
+	case cassette.ModeRecord:
+		date := time.Now().AddDate(0, 0, 1).Format(time.RFC3339)
+		testAccCassette(t).SetEnv(name, date)
+		return date
+	default:
+		return time.Now().AddDate(0, 0, 1).Format(time.RFC3339)
+	}
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceActivities(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceActivities,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith(
+						"data.cloudtemple_activities.foo",
+						"activities.#",
+						func(value string) error {
+							count, err := strconv.Atoi(value)
+							if err != nil {
+								return fmt.Errorf("failed to parse activities count: %s", err)
+							}
+							if count <= 0 {
+								return fmt.Errorf("expected activities list to be non-empty, got %d items", count)
+							}
+							return nil
+						},
+					),
+					resource.TestCheckResourceAttrSet("data.cloudtemple_activities.foo", "activities.0.id"),
+					resource.TestCheckResourceAttrSet("data.cloudtemple_activities.foo", "activities.0.type"),
+					resource.TestCheckResourceAttrSet("data.cloudtemple_activities.foo", "activities.0.creation_date"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccDataSourceActivitiesConcernedItem, os.Getenv(OpenIaaSVirtualMachineId)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.cloudtemple_activities.foo", "activities.#"),
+				),
+			},
+			{
+				Config: testAccDataSourceActivitiesEmptyWindow,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudtemple_activities.foo", "activities.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceActivities = `
+data "cloudtemple_activities" "foo" {}
+`
+
+const testAccDataSourceActivitiesConcernedItem = `
+data "cloudtemple_activities" "foo" {
+  concerned_item_id = "%s"
+  state             = "completed"
+}
+`
+
+const testAccDataSourceActivitiesEmptyWindow = `
+data "cloudtemple_activities" "foo" {
+  created_after  = "1999-01-01T00:00:00Z"
+  created_before = "1999-01-02T00:00:00Z"
+}
+`
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const ActivityId = "ACTIVITY_ID"
+
+func TestAccDataSourceActivity(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccDataSourceActivity, os.Getenv(ActivityId)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudtemple_activity.foo", "id", os.Getenv(ActivityId)),
+					resource.TestCheckResourceAttrSet("data.cloudtemple_activity.foo", "type"),
+					resource.TestCheckResourceAttrSet("data.cloudtemple_activity.foo", "creation_date"),
+					resource.TestCheckResourceAttrSet("data.cloudtemple_activity.foo", "state.#"),
+					resource.TestCheckResourceAttrSet("data.cloudtemple_activity.foo", "state.0.name"),
+				),
+			},
+			{
+				Config:      testAccDataSourceActivityMissing,
+				ExpectError: regexp.MustCompile("failed to find activity with id"),
+			},
+		},
+	})
+}
+
+const testAccDataSourceActivity = `
+data "cloudtemple_activity" "foo" {
+  id = "%s"
+}
+`
+
+const testAccDataSourceActivityMissing = `
+data "cloudtemple_activity" "foo" {
+  id = "12345678-1234-5678-1234-567812345678"
+}
+`
@@ -16,7 +16,7 @@ const (
 func TestAccDataSourceOpenIaaSBackup(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(testAccDataSourceOpenIaaSBackup, os.Getenv(OpenIaaSBackupId)),
@@ -18,7 +18,7 @@ const (
 func TestAccDataSourceOpenIaaSBackupPolicy(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(testAccDataSourceOpenIaaSBackupPolicy, os.Getenv(OpenIaaSBackupPolicyId)),
@@ -11,7 +11,7 @@ import (
 func TestAccDataSourceBackupSLAPolicies(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceBackupSLAPolicies,
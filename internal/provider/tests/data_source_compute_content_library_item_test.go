@@ -20,7 +20,7 @@ const (
 func TestAccDataSourceLibraryItem(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(testAccDataSourceLibraryItem, os.Getenv(ContentLibraryName), os.Getenv(ContentLibraryItemId)),
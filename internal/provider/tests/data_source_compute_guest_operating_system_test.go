@@ -16,7 +16,7 @@ const (
 func TestAccDataSourceGuestOperatingSystem(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(testAccDataSourceGuestOperatingSystem, os.Getenv(OperatingSystemMoRef), os.Getenv(HostClusterId)),
@@ -12,7 +12,7 @@ import (
 func TestAccDataSourceNetworkAdapters(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(testAccDataSourceNetworkAdapters, os.Getenv(VirtualMachineId)),
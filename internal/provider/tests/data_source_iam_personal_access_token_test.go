@@ -5,21 +5,19 @@ import (
 	"os"
 	"regexp"
 	"testing"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccDataSourcePersonalAccessToken(t *testing.T) {
-
-	expirationDate := time.Now().AddDate(0, 0, 1)
+	expirationDate := testAccPATExpirationDate(t)
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
-				Config: fmt.Sprintf(testAccDataSourcePersonalAccessToken, expirationDate.Format(time.RFC3339), os.Getenv(RoleId)),
+				Config: fmt.Sprintf(testAccDataSourcePersonalAccessToken, expirationDate, os.Getenv(RoleId)),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet("data.cloudtemple_iam_personal_access_token.foo", "id"),
 					resource.TestCheckResourceAttrSet("data.cloudtemple_iam_personal_access_token.foo", "name"),
@@ -28,7 +26,7 @@ func TestAccDataSourcePersonalAccessToken(t *testing.T) {
 				),
 			},
 			{
-				Config: fmt.Sprintf(testAccDataSourcePersonalAccessTokenName, expirationDate.Format(time.RFC3339), os.Getenv(RoleId)),
+				Config: fmt.Sprintf(testAccDataSourcePersonalAccessTokenName, expirationDate, os.Getenv(RoleId)),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet("data.cloudtemple_iam_personal_access_token.foo", "id"),
 					resource.TestCheckResourceAttrSet("data.cloudtemple_iam_personal_access_token.foo", "name"),
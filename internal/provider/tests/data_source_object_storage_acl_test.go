@@ -10,6 +10,7 @@ import (
 )
 
 func TestAccDataSourceObjectStorageACL_ByBucket(t *testing.T) {
+	providerFactories := testAccProviderFactories(t)
 	bucketName := os.Getenv("OBJECT_STORAGE_BUCKET_NAME")
 	if bucketName == "" {
 		t.Skip("OBJECT_STORAGE_BUCKET_NAME not set")
@@ -30,6 +31,7 @@ func TestAccDataSourceObjectStorageACL_ByBucket(t *testing.T) {
 }
 
 func TestAccDataSourceObjectStorageACL_ByStorageAccount(t *testing.T) {
+	providerFactories := testAccProviderFactories(t)
 	storageAccountName := os.Getenv("OBJECT_STORAGE_STORAGE_ACCOUNT_NAME")
 	if storageAccountName == "" {
 		t.Skip("OBJECT_STORAGE_STORAGE_ACCOUNT_NAME not set")
@@ -52,7 +54,7 @@ func TestAccDataSourceObjectStorageACL_ByStorageAccount(t *testing.T) {
 func TestAccDataSourceObjectStorageACL_NeitherParameter(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config:      testAccDataSourceObjectStorageACLNeitherParameter,
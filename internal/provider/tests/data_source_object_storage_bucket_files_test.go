@@ -9,6 +9,7 @@ import (
 )
 
 func TestAccDataSourceObjectStorageBucketFiles(t *testing.T) {
+	providerFactories := testAccProviderFactories(t)
 	bucketName := os.Getenv("OBJECT_STORAGE_BUCKET_NAME")
 	if bucketName == "" {
 		t.Skip("OBJECT_STORAGE_BUCKET_NAME not set")
@@ -29,6 +30,7 @@ func TestAccDataSourceObjectStorageBucketFiles(t *testing.T) {
 }
 
 func TestAccDataSourceObjectStorageBucketFiles_WithFolderPath(t *testing.T) {
+	providerFactories := testAccProviderFactories(t)
 	bucketName := os.Getenv("OBJECT_STORAGE_BUCKET_NAME")
 	if bucketName == "" {
 		t.Skip("OBJECT_STORAGE_BUCKET_NAME not set")
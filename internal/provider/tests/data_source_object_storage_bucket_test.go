@@ -16,7 +16,7 @@ const (
 func TestAccDataSourceObjectStorageBucket(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheckObjectStorageBucket(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(testAccDataSourceObjectStorageBucket, os.Getenv(testBucketNameEnvName)),
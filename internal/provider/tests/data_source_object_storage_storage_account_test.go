@@ -16,7 +16,7 @@ const (
 func TestAccDataSourceObjectStorageStorageAccount(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheckObjectStorageStorageAccount(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(testAccDataSourceObjectStorageStorageAccount, os.Getenv(testStorageAccountNameEnvName)),
@@ -17,7 +17,7 @@ const (
 func TestAccDataSourcePublicCloudVMAvailabilityZone(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(testAccDataSourcePublicCloudVMAvailabilityZone, os.Getenv(PublicCloudVMAvailabilityZoneId)),
@@ -9,7 +9,7 @@ import (
 func TestAccDataSourcePublicCloudVMAvailabilityZones(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourcePublicCloudVMAvailabilityZones,
@@ -16,7 +16,7 @@ const (
 func TestAccDataSourcePublicCloudVMBackupPolicy(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(testAccDataSourcePublicCloudVMBackupPolicyName, os.Getenv(PublicCloudVMBackupPolicyName)),
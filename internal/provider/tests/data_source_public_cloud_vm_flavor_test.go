@@ -16,7 +16,7 @@ const (
 func TestAccDataSourcePublicCloudVMFlavor(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(testAccDataSourcePublicCloudVMFlavorName, os.Getenv(PublicCloudVMFlavorName)),
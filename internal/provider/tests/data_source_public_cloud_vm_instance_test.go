@@ -14,7 +14,7 @@ const PublicCloudVMInstanceId = "PUBLIC_CLOUD_VM_INSTANCE_ID"
 func TestAccDataSourcePublicCloudVMInstance(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(testAccDataSourcePublicCloudVMInstance, os.Getenv(PublicCloudVMInstanceId)),
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceResourcesByTag(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccDataSourceResourcesByTag, os.Getenv(VirtualMachineId)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudtemple_resources_by_tag.foo", "resources.#", "1"),
+					resource.TestCheckResourceAttr("data.cloudtemple_resources_by_tag.foo", "resources.0.id", os.Getenv(VirtualMachineId)),
+					resource.TestCheckResourceAttr("data.cloudtemple_resources_by_tag.foo", "resources.0.type", "cloudtemple_compute_virtual_machine"),
+					resource.TestCheckResourceAttr("data.cloudtemple_resources_by_tag.foo", "ids.0", os.Getenv(VirtualMachineId)),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceResourcesByTag = `
+resource "cloudtemple_tag" "foo" {
+  resource_id   = "%s"
+  resource_type = "vcenter_virtual_machine"
+  source        = "vmware"
+  key           = "acc_test_resources_by_tag"
+  value         = "found"
+}
+
+data "cloudtemple_resources_by_tag" "foo" {
+  key   = cloudtemple_tag.foo.key
+  value = cloudtemple_tag.foo.value
+  types = ["cloudtemple_compute_virtual_machine"]
+}
+`
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceTags(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccDataSourceTags, os.Getenv(VirtualMachineId)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudtemple_tags.foo", "id", os.Getenv(VirtualMachineId)),
+					resource.TestCheckResourceAttrSet("data.cloudtemple_tags.foo", "tags.%"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceTags = `
+data "cloudtemple_tags" "foo" {
+  resource_id = "%s"
+}
+`
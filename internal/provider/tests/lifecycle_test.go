@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// testLifecycle drives the create, updates and delete of one resource through
+// the gRPC server of the provider, as Terraform does, but without the
+// Terraform CLI: its cassette replays anywhere `go test` runs.
+type testLifecycle struct {
+	t        *testing.T
+	server   tfprotov5.ProviderServer
+	typeName string
+	resource *schema.Resource
+
+	state    *tfprotov5.DynamicValue
+	private  []byte
+	identity *tfprotov5.ResourceIdentityData
+}
+
+// newTestLifecycle configures a provider on the cassette of t, as
+// testAccProviderFactories does, for the lifecycle of typeName.
+func newTestLifecycle(t *testing.T, typeName string) *testLifecycle {
+	t.Helper()
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+	p, err := testAccProviderFactories(t)["cloudtemple"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := &testLifecycle{
+		t:        t,
+		server:   schema.NewGRPCProviderServer(p),
+		typeName: typeName,
+		resource: p.ResourcesMap[typeName],
+	}
+	if l.resource == nil {
+		t.Fatalf("no resource %s", typeName)
+	}
+
+	block := schema.InternalMap(p.Schema).CoreConfigSchema()
+	config := testLifecycleObject(block.ImpliedType(), map[string]cty.Value{"api_suffix": cty.False})
+	b, err := msgpack.Marshal(config, block.ImpliedType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := l.server.ConfigureProvider(context.Background(), &tfprotov5.ConfigureProviderRequest{
+		Config: &tfprotov5.DynamicValue{MsgPack: b},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.requireNoError("configure", resp.Diagnostics)
+	return l
+}
+
+// testLifecycleObject is an object of type ty setting values only, its nested
+// blocks empty.
+func testLifecycleObject(ty cty.Type, values map[string]cty.Value) cty.Value {
+	attributes := map[string]cty.Value{}
+	for name, attributeType := range ty.AttributeTypes() {
+		switch {
+		case values[name] != cty.NilVal:
+			attributes[name] = values[name]
+		case attributeType.IsListType():
+			attributes[name] = cty.ListValEmpty(attributeType.ElementType())
+		case attributeType.IsSetType():
+			attributes[name] = cty.SetValEmpty(attributeType.ElementType())
+		default:
+			attributes[name] = cty.NullVal(attributeType)
+		}
+	}
+	return cty.ObjectVal(attributes)
+}
+
+// apply plans and applies the configuration setting values, a create the first
+// time and an update afterwards, then refreshes the resource as the plan that
+// follows an apply does. It returns the refreshed state.
+func (l *testLifecycle) apply(values map[string]cty.Value) cty.Value {
+	l.t.Helper()
+	ctx := context.Background()
+	ty := l.resource.CoreConfigSchema().ImpliedType()
+	config := testLifecycleObject(ty, values)
+
+	// The proposed state keeps the computed attributes the configuration does
+	// not set, as Terraform does.
+	proposed := config
+	prior := cty.NullVal(ty)
+	if l.state != nil {
+		prior = l.value(l.state)
+		attributes := config.AsValueMap()
+		for name, s := range l.resource.SchemaMap() {
+			if s.Computed && attributes[name].IsNull() {
+				attributes[name] = prior.GetAttr(name)
+			}
+		}
+		proposed = cty.ObjectVal(attributes)
+	}
+
+	planned, err := l.server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+		TypeName:         l.typeName,
+		PriorState:       l.dynamicValue(prior),
+		ProposedNewState: l.dynamicValue(proposed),
+		Config:           l.dynamicValue(config),
+		PriorPrivate:     l.private,
+		PriorIdentity:    l.identity,
+	})
+	if err != nil {
+		l.t.Fatal(err)
+	}
+	l.requireNoError("plan", planned.Diagnostics)
+
+	applied, err := l.server.ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{
+		TypeName:        l.typeName,
+		PriorState:      l.dynamicValue(prior),
+		PlannedState:    planned.PlannedState,
+		Config:          l.dynamicValue(config),
+		PlannedPrivate:  planned.PlannedPrivate,
+		PlannedIdentity: planned.PlannedIdentity,
+	})
+	if err != nil {
+		l.t.Fatal(err)
+	}
+	l.requireNoError("apply", applied.Diagnostics)
+	l.state, l.private, l.identity = applied.NewState, applied.Private, applied.NewIdentity
+
+	read, err := l.server.ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName:        l.typeName,
+		CurrentState:    l.state,
+		Private:         l.private,
+		CurrentIdentity: l.identity,
+	})
+	if err != nil {
+		l.t.Fatal(err)
+	}
+	l.requireNoError("refresh", read.Diagnostics)
+	l.state, l.private, l.identity = read.NewState, read.Private, read.NewIdentity
+
+	state := l.value(l.state)
+	if state.IsNull() {
+		l.t.Fatal("the refresh dropped the resource")
+	}
+	return state
+}
+
+// destroy plans and applies the deletion of the resource.
+func (l *testLifecycle) destroy() {
+	l.t.Helper()
+	ctx := context.Background()
+	ty := l.resource.CoreConfigSchema().ImpliedType()
+	null := l.dynamicValue(cty.NullVal(ty))
+
+	planned, err := l.server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+		TypeName:         l.typeName,
+		PriorState:       l.state,
+		ProposedNewState: null,
+		Config:           null,
+		PriorPrivate:     l.private,
+		PriorIdentity:    l.identity,
+	})
+	if err != nil {
+		l.t.Fatal(err)
+	}
+	l.requireNoError("destroy plan", planned.Diagnostics)
+
+	applied, err := l.server.ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{
+		TypeName:        l.typeName,
+		PriorState:      l.state,
+		PlannedState:    planned.PlannedState,
+		Config:          null,
+		PlannedPrivate:  planned.PlannedPrivate,
+		PlannedIdentity: planned.PlannedIdentity,
+	})
+	if err != nil {
+		l.t.Fatal(err)
+	}
+	l.requireNoError("destroy", applied.Diagnostics)
+	if !l.value(applied.NewState).IsNull() {
+		l.t.Fatal("the resource is still in the state after its deletion")
+	}
+	l.state = nil
+}
+
+func (l *testLifecycle) dynamicValue(v cty.Value) *tfprotov5.DynamicValue {
+	l.t.Helper()
+	b, err := msgpack.Marshal(v, l.resource.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		l.t.Fatal(err)
+	}
+	return &tfprotov5.DynamicValue{MsgPack: b}
+}
+
+func (l *testLifecycle) value(v *tfprotov5.DynamicValue) cty.Value {
+	l.t.Helper()
+	value, err := msgpack.Unmarshal(v.MsgPack, l.resource.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		l.t.Fatal(err)
+	}
+	return value
+}
+
+func (l *testLifecycle) requireNoError(step string, diags []*tfprotov5.Diagnostic) {
+	l.t.Helper()
+	for _, d := range diags {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			l.t.Fatalf("%s %s: %s: %s", step, l.typeName, d.Summary, d.Detail)
+		}
+	}
+}
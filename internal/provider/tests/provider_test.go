@@ -3,12 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
 	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client"
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client/cassette"
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/client/fakeapi"
 	providerpkg "github.com/cloud-temple/terraform-provider-cloudtemple/internal/provider"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -28,6 +31,25 @@ func TestMain(m *testing.M) {
 	// 	log.Fatal("Error loading .env file")
 	// }
 
+	mode, err := cassette.ParseMode(os.Getenv(cassette.ModeEnvName))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+		os.Exit(1)
+	}
+	testAccMode = mode
+	if testAccMode == cassette.ModeReplay {
+		// The replay needs neither credentials nor a tenant to clean.
+		for _, name := range []string{client.HTTPClientIDEnvName, client.HTTPClientSecretEnvName} {
+			if os.Getenv(name) == "" {
+				os.Setenv(name, "replay")
+			}
+		}
+		os.Exit(m.Run())
+	}
+	if testAccMode == cassette.ModeRecord && os.Getenv(testAccFakeAPIEnvName) != "" {
+		os.Exit(recordAgainstFakeAPI(m))
+	}
+
 	envNames := []string{
 		testClientIDEnvName,
 		testSecretIDEnvName,
@@ -45,7 +67,11 @@ func TestMain(m *testing.M) {
 		os.Exit(1)
 	}
 
-	config := client.DefaultConfig()
+	config, err := client.DefaultConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+		os.Exit(1)
+	}
 	config.ClientID = os.Getenv(testClientIDEnvName)
 	config.SecretID = os.Getenv(testSecretIDEnvName)
 
@@ -144,15 +170,29 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
-// providerFactories are used to instantiate a provider during acceptance testing.
-// The factory function will be invoked for every Terraform CLI command executed
-// to create a provider server to which the CLI can reattach.
-var providerFactories = map[string]func() (*schema.Provider, error){
-	"cloudtemple": func() (*schema.Provider, error) {
-		p := providerpkg.New("dev")()
-		p.Schema["api_suffix"].Default = false
-		return p, nil
-	},
+// recordAgainstFakeAPI runs the tests against a fake of the API, which needs
+// neither credentials nor a tenant to clean. Its activities stay running for
+// one read, so the cassettes record the polling of each of them.
+func recordAgainstFakeAPI(m *testing.M) int {
+	fake := fakeapi.NewServer()
+	defer fake.Close()
+	fake.SetRunningReads(1)
+
+	address, err := url.Parse(fake.URL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+		return 1
+	}
+	config := fake.Config()
+	for name, value := range map[string]string{
+		client.HTTPAddrEnvName:         address.Host,
+		client.HTTPSchemeEnvName:       address.Scheme,
+		client.HTTPClientIDEnvName:     config.ClientID,
+		client.HTTPClientSecretEnvName: config.SecretID,
+	} {
+		os.Setenv(name, value)
+	}
+	return m.Run()
 }
 
 func TestProvider(t *testing.T) {
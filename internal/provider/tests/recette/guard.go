@@ -77,7 +77,10 @@ func assertRecetteTenant(expected, actual string) error {
 // environment by the caller (CI environment secrets or a sourced, gitignored
 // .env.recette), never loaded from a committed file.
 func newRecetteClient() (*client.Client, error) {
-	config := client.DefaultConfig()
+	config, err := client.DefaultConfig()
+	if err != nil {
+		return nil, err
+	}
 	if config.ClientID == "" || config.SecretID == "" {
 		return nil, fmt.Errorf(
 			"%s and %s must be set to authenticate the recette harness",
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccResourceOpenIaaSSnapshot snapshots an existing Open IaaS virtual
+// machine (COMPUTE_IAAS_OPENSOURCE_VIRTUAL_MACHINE_ID) with its memory, reverts
+// it through revert_trigger, imports the snapshot and destroys it.
+func TestAccResourceOpenIaaSSnapshot(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccResourceOpenIaaSSnapshotConfig, os.Getenv(OpenIaaSVirtualMachineId), "initial"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("cloudtemple_compute_iaas_opensource_snapshot.test", "id"),
+					resource.TestCheckResourceAttr("cloudtemple_compute_iaas_opensource_snapshot.test", "name", "tf-acc-snapshot"),
+					resource.TestCheckResourceAttr("cloudtemple_compute_iaas_opensource_snapshot.test", "description", "Terraform acceptance test"),
+					resource.TestCheckResourceAttr("cloudtemple_compute_iaas_opensource_snapshot.test", "save_memory", "true"),
+					resource.TestCheckResourceAttrSet("cloudtemple_compute_iaas_opensource_snapshot.test", "create_time"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccResourceOpenIaaSSnapshotConfig, os.Getenv(OpenIaaSVirtualMachineId), "reverted"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudtemple_compute_iaas_opensource_snapshot.test", "revert_trigger", "reverted"),
+				),
+			},
+			{
+				ResourceName:            "cloudtemple_compute_iaas_opensource_snapshot.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"revert_trigger", "save_memory"},
+			},
+		},
+	})
+}
+
+const testAccResourceOpenIaaSSnapshotConfig = `
+resource "cloudtemple_compute_iaas_opensource_snapshot" "test" {
+  virtual_machine_id = "%s"
+  name               = "tf-acc-snapshot"
+  description        = "Terraform acceptance test"
+  save_memory        = true
+  revert_trigger     = "%s"
+}
+`
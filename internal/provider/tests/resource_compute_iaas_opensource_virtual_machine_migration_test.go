@@ -31,6 +31,7 @@ import (
 //     and the post-step plan is non-empty (the same host_id diff reappears) —
 //     resource.Test fails on both counts. So this step goes RED without the fix.
 func TestAccResourceIaasOpensourceVirtualMachineHostMigration(t *testing.T) {
+	providerFactories := testAccProviderFactories(t)
 	hostA := os.Getenv("COMPUTE_IAAS_OPENSOURCE_HOST_A_ID")
 	hostB := os.Getenv("COMPUTE_IAAS_OPENSOURCE_HOST_B_ID")
 	if hostA == "" || hostB == "" {
@@ -18,7 +18,7 @@ import (
 func TestAccResourceIaasOpensourceVirtualMachineHostPlacementPoweredOffRejected(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(
@@ -34,7 +34,7 @@ func TestAccResourceIaasOpensourceVirtualMachineHostPlacementPoweredOffRejected(
 func TestAccResourceIaasOpensourceVirtualMachine(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(
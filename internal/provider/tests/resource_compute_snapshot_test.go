@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccResourceComputeSnapshot snapshots an existing VMware virtual machine
+// (COMPUTE_VIRTUAL_MACHINE_ID), reverts it through revert_trigger, imports the
+// snapshot and destroys it.
+func TestAccResourceComputeSnapshot(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccResourceComputeSnapshotConfig, os.Getenv(VirtualMachineId), "initial"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("cloudtemple_compute_snapshot.test", "id"),
+					resource.TestCheckResourceAttr("cloudtemple_compute_snapshot.test", "name", "tf-acc-snapshot"),
+					resource.TestCheckResourceAttrSet("cloudtemple_compute_snapshot.test", "create_time"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccResourceComputeSnapshotConfig, os.Getenv(VirtualMachineId), "reverted"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudtemple_compute_snapshot.test", "revert_trigger", "reverted"),
+				),
+			},
+			{
+				ResourceName:            "cloudtemple_compute_snapshot.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"revert_trigger"},
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["cloudtemple_compute_snapshot.test"]
+					return rs.Primary.Attributes["virtual_machine_id"] + "/" + rs.Primary.ID, nil
+				},
+			},
+		},
+	})
+}
+
+const testAccResourceComputeSnapshotConfig = `
+resource "cloudtemple_compute_snapshot" "test" {
+  virtual_machine_id = "%s"
+  name               = "tf-acc-snapshot"
+  revert_trigger     = "%s"
+}
+`
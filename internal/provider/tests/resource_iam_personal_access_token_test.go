@@ -4,18 +4,15 @@ import (
 	"fmt"
 	"os"
 	"testing"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccResourcePersonalAccessToken(t *testing.T) {
-	// current date + 1 day
-	t1 := time.Now().AddDate(0, 0, 1)
-	expirationDate := t1.Format(time.RFC3339)
+	expirationDate := testAccPATExpirationDate(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(testAccResourcePersonalAccessToken, expirationDate, os.Getenv(RoleId)),
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const (
+	MarketplaceItemId = "MARKETPLACE_ITEM_ID"
+)
+
+// TestAccResourceMarketplaceDeployment deploys the marketplace item
+// MARKETPLACE_ITEM_ID on Open IaaS (COMPUTE_IAAS_OPENSOURCE_STORAGE_REPOSITORY_ID)
+// and destroys every virtual machine it created.
+func TestAccResourceMarketplaceDeployment(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccResourceMarketplaceDeploymentOpenIaaS, os.Getenv(MarketplaceItemId), os.Getenv(OpenIaaSStorageRepositoryId)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("cloudtemple_marketplace_deployment.test", "id"),
+					resource.TestCheckResourceAttr("cloudtemple_marketplace_deployment.test", "target", "openiaas"),
+					resource.TestCheckResourceAttrSet("cloudtemple_marketplace_deployment.test", "virtual_machine_ids.0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccResourceMarketplaceDeploymentOpenIaaS = `
+resource "cloudtemple_marketplace_deployment" "test" {
+  marketplace_item_id = "%s"
+  target              = "openiaas"
+  name                = "tf-acc-marketplace-deployment"
+
+  openiaas {
+    storage_repository_id = "%s"
+  }
+}
+`
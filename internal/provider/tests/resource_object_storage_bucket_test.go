@@ -3,13 +3,14 @@ package provider
 import (
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccResourceObjectStorageBucket_Private(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceObjectStorageBucketPrivate,
@@ -27,7 +28,7 @@ func TestAccResourceObjectStorageBucket_Private(t *testing.T) {
 func TestAccResourceObjectStorageBucket_CustomWithWhitelist(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceObjectStorageBucketCustom,
@@ -44,7 +45,7 @@ func TestAccResourceObjectStorageBucket_CustomWithWhitelist(t *testing.T) {
 func TestAccResourceObjectStorageBucket_WithVersioning(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceObjectStorageBucketVersioning,
@@ -63,6 +64,30 @@ func TestAccResourceObjectStorageBucket_WithVersioning(t *testing.T) {
 	})
 }
 
+// TestResourceObjectStorageBucketLifecycle creates a bucket, enables then
+// suspends its versioning and deletes it, each write waiting on its activity.
+func TestResourceObjectStorageBucketLifecycle(t *testing.T) {
+	l := newTestLifecycle(t, "cloudtemple_object_storage_bucket")
+	config := map[string]cty.Value{
+		"name":        cty.StringVal("terraform-test-bucket-lifecycle"),
+		"access_type": cty.StringVal("private"),
+	}
+
+	state := l.apply(config)
+	if state.GetAttr("id").AsString() == "" || state.GetAttr("endpoint").AsString() == "" {
+		t.Fatalf("the created bucket has no id or endpoint: %#v", state)
+	}
+
+	for _, versioning := range []string{"Enabled", "Suspended"} {
+		config["versioning"] = cty.StringVal(versioning)
+		if got := l.apply(config).GetAttr("versioning").AsString(); got != versioning {
+			t.Fatalf("versioning = %q, want %q", got, versioning)
+		}
+	}
+
+	l.destroy()
+}
+
 const testAccResourceObjectStorageBucketPrivate = `
 resource "cloudtemple_object_storage_bucket" "test" {
   name        = "terraform-test-bucket-private"
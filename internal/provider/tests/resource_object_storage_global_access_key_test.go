@@ -14,7 +14,7 @@ resource "cloudtemple_object_storage_global_access_key" "test" {
 func TestAccResourceObjectStorageGlobalAccessKey(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceObjectStorageGlobalAccessKey,
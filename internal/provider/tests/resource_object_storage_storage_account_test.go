@@ -3,13 +3,14 @@ package provider
 import (
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccResourceObjectStorageStorageAccount_Basic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceObjectStorageStorageAccountBasic,
@@ -24,6 +25,21 @@ func TestAccResourceObjectStorageStorageAccount_Basic(t *testing.T) {
 	})
 }
 
+// TestResourceObjectStorageStorageAccountLifecycle creates a storage account,
+// whose secret access key is only returned by its creation, and deletes it.
+func TestResourceObjectStorageStorageAccountLifecycle(t *testing.T) {
+	l := newTestLifecycle(t, "cloudtemple_object_storage_storage_account")
+
+	state := l.apply(map[string]cty.Value{"name": cty.StringVal("terraform-test-storage-account-lifecycle")})
+	for _, name := range []string{"id", "access_key_id", "access_secret_key"} {
+		if state.GetAttr(name).IsNull() || state.GetAttr(name).AsString() == "" {
+			t.Fatalf("the created storage account has no %s", name)
+		}
+	}
+
+	l.destroy()
+}
+
 const testAccResourceObjectStorageStorageAccountBasic = `
 resource "cloudtemple_object_storage_storage_account" "test" {
   name = "terraform-test-storage-account"
@@ -15,7 +15,7 @@ import (
 func TestAccResourcePublicCloudVMInstanceDisk(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(testAccResourcePublicCloudVMDiskConfig, os.Getenv(PublicCloudVMInstanceId)),
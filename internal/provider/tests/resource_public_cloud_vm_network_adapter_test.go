@@ -17,7 +17,7 @@ import (
 func TestAccResourcePublicCloudVMNetworkAdapter(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(testAccResourcePublicCloudVMNetworkAdapterConfig, os.Getenv(PublicCloudVMInstanceId), os.Getenv(PublicCloudVMNetworkId)),
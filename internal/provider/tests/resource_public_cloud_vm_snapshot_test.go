@@ -14,7 +14,7 @@ import (
 func TestAccResourcePublicCloudVMInstanceSnapshot(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(testAccResourcePublicCloudVMSnapshotConfig, os.Getenv(PublicCloudVMInstanceId)),
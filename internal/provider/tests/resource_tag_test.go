@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceTag(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccResourceTag, os.Getenv(VirtualMachineId), "1234"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudtemple_tag.foo", "id", os.Getenv(VirtualMachineId)+"/acc_test_cost_center"),
+					resource.TestCheckResourceAttr("cloudtemple_tag.foo", "value", "1234"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccResourceTag, os.Getenv(VirtualMachineId), "5678"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudtemple_tag.foo", "value", "5678"),
+					resource.TestCheckResourceAttr("data.cloudtemple_tags.foo", "tags.acc_test_cost_center", "5678"),
+				),
+			},
+			{
+				ResourceName:      "cloudtemple_tag.foo",
+				ImportState:       true,
+				ImportStateId:     os.Getenv(VirtualMachineId) + "/vcenter_virtual_machine/vmware/acc_test_cost_center",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccResourceTag = `
+resource "cloudtemple_tag" "foo" {
+  resource_id   = "%s"
+  resource_type = "vcenter_virtual_machine"
+  source        = "vmware"
+  key           = "acc_test_cost_center"
+  value         = "%s"
+}
+
+data "cloudtemple_tags" "foo" {
+  resource_id = cloudtemple_tag.foo.resource_id
+}
+`
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/provider/helpers"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The cloud-init keys of the cloud_init_wo block, by attribute: a map cannot be
+// write-only, so the block holds one write-only attribute per key.
+var (
+	vmwareCloudInitKeys = map[string]string{
+		"user_data":      "user-data",
+		"network_config": "network-config",
+		"public_keys":    "public-keys",
+		"instance_id":    "instance-id",
+		"password":       "password",
+		"hostname":       "hostname",
+		"seedfrom":       "seedfrom",
+	}
+	noCloudInitKeys = map[string]string{
+		"cloud_config":   "cloud_config",
+		"network_config": "network_config",
+	}
+)
+
+// cloudInitWriteOnlySchema is the write-only counterpart of the cloud_init map:
+// the values reach the create request but are never written to the state.
+func cloudInitWriteOnlySchema(keys map[string]string, forceNew bool) *schema.Schema {
+	attributes := make(map[string]*schema.Schema, len(keys))
+	names := make([]string, 0, len(keys))
+	for attribute, key := range keys {
+		names = append(names, "`"+attribute+"`")
+		attributes[attribute] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			WriteOnly:   true,
+			Description: fmt.Sprintf("The value of the `%s` cloud-init key, as in `cloud_init`.", key),
+		}
+	}
+	sort.Strings(names)
+
+	apply := "bump `cloud_init_wo_version` to apply a new payload"
+	if !forceNew {
+		apply = "bump `cloud_init_wo_version` to record a new payload; like `cloud_init`, it only applies to a new virtual machine"
+	}
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		MaxItems:      1,
+		Optional:      true,
+		ForceNew:      forceNew,
+		ConflictsWith: []string{"cloud_init"},
+		Description:   fmt.Sprintf("The write-only alternative to `cloud_init` (Terraform 1.11+): the payload is sent at creation but never written to the plan or the state. Takes %s. Since Terraform cannot see a change of these values, %s.", strings.Join(names, ", "), apply),
+		Elem: &schema.Resource{
+			Schema: attributes,
+		},
+	}
+}
+
+// cloudInitWriteOnlyVersionSchema is the trigger of a new cloud_init_wo
+// payload. forceNew must be the one of the resource's cloud_init, so bumping
+// the version does what editing cloud_init does.
+func cloudInitWriteOnlyVersionSchema(forceNew bool) *schema.Schema {
+	description := "Any value, to change when the payload of `cloud_init_wo` changes. cloud-init only runs at the first boot: changing it replaces the virtual machine."
+	if !forceNew {
+		description = "Any value, to change when the payload of `cloud_init_wo` changes. cloud-init only runs at the first boot: like a change of `cloud_init`, changing it updates the virtual machine in place without running cloud-init again."
+	}
+	return &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ForceNew:     forceNew,
+		RequiredWith: []string{"cloud_init_wo"},
+		Description:  description,
+	}
+}
+
+// writeOnlyCloudInit returns the cloud_init_wo block as a cloud_init map. It is
+// empty outside of a plan or an apply.
+func writeOnlyCloudInit(d *schema.ResourceData, keys map[string]string) map[string]interface{} {
+	cloudInit := map[string]interface{}{}
+	block := cty.GetAttrPath("cloud_init_wo").IndexInt(0)
+	for attribute, key := range keys {
+		if value := helpers.GetWriteOnlyString(d, block.GetAttr(attribute)); value != "" {
+			cloudInit[key] = value
+		}
+	}
+	return cloudInit
+}
+
+// cloudInitConfig merges cloud_init and cloud_init_wo, which conflict: at most
+// one of them holds values.
+func cloudInitConfig(d *schema.ResourceData, keys map[string]string) map[string]interface{} {
+	cloudInit := map[string]interface{}{}
+	for key, value := range d.Get("cloud_init").(map[string]interface{}) {
+		cloudInit[key] = value
+	}
+	for key, value := range writeOnlyCloudInit(d, keys) {
+		cloudInit[key] = value
+	}
+	return cloudInit
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/provider/helpers"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestCloudInitConfigReadsTheWriteOnlyBlock(t *testing.T) {
+	d := resourceOpenIaasVirtualMachine().Data(&terraform.InstanceState{
+		RawConfig: cty.ObjectVal(map[string]cty.Value{
+			"cloud_init": cty.NullVal(cty.Map(cty.String)),
+			"cloud_init_wo": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"cloud_config":   cty.StringVal("#cloud-config"),
+				"network_config": cty.NullVal(cty.String),
+			})}),
+		}),
+	})
+
+	cloudInit := cloudInitConfig(d, noCloudInitKeys)
+	if len(cloudInit) != 1 || cloudInit["cloud_config"] != "#cloud-config" {
+		t.Fatalf("cloudInitConfig = %v, want only cloud_config", cloudInit)
+	}
+}
+
+func TestWriteOnlyCloudInitUsesTheCloudInitKeys(t *testing.T) {
+	d := resourceVirtualMachine().Data(&terraform.InstanceState{
+		RawConfig: cty.ObjectVal(map[string]cty.Value{
+			"cloud_init_wo": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"user_data":   cty.StringVal("I2Nsb3VkLWNvbmZpZw=="),
+				"public_keys": cty.StringVal("ssh-ed25519 AAAA"),
+			})}),
+		}),
+	})
+
+	cloudInit := writeOnlyCloudInit(d, vmwareCloudInitKeys)
+	if len(cloudInit) != 2 || cloudInit["user-data"] != "I2Nsb3VkLWNvbmZpZw==" || cloudInit["public-keys"] != "ssh-ed25519 AAAA" {
+		t.Fatalf("writeOnlyCloudInit = %v", cloudInit)
+	}
+}
+
+// TestWriteOnlyValuesAreEmptyWithoutConfiguration: a refresh or an import has
+// no configuration, the write-only values are then simply absent.
+func TestWriteOnlyValuesAreEmptyWithoutConfiguration(t *testing.T) {
+	d := resourceVirtualMachine().Data(&terraform.InstanceState{})
+	if cloudInit := writeOnlyCloudInit(d, vmwareCloudInitKeys); len(cloudInit) != 0 {
+		t.Fatalf("writeOnlyCloudInit = %v, want it empty", cloudInit)
+	}
+	if v := helpers.GetWriteOnlyString(d, cty.GetAttrPath("cloud_init_wo").IndexInt(0).GetAttr("user_data")); v != "" {
+		t.Fatalf("GetWriteOnlyString = %q, want it empty", v)
+	}
+}
+
+func TestGuestOSCustomizationUsesTheWriteOnlyPasswords(t *testing.T) {
+	windowsConfig := cty.ObjectVal(map[string]cty.Value{
+		"password":    cty.NullVal(cty.String),
+		"password_wo": cty.StringVal("local-secret"),
+		"domain": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"admin_password":    cty.NullVal(cty.String),
+			"admin_password_wo": cty.StringVal("domain-secret"),
+		})}),
+	})
+	d := resourceVirtualMachine().Data(&terraform.InstanceState{
+		Attributes: map[string]string{
+			"customize.#":                                          "1",
+			"customize.0.network_config.#":                         "1",
+			"customize.0.network_config.0.hostname":                "web",
+			"customize.0.network_config.0.domain":                  "example.com",
+			"customize.0.windows_config.#":                         "1",
+			"customize.0.windows_config.0.domain.#":                "1",
+			"customize.0.windows_config.0.domain.0.name":           "example.com",
+			"customize.0.windows_config.0.domain.0.admin_username": "admin",
+		},
+		RawConfig: cty.ObjectVal(map[string]cty.Value{
+			"customize": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"windows_config": cty.ListVal([]cty.Value{windowsConfig}),
+			})}),
+		}),
+	})
+
+	req := helpers.BuildGuestOSCustomizationRequest(t.Context(), d)
+	if req.WindowsConfig == nil || req.WindowsConfig.Password != "local-secret" || req.WindowsConfig.DomainAdminPassword != "domain-secret" {
+		t.Fatalf("windows config = %+v, want the write-only passwords", req.WindowsConfig)
+	}
+}
+
+// TestCloudInitWriteOnlyVersionFollowsCloudInit: bumping cloud_init_wo_version
+// must do what editing cloud_init does, replace the virtual machine or not.
+func TestCloudInitWriteOnlyVersionFollowsCloudInit(t *testing.T) {
+	for name, resource := range map[string]*schema.Resource{
+		"cloudtemple_compute_virtual_machine":                 resourceVirtualMachine(),
+		"cloudtemple_compute_iaas_opensource_virtual_machine": resourceOpenIaasVirtualMachine(),
+		"cloudtemple_public_cloud_vm_instance":                resourcePublicCloudVMInstance(),
+	} {
+		cloudInit := resource.Schema["cloud_init"].ForceNew
+		if got := resource.Schema["cloud_init_wo_version"].ForceNew; got != cloudInit {
+			t.Errorf("%s: cloud_init_wo_version ForceNew = %v, cloud_init ForceNew = %v", name, got, cloudInit)
+		}
+		if got := resource.Schema["cloud_init_wo"].ForceNew; got != cloudInit {
+			t.Errorf("%s: cloud_init_wo ForceNew = %v, cloud_init ForceNew = %v", name, got, cloudInit)
+		}
+	}
+}
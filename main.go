@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"log"
 
 	"github.com/cloud-temple/terraform-provider-cloudtemple/internal/provider"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 )
 
 // Run "go generate" to format example terraform files and generate the docs for the registry/website
@@ -32,11 +34,18 @@ func main() {
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := &plugin.ServeOpts{
-		Debug:        debugMode,
-		ProviderAddr: "registry.terraform.io/Cloud-Temple/cloudtemple",
-		ProviderFunc: provider.New(version),
+	server, err := provider.NewProviderServer(context.Background(), version)
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	plugin.Serve(opts)
+	var opts []tf5server.ServeOpt
+	if debugMode {
+		opts = append(opts, tf5server.WithManagedDebug())
+	}
+
+	err = tf5server.Serve("registry.terraform.io/Cloud-Temple/cloudtemple", server, opts...)
+	if err != nil {
+		log.Fatal(err)
+	}
 }